@@ -1,19 +1,32 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	"wallet_create_address/pkg/chain"
+	"wallet_create_address/pkg/matcher"
+	"wallet_create_address/pkg/output"
+	"wallet_create_address/pkg/wallet"
 )
 
 // App 应用程序结构
 type App struct {
-	config *Config
+	config     *Config
+	configPath string
 }
 
 // NewApp 创建新的应用实例
-func NewApp(config *Config) *App {
+func NewApp(config *Config, configPath string) *App {
 	return &App{
-		config: config,
+		config:     config,
+		configPath: configPath,
 	}
 }
 
@@ -51,14 +64,17 @@ func (app *App) Run() {
 
 // generateSingleWallet 生成单个钱包
 func (app *App) generateSingleWallet(useMnemonic bool) {
-	generator := NewWalletGenerator(app.config)
+	generator := wallet.NewWalletGenerator()
 
-	config := GeneratorConfig{
+	opts := app.config.WalletOptions(wallet.Options{
 		Count:       1,
 		UseMnemonic: useMnemonic,
-	}
+	})
 
-	wallets := generator.GenerateWallets(config)
+	wallets, err := generator.GenerateWallets(context.Background(), opts)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
 	if len(wallets) > 0 {
 		PrintWallet(wallets[0])
 	}
@@ -66,8 +82,6 @@ func (app *App) generateSingleWallet(useMnemonic bool) {
 
 // generateBatchWallets 批量生成钱包
 func (app *App) generateBatchWallets() {
-	generator := NewWalletGenerator(app.config)
-
 	var count, workers int
 	var useMnemonic string
 
@@ -85,116 +99,281 @@ func (app *App) generateBatchWallets() {
 	fmt.Print("使用助记词? (y/n): ")
 	fmt.Scanln(&useMnemonic)
 
-	config := GeneratorConfig{
+	opts := wallet.Options{
 		Count:          count,
 		UseMnemonic:    useMnemonic == "y" || useMnemonic == "Y",
 		ConcurrentMode: true,
 		WorkerCount:    workers,
 	}
 
-	result := generator.GenerateBatch(config)
-
-	fmt.Printf("\n✅ 成功生成 %d 个钱包，耗时: %v\n", len(result.Wallets), result.Duration)
-	fmt.Printf("平均每个钱包耗时: %v\n", result.AvgTime)
-	fmt.Printf("生成速度: %.2f 钱包/秒\n", result.WalletsPerSec)
+	// Ctrl+C 时停止生成，已生成的钱包保持已写入状态
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
 
-	// 显示前N个钱包
+	// 只预览前N个钱包，不在内存中保留全部结果
 	previewCount := app.config.Output.PreviewCount
-	for i, wallet := range result.Wallets {
-		if i >= previewCount {
-			fmt.Printf("... 还有 %d 个钱包\n", len(result.Wallets)-previewCount)
-			break
+	result, err := runBatch(ctx, app.config, opts, func(w wallet.MultiChainWallet) error {
+		if previewCount > 0 {
+			PrintWalletSimple(w)
+			previewCount--
 		}
-		PrintWalletSimple(wallet)
+		return nil
+	}, os.Stdout)
+
+	if err != nil {
+		fmt.Printf("\n⚠️  生成已中止: %v\n", err)
+	}
+	printBatchSummary(os.Stdout, app.config, result)
+
+	if remaining := result.Count - app.config.Output.PreviewCount; remaining > 0 {
+		fmt.Printf("... 还有 %d 个钱包\n", remaining)
 	}
 }
 
-// 存储钱包到文件
-func saveWalletsToFile(isMnemonic bool, chain string, multiChainWallet MultiChainWallet, s string) error {
-	file, err := os.OpenFile(s, os.O_APPEND|os.O_WRONLY, 0600)
-	if err != nil {
-		// 如果文件不存在，创建新文件
-		file, err = os.Create(s)
+// runBatch 批量生成钱包（菜单与命令行共用）
+// 启用地址匹配时只保留匹配的钱包；按配置写入输出文件，并把每个钱包交给 emit；过程信息写到 msg
+func runBatch(ctx context.Context, config *Config, opts wallet.Options, emit func(wallet.MultiChainWallet) error, msg io.Writer) (*wallet.GenerationResult, error) {
+	opts = config.WalletOptions(opts)
+
+	// 启用地址匹配时，只保留匹配的钱包
+	var addressMatcher *matcher.AddressMatcher
+	if config.AddressMatching.Enabled {
+		var err error
+		addressMatcher, err = matcher.NewAddressMatcher(config.MatcherConfig())
 		if err != nil {
-			return fmt.Errorf("创建文件失败: %v", err)
+			return &wallet.GenerationResult{}, fmt.Errorf("创建地址匹配器失败: %v", err)
 		}
+		opts.Filter = addressMatcher.MatchWallet
+		opts.MaxAttempts = config.AddressMatching.MaxAttempts
 	}
-	// 文件已存在,逐行写入
-	defer file.Close()
-	address := ""
-	switch chain {
-	case "eth":
-	case "polygon":
-	case "bsc":
-		address = multiChainWallet.EthAddress
-	case "btc":
-		address = multiChainWallet.BtcAddress
-	case "tron":
-		address = multiChainWallet.TronAddress
-	case "all":
-		address = multiChainWallet.EthAddress + " " + multiChainWallet.BtcAddress + " " + multiChainWallet.TronAddress
-	default:
-		return fmt.Errorf("未知链类型: %s", chain)
+
+	// 输出目标链
+	chainName := chain.All
+	if addressMatcher != nil {
+		chainName = addressMatcher.TargetChain()
 	}
 
-	if isMnemonic {
-		// 如果是助记词模式，写入地址和助记词
-		_, err = file.WriteString(fmt.Sprintf("钱包地址: %s>>>助记词: %s\n", address, multiChainWallet.Mnemonic))
-		if err != nil {
-			return fmt.Errorf("写入文件失败: %v", err)
+	writer, err := openOutputFile(config, chainName, opts.UseMnemonic)
+	if err != nil {
+		return &wallet.GenerationResult{}, err
+	}
+	if writer != nil {
+		defer writer.Close()
+	}
+
+	generator := wallet.NewWalletGenerator()
+	result, err := generator.GenerateBatch(ctx, opts, func(w wallet.MultiChainWallet) error {
+		if writer != nil {
+			if err := writer.Write(w); err != nil {
+				return err
+			}
 		}
-	} else {
-		// 如果是随机钱包模式，只写入地址和私钥
-		_, err = file.WriteString(fmt.Sprintf("钱包地址: %s>>>私钥: %s\n", address, multiChainWallet.PrivateKey))
+		return emit(w)
+	}, func(err error) {
+		fmt.Fprintf(msg, "⚠️  %v\n", err)
+	})
+
+	// 显示匹配统计
+	if addressMatcher != nil {
+		FprintMatchStats(msg, addressMatcher.GetStats())
+	}
+
+	return result, err
+}
+
+// openOutputFile 按配置打开输出文件，未启用 output.save_to_file 时返回 nil
+func openOutputFile(config *Config, chainName string, useMnemonic bool) (output.OutputWriter, error) {
+	if !config.Output.SaveToFile || config.Output.OutputFile == "" {
+		return nil, nil
+	}
+	outputOpts, err := outputOptions(config, chainName, useMnemonic)
+	if err != nil {
+		return nil, err
+	}
+	return output.OpenFile(config.Output.OutputFile, outputOpts)
+}
+
+// outputOptions 按配置生成输出文件选项，启用加密或输出 keystore 时获取口令
+func outputOptions(config *Config, chainName string, useMnemonic bool) (output.Options, error) {
+	opts := config.OutputOptions(chainName, useMnemonic)
+	if config.Output.Encrypt || opts.Format == output.FormatKeystore {
+		passphrase, err := outputPassphrase()
 		if err != nil {
-			return fmt.Errorf("写入文件失败: %v", err)
+			return opts, err
 		}
+		opts.Passphrase = passphrase
+	}
+	return opts, nil
+}
+
+// printBatchSummary 打印批量生成统计
+func printBatchSummary(w io.Writer, config *Config, result *wallet.GenerationResult) {
+	fmt.Fprintf(w, "\n✅ 成功生成 %d 个钱包，耗时: %v\n", result.Count, result.Duration)
+	if result.Failed > 0 {
+		fmt.Fprintf(w, "失败 %d 个\n", result.Failed)
+	}
+	fmt.Fprintf(w, "平均每个钱包耗时: %v\n", result.AvgTime)
+	fmt.Fprintf(w, "生成速度: %.2f 钱包/秒\n", result.WalletsPerSec)
+	if config.Output.SaveToFile && config.Output.OutputFile != "" {
+		fmt.Fprintf(w, "已保存到 %s\n", config.Output.OutputFile)
 	}
-	return nil
 }
 
 // deriveFromMnemonic 从指定助记词派生多个地址
 func (app *App) deriveFromMnemonic() {
-	generator := NewWalletGenerator(app.config)
-
-	var mnemonic string
-	var count int
+	generator := wallet.NewWalletGenerator()
 
-	fmt.Print("输入助记词: ")
-	fmt.Scanln(&mnemonic)
-	fmt.Print("派生地址数量: ")
-	fmt.Scanln(&count)
+	mnemonic, err := readLine("输入助记词: ")
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
+	countText, _ := readLine("派生地址数量: ")
+	count, _ := strconv.Atoi(countText)
 
-	config := GeneratorConfig{
+	opts := app.config.WalletOptions(wallet.Options{
 		Count:       count,
 		UseMnemonic: true,
 		Mnemonic:    mnemonic,
-	}
+	})
 
-	wallets := generator.GenerateWallets(config)
+	wallets, err := generator.GenerateWallets(context.Background(), opts)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
 	fmt.Printf("\n✅ 从助记词派生了 %d 个地址\n", len(wallets))
 
-	for _, wallet := range wallets {
-		PrintWalletSimple(wallet)
+	for _, w := range wallets {
+		PrintWalletSimple(w)
 	}
 }
 
 // runAddressMatching 运行地址匹配模式
 func (app *App) runAddressMatching() {
 	if !app.config.AddressMatching.Enabled {
-		fmt.Println("❌ 地址匹配功能未启用，请在config.yaml中配置")
+		fmt.Println("❌ 地址匹配功能未启用，请在配置文件中配置")
+		return
+	}
+
+	// Ctrl+C 时停止匹配
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	result, err := runMatching(ctx, app.config, app.config.GetOptimalWorkerCount(), func(w wallet.MultiChainWallet) error {
+		PrintWalletSimple(w)
+		return nil
+	}, os.Stdout)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
 		return
 	}
 
-	matcher := NewMatchingService(app.config)
-	result := matcher.RunMatching()
+	for _, w := range result.Wallets {
+		PrintWalletSimple(w)
+	}
+}
+
+// runMatching 运行地址匹配服务（菜单与命令行共用）
+// 匹配结果按配置写入输出文件并交给 emit，稀有地址写入单独的文件；过程信息写到 msg
+func runMatching(ctx context.Context, config *Config, workerCount int, emit func(wallet.MultiChainWallet) error, msg io.Writer) (*matcher.MatchingResult, error) {
+	rules := config.AddressMatching.Rules
+	fmt.Fprintln(msg, "🎯 地址匹配模式")
+	fmt.Fprintf(msg, "匹配规则: 前缀=%v, 后缀=%v, 包含=%v\n", rules.Prefixes, rules.Suffixes, rules.Contains)
+	if rules.Regex != "" {
+		fmt.Fprintf(msg, "正则表达式: %s\n", rules.Regex)
+	}
+	fmt.Fprintf(msg, "目标链: %v\n", config.AddressMatching.TargetChains)
+	fmt.Fprintf(msg, "最大尝试次数: %d\n", config.AddressMatching.MaxAttempts)
+
+	rarityChain := config.RarityChain()
+	if rarityChain != "" {
+		rarity := config.Rarity
+		fmt.Fprintf(msg, "稀有地址捕获: 链=%s, 连号>=%d, 序列>=%d, 回文尾>=%d, 数字尾>=%d -> %s\n",
+			rarityChain, rarity.MinRepeatRun, rarity.MinSequence,
+			rarity.MinPalindromeTail, rarity.MinDigitTail, rarity.OutputFile)
+	}
 
-	fmt.Printf("\n🏁 匹配完成，耗时: %v\n", result.Duration)
-	fmt.Printf("找到 %d 个匹配地址\n", len(result.Wallets))
+	useMnemonic := config.Generator.UseMnemonic
+	outputCfg := config.Output
 
-	for _, wallet := range result.Wallets {
-		PrintWalletSimple(wallet)
+	// 匹配结果输出文件
+	var writer output.OutputWriter
+	if outputCfg.SaveToFile && outputCfg.OutputFile != "" {
+		chainName := config.AddressMatching.TargetChains[0]
+		outputOpts, err := outputOptions(config, chainName, useMnemonic)
+		if err != nil {
+			return nil, err
+		}
+		writer, err = output.OpenFile(outputCfg.OutputFile, outputOpts)
+		if err != nil {
+			return nil, err
+		}
+		defer writer.Close()
 	}
+
+	// 稀有地址输出文件
+	var rareWriter *output.TextWriter
+	if rarityChain != "" {
+		outputOpts, err := outputOptions(config, rarityChain, useMnemonic)
+		if err != nil {
+			return nil, err
+		}
+		// 稀有地址始终写为文本，仅在启用加密时加密（keystore 口令不用于该文件）
+		if !config.Output.Encrypt {
+			outputOpts.Passphrase = ""
+		}
+		rareWriter, err = output.OpenTextFile(config.Rarity.OutputFile, outputOpts)
+		if err != nil {
+			return nil, err
+		}
+		defer rareWriter.Close()
+	}
+
+	service, err := matcher.NewMatchingService(matcher.ServiceConfig{
+		Matcher:       config.MatcherConfig(),
+		WorkerCount:   workerCount,
+		MaxMatch:      config.AddressMatching.MaxMatch,
+		RarityChain:   rarityChain,
+		Rarity:        config.Rarity.RarityThresholds,
+		StatsInterval: 5 * time.Second,
+	}, matcher.Callbacks{
+		OnMatch: func(w wallet.MultiChainWallet, count int) {
+			fmt.Fprintf(msg, "✅ 找到匹配地址! (#%d)\n", count)
+			// 储存地址
+			if writer != nil {
+				if err := writer.Write(w); err != nil {
+					fmt.Fprintf(msg, "保存钱包到文件失败: %v\n", err)
+				}
+			}
+			if err := emit(w); err != nil {
+				fmt.Fprintf(msg, "输出钱包失败: %v\n", err)
+			}
+		},
+		OnRareFind: func(find *matcher.RareFind) {
+			fmt.Fprintf(msg, "💎 发现稀有地址: %s (稀有度 %d: %s)\n",
+				find.Address, find.Score, strings.Join(find.Reasons, ", "))
+			if err := rareWriter.WriteRareFind(find); err != nil {
+				fmt.Fprintf(msg, "保存稀有地址到文件失败: %v\n", err)
+			}
+		},
+		OnStats: func(stats matcher.Stats) {
+			FprintMatchStats(msg, stats)
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("创建匹配服务失败: %v", err)
+	}
+
+	fmt.Fprintf(msg, "\n开始匹配，使用 %d 个协程...\n", workerCount)
+	result := service.Run(ctx)
+
+	fmt.Fprintf(msg, "\n🏁 匹配完成，耗时: %v\n", result.Duration)
+	fmt.Fprintf(msg, "找到 %d 个匹配地址\n", len(result.Wallets))
+	if len(result.RareFinds) > 0 {
+		fmt.Fprintf(msg, "另外捕获 %d 个稀有地址，已保存到 %s\n", len(result.RareFinds), config.Rarity.OutputFile)
+	}
+
+	return result, nil
 }
 
 // runPerformanceBenchmark 运行性能基准测试
@@ -243,7 +422,7 @@ func (app *App) runPerformanceBenchmark() {
 		var save string
 		fmt.Scanln(&save)
 		if save == "y" || save == "Y" {
-			if err := SaveConfig(app.config, "config.yaml"); err != nil {
+			if err := SaveWorkerCount(app.configPath, optimalCount); err != nil {
 				fmt.Printf("❌ 保存配置失败: %v\n", err)
 			} else {
 				fmt.Println("✅ 配置已保存")
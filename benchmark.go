@@ -1,11 +1,16 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"os"
 	"runtime"
 	"sort"
 	"sync"
 	"time"
+
+	"wallet_create_address/pkg/wallet"
 )
 
 // BenchmarkResult 性能测试结果
@@ -21,18 +26,25 @@ type BenchmarkResult struct {
 type PerformanceTester struct {
 	config *Config
 	mutex  sync.RWMutex
+	out    io.Writer
 }
 
 // NewPerformanceTester 创建性能测试器
 func NewPerformanceTester(config *Config) *PerformanceTester {
 	return &PerformanceTester{
 		config: config,
+		out:    os.Stdout,
 	}
 }
 
+// SetOutput 设置测试过程信息的输出位置
+func (pt *PerformanceTester) SetOutput(w io.Writer) {
+	pt.out = w
+}
+
 // RunBenchmark 运行性能基准测试
 func (pt *PerformanceTester) RunBenchmark() (*BenchmarkResult, error) {
-	fmt.Println("🔥 开始性能基准测试...")
+	fmt.Fprintln(pt.out, "🔥 开始性能基准测试...")
 
 	bestResult := &BenchmarkResult{}
 	var results []BenchmarkResult
@@ -42,16 +54,16 @@ func (pt *PerformanceTester) RunBenchmark() (*BenchmarkResult, error) {
 
 	// 测试不同协程数的性能
 	for workers := workerRange.Min; workers <= workerRange.Max; workers += workerRange.Step {
-		fmt.Printf("测试 %d 个协程... ", workers)
+		fmt.Fprintf(pt.out, "测试 %d 个协程... ", workers)
 
 		result, err := pt.benchmarkWorkerCount(workers, testSamples)
 		if err != nil {
-			fmt.Printf("❌ 失败: %v\n", err)
+			fmt.Fprintf(pt.out, "❌ 失败: %v\n", err)
 			continue
 		}
 
 		results = append(results, *result)
-		fmt.Printf("✅ %.2f 钱包/秒\n", result.WalletsPerSec)
+		fmt.Fprintf(pt.out, "✅ %.2f 钱包/秒\n", result.WalletsPerSec)
 
 		// 更新最佳结果
 		if result.WalletsPerSec > bestResult.WalletsPerSec {
@@ -62,7 +74,7 @@ func (pt *PerformanceTester) RunBenchmark() (*BenchmarkResult, error) {
 	// 显示测试结果
 	pt.displayResults(results)
 
-	fmt.Printf("\n🏆 最佳性能: %d 协程, %.2f 钱包/秒\n",
+	fmt.Fprintf(pt.out, "\n🏆 最佳性能: %d 协程, %.2f 钱包/秒\n",
 		bestResult.WorkerCount, bestResult.WalletsPerSec)
 
 	return bestResult, nil
@@ -70,16 +82,19 @@ func (pt *PerformanceTester) RunBenchmark() (*BenchmarkResult, error) {
 
 // benchmarkWorkerCount 测试指定协程数的性能
 func (pt *PerformanceTester) benchmarkWorkerCount(workerCount, sampleCount int) (*BenchmarkResult, error) {
-	generator := NewWalletGenerator(pt.config)
+	generator := wallet.NewWalletGenerator()
 
-	config := GeneratorConfig{
+	opts := wallet.Options{
 		Count:          sampleCount,
 		UseMnemonic:    false,
 		ConcurrentMode: true,
 		WorkerCount:    workerCount,
 	}
 
-	result := generator.GenerateBatch(config)
+	result, err := generator.GenerateBatch(context.Background(), opts, nil, nil)
+	if err != nil {
+		return nil, err
+	}
 
 	return &BenchmarkResult{
 		WorkerCount:   workerCount,
@@ -100,20 +115,20 @@ func (pt *PerformanceTester) displayResults(results []BenchmarkResult) {
 		return results[i].WorkerCount < results[j].WorkerCount
 	})
 
-	fmt.Println("\n📊 性能测试报告:")
-	fmt.Println("================================================================")
-	fmt.Printf("%-10s %-12s %-12s %-15s\n", "协程数", "总时间", "平均时间", "钱包/秒")
-	fmt.Println("================================================================")
+	fmt.Fprintln(pt.out, "\n📊 性能测试报告:")
+	fmt.Fprintln(pt.out, "================================================================")
+	fmt.Fprintf(pt.out, "%-10s %-12s %-12s %-15s\n", "协程数", "总时间", "平均时间", "钱包/秒")
+	fmt.Fprintln(pt.out, "================================================================")
 
 	for _, result := range results {
-		fmt.Printf("%-10d %-12s %-12s %-15.2f\n",
+		fmt.Fprintf(pt.out, "%-10d %-12s %-12s %-15.2f\n",
 			result.WorkerCount,
 			formatDuration(result.TotalTime),
 			formatDuration(result.AvgTime),
 			result.WalletsPerSec,
 		)
 	}
-	fmt.Println("================================================================")
+	fmt.Fprintln(pt.out, "================================================================")
 }
 
 // GetOptimalWorkerCountByBenchmark 通过基准测试获取最优协程数
@@ -124,7 +139,7 @@ func (pt *PerformanceTester) GetOptimalWorkerCountByBenchmark() int {
 
 	result, err := pt.RunBenchmark()
 	if err != nil {
-		fmt.Printf("⚠️  性能测试失败，使用默认协程数: %v\n", err)
+		fmt.Fprintf(pt.out, "⚠️  性能测试失败，使用默认协程数: %v\n", err)
 		return pt.config.GetOptimalWorkerCount()
 	}
 
@@ -37,14 +37,19 @@ if [ $? -eq 0 ]; then
     echo ""
     echo "📁 项目结构:"
     echo "├── main.go          # 程序入口"
-    echo "├── app.go           # 应用主逻辑"
-    echo "├── types.go         # 类型定义"
+    echo "├── app.go           # 交互菜单（CLI）"
     echo "├── config.go        # 配置管理"
-    echo "├── generator.go     # 钱包生成器"
-    echo "├── matcher.go       # 地址匹配器"
-    echo "├── matching.go      # 匹配服务"
     echo "├── benchmark.go     # 性能测试"
-    echo "├── printer.go       # 输出管理"
+    echo "├── printer.go       # 终端输出"
+    echo "├── pkg/chain        # 各链地址编码"
+    echo "├── pkg/wallet       # 钱包生成与派生"
+    echo "├── pkg/matcher      # 地址匹配、稀有度评分、匹配服务"
+    echo "├── pkg/output       # 输出写入器"
+    echo "├── pkg/bip38        # BIP38 加密私钥"
+    echo "├── pkg/slip39       # SLIP-39 Shamir 份额"
+    echo "├── pkg/bip85        # BIP85 确定性子密钥"
+    echo "├── pkg/recovery     # 助记词找回"
+    echo "├── pkg/signing      # 离线签名"
     echo "└── config.yaml      # 配置文件"
 else
     echo "❌ 构建失败！"
@@ -0,0 +1,373 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/term"
+)
+
+// 退出码
+const (
+	exitOK       = 0 // 成功
+	exitError    = 1 // 运行错误
+	exitUsage    = 2 // 参数错误
+	exitNegative = 3 // 执行成功但结果为否定（未找到匹配、地址无效等）
+)
+
+// 输出格式
+const (
+	formatText  = "text"
+	formatJSON  = "json"
+	formatJSONL = "jsonl"
+	formatCSV   = "csv"
+)
+
+// command 子命令
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) int
+}
+
+// commands 全部子命令，与交互菜单的模式一一对应
+var commands = []command{
+	{"generate", "生成钱包（随机/助记词，数量大于1时并发）", runGenerateCommand},
+	{"derive", "从指定助记词派生多个地址", runDeriveCommand},
+	{"watch", "从账户扩展公钥派生观察地址（不接触私钥）", runWatchCommand},
+	{"import", "批量导入已有私钥（十六进制/WIF）和助记词，计算多链地址", runImportCommand},
+	{"descriptors", "输出助记词钱包的比特币输出描述符（importdescriptors）", runDescriptorsCommand},
+	{"match", "地址匹配模式（靓号生成）", runMatchCommand},
+	{"bench", "性能基准测试", runBenchCommand},
+	{"inspect", "查看助记词在指定路径下的钱包", runInspectCommand},
+	{"keyinfo", "查看已有私钥（十六进制/WIF）或助记词路径的全部地址与编码", runKeyInfoCommand},
+	{"recover", "找回缺词、拼错或顺序颠倒的助记词（需一个已知地址）", runRecoverCommand},
+	{"validate", "校验地址并识别所属链，输出解码出的哈希", runValidateCommand},
+	{"convert", "EVM 地址与波场地址（T 开头/41 十六进制）互相转换", runConvertCommand},
+	{"sign-message", "对消息签名证明地址所有权（EIP-191、波场 signMessageV2、BIP-137）", runSignMessageCommand},
+	{"verify-message", "验证消息签名：恢复签名者地址并与给定地址比较", runVerifyMessageCommand},
+	{"sign-typed-data", "EIP-712 结构化数据签名（permit、订单等）", runSignTypedDataCommand},
+	{"sign-tx", "离线签名以太坊交易（EIP-1559/EIP-2930/传统），输出可广播的 RLP 十六进制", runSignTxCommand},
+	{"sign-tron-tx", "离线签名波场交易（TRX 转账、TRC20 转账），输出可广播的 JSON", runSignTronTxCommand},
+	{"psbt", "比特币 PSBT 签名（P2PKH/P2SH-P2WPKH/P2WPKH/P2TR 密钥路径）", runPSBTCommand},
+	{"config", "查看合并后的配置及每项的来源，生成默认配置文件", runConfigCommand},
+	{"decrypt", "解密输出文件（未加密文件按原样输出）", runDecryptCommand},
+	{"cat", "decrypt 的别名", runDecryptCommand},
+	{"bip38", "BIP38 加密私钥：加密、解密、中间码与确认码", runBIP38Command},
+	{"shamir", "SLIP-39 Shamir 份额：拆分与恢复助记词", runShamirCommand},
+	{"bip85", "BIP85 由主助记词派生子助记词、WIF、xprv、十六进制熵与密码", runBIP85Command},
+}
+
+// defaultConfigPath 子命令和交互模式使用的配置文件，可由全局 --config 或 WALLET_CONFIG 指定
+var defaultConfigPath = resolveConfigPath("")
+
+// parseGlobalFlags 解析命令之前的全局参数（目前仅 --config），返回剩余参数
+func parseGlobalFlags(args []string) ([]string, int) {
+	fs := flag.NewFlagSet("wallet_generator", flag.ContinueOnError)
+	fs.StringVar(&defaultConfigPath, "config", defaultConfigPath, "配置文件路径（也可通过 "+configPathEnv+" 指定）")
+	fs.Usage = func() {
+		printUsage(fs.Output())
+		fmt.Fprintln(fs.Output(), "\n全局参数:")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil, exitOK
+		}
+		return nil, exitUsage
+	}
+	return fs.Args(), -1
+}
+
+// RunCLI 执行子命令并返回退出码
+func RunCLI(args []string) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		printUsage(os.Stdout)
+		return exitOK
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			return cmd.run(args[1:])
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "❌ 未知命令: %s\n\n", args[0])
+	printUsage(os.Stderr)
+	return exitUsage
+}
+
+// runSubcommand 分派二级子命令
+func runSubcommand(name string, subcommands []command, args []string) int {
+	if len(args) > 0 {
+		for _, cmd := range subcommands {
+			if cmd.name == args[0] {
+				return cmd.run(args[1:])
+			}
+		}
+	}
+
+	w := os.Stderr
+	code := exitUsage
+	if len(args) > 0 && (args[0] == "-h" || args[0] == "--help" || args[0] == "help") {
+		w, code = os.Stdout, exitOK
+	} else if len(args) > 0 {
+		fmt.Fprintf(w, "❌ 未知命令: %s %s\n\n", name, args[0])
+	}
+	fmt.Fprintf(w, "用法: wallet_generator %s <命令> [参数]\n\n命令:\n", name)
+	for _, cmd := range subcommands {
+		fmt.Fprintf(w, "  %-14s %s\n", cmd.name, cmd.summary)
+	}
+	return code
+}
+
+// printUsage 打印命令列表
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "用法: wallet_generator [--config 文件] [命令] [参数]")
+	fmt.Fprintln(w, "不带命令运行时进入交互菜单。")
+	fmt.Fprintln(w, "\n命令:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-16s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(w, "\n使用 \"wallet_generator <命令> -h\" 查看命令参数。")
+	fmt.Fprintf(w, "配置优先级: 默认值 < 配置文件 < %s* 环境变量 < 命令行参数（--set 键=值）。\n", envPrefix)
+}
+
+// cliFlags 各子命令共用的参数
+type cliFlags struct {
+	fs         *flag.FlagSet
+	configPath string
+	format     string
+	formats    []string
+	sets       settings
+	overrides  []setting
+}
+
+// setting 命令行对配置项的覆盖
+type setting struct {
+	key, value, flag string
+}
+
+// settings 可重复的 --set 键=值 参数
+type settings []setting
+
+func (s *settings) String() string {
+	return ""
+}
+
+func (s *settings) Set(v string) error {
+	key, value, ok := strings.Cut(v, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("格式应为 键=值，如 worker_pool.manual_count=8")
+	}
+	*s = append(*s, setting{key: key, value: value, flag: "--set " + key})
+	return nil
+}
+
+// newCLIFlags 创建子命令参数集，formats 为支持的输出格式，第一个为默认值
+func newCLIFlags(name string, formats ...string) *cliFlags {
+	cf := &cliFlags{
+		fs:      flag.NewFlagSet(name, flag.ContinueOnError),
+		formats: formats,
+	}
+	cf.fs.StringVar(&cf.configPath, "config", defaultConfigPath, "配置文件路径")
+	cf.fs.Var(&cf.sets, "set", "覆盖配置项，格式 键=值（可重复，列表用逗号分隔）")
+	cf.fs.StringVar(&cf.format, "format", formats[0], "输出格式: "+strings.Join(formats, "|"))
+	return cf
+}
+
+// parse 解析参数，返回非负退出码表示应立即退出
+func (cf *cliFlags) parse(args []string) int {
+	if err := cf.fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	for _, f := range cf.formats {
+		if f == cf.format {
+			return -1
+		}
+	}
+	fmt.Fprintf(os.Stderr, "❌ 不支持的输出格式: %s (可选: %s)\n", cf.format, strings.Join(cf.formats, "|"))
+	return exitUsage
+}
+
+// isSet 检查参数是否在命令行中显式指定
+func (cf *cliFlags) isSet(name string) bool {
+	set := false
+	cf.fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// override 把命令参数映射为配置项覆盖，在 loadConfig 时作为命令行层应用
+func (cf *cliFlags) override(key, value, flagName string) {
+	cf.overrides = append(cf.overrides, setting{key: key, value: value, flag: "--" + flagName})
+}
+
+// overrideWorkers 把 --workers 映射为手动协程数，0 表示沿用配置
+func (cf *cliFlags) overrideWorkers(workers int) {
+	if workers > 0 {
+		cf.override("worker_pool.auto_detect", "false", "workers")
+		cf.override("worker_pool.manual_count", strconv.Itoa(workers), "workers")
+	}
+}
+
+// extendedFlags 注册 --extended 和 --network，返回的函数在解析后把显式指定的值作为配置覆盖
+func (cf *cliFlags) extendedFlags() func() {
+	extended := cf.fs.Bool("extended", false, "输出 WIF 与账户级扩展密钥 xprv/xpub/ypub/zpub（默认使用配置 generator.extended_keys）")
+	network := cf.fs.String("network", "", "WIF 与扩展密钥的网络: mainnet|testnet（默认使用配置）")
+	return func() {
+		if cf.isSet("extended") {
+			cf.override("generator.extended_keys", strconv.FormatBool(*extended), "extended")
+		}
+		if *network != "" {
+			cf.override("generator.network", *network, "network")
+		}
+	}
+}
+
+// loadLayered 加载分层配置并应用命令行覆盖（--set 优先于专用参数），返回验证后的结果
+func (cf *cliFlags) loadLayered() (*LayeredConfig, int) {
+	layered, err := LoadLayeredConfig(cf.configPath, os.Environ())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ 加载配置失败: %v\n", err)
+		return nil, exitError
+	}
+	for _, s := range append(cf.overrides, cf.sets...) {
+		if err := layered.Set(s.key, s.value, s.flag); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %s: %v\n", s.flag, err)
+			return nil, exitUsage
+		}
+	}
+	if err := layered.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ 加载配置失败: %v\n", err)
+		return nil, exitError
+	}
+	return layered, -1
+}
+
+// loadConfig 加载 --config 指定的配置文件，并叠加环境变量和命令行参数
+func (cf *cliFlags) loadConfig() (*Config, int) {
+	layered, code := cf.loadLayered()
+	if code >= 0 {
+		return nil, code
+	}
+	return layered.Config, -1
+}
+
+// writeJSON 以缩进格式输出单个 JSON 对象
+func writeJSON(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// writeJSONLine 输出单行 JSON（JSON Lines）
+func writeJSONLine(w io.Writer, v any) error {
+	return json.NewEncoder(w).Encode(v)
+}
+
+// stdinReader 标准输入的共享缓冲读取器
+var stdinReader = bufio.NewReader(os.Stdin)
+
+// readLine 输出提示（到标准错误）后从标准输入读取一整行
+func readLine(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	line, err := stdinReader.ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && line != "") {
+		return "", fmt.Errorf("读取输入失败: %v", err)
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// passphraseEnv 加密输出文件口令的环境变量
+const passphraseEnv = "WALLET_PASSPHRASE"
+
+var (
+	passphraseOnce  sync.Once
+	passphraseValue string
+	passphraseErr   error
+)
+
+// outputPassphrase 获取加密输出的口令：优先使用环境变量，否则交互输入并确认；同一进程只询问一次
+func outputPassphrase() (string, error) {
+	passphraseOnce.Do(func() {
+		if value := os.Getenv(passphraseEnv); value != "" {
+			passphraseValue = value
+			return
+		}
+		passphraseValue, passphraseErr = readPassphrase("输入加密口令: ")
+		if passphraseErr != nil {
+			return
+		}
+		if term.IsTerminal(int(os.Stdin.Fd())) {
+			confirm, err := readPassphrase("再次输入口令: ")
+			if err != nil {
+				passphraseErr = err
+				return
+			}
+			if confirm != passphraseValue {
+				passphraseErr = errors.New("两次输入的口令不一致")
+			}
+		}
+	})
+	return passphraseValue, passphraseErr
+}
+
+// commandPassphrase 获取命令使用的口令：confirm 为 true 时与 outputPassphrase 相同（交互输入需确认），
+// 否则优先使用环境变量，未设置时以 prompt 提示输入一次
+func commandPassphrase(prompt string, confirm bool) (string, error) {
+	if confirm {
+		return outputPassphrase()
+	}
+	if value := os.Getenv(passphraseEnv); value != "" {
+		return value, nil
+	}
+	return readPassphrase(prompt)
+}
+
+// readPassphrase 读取口令：终端中不回显，否则从标准输入读取一行
+func readPassphrase(prompt string) (string, error) {
+	var passphrase string
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		fmt.Fprint(os.Stderr, prompt)
+		data, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", fmt.Errorf("读取口令失败: %v", err)
+		}
+		passphrase = string(data)
+	} else {
+		line, err := readLine(prompt)
+		if err != nil {
+			return "", err
+		}
+		passphrase = line
+	}
+	if passphrase == "" {
+		return "", errors.New("口令不能为空")
+	}
+	return passphrase, nil
+}
+
+// readSecret 读取敏感参数：命令行未指定时从标准输入读取一行，避免出现在 shell 历史中
+func readSecret(value, prompt string) (string, error) {
+	if value != "" {
+		return strings.TrimSpace(value), nil
+	}
+	return readLine(prompt)
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+
+	"wallet_create_address/pkg/bip38"
+	"wallet_create_address/pkg/wallet"
+)
+
+// bip38Commands bip38 的子命令
+var bip38Commands = []command{
+	{"encrypt", "用口令加密比特币私钥（非 EC 乘法模式）", runBIP38Encrypt},
+	{"decrypt", "解密 6P 开头的加密私钥并校验地址", runBIP38Decrypt},
+	{"intermediate", "由口令生成 EC 乘法模式的中间码", runBIP38Intermediate},
+	{"generate", "由中间码生成加密私钥和确认码（无需口令）", runBIP38Generate},
+	{"verify", "用口令验证确认码并输出地址", runBIP38Verify},
+}
+
+// runBIP38Command bip38 子命令：BIP38 加密私钥（纸钱包备份）
+func runBIP38Command(args []string) int {
+	return runSubcommand("bip38", bip38Commands, args)
+}
+
+// bip38Passphrase 获取 BIP38 口令，confirm 为 true 时交互输入需要确认
+func bip38Passphrase(confirm bool) (string, error) {
+	return commandPassphrase("输入 BIP38 口令: ", confirm)
+}
+
+// bip38Result 命令的 JSON 输出
+type bip38Result struct {
+	Encrypted    string `json:"encrypted,omitempty"`
+	Intermediate string `json:"intermediate,omitempty"`
+	Confirmation string `json:"confirmation,omitempty"`
+	WIF          string `json:"wif,omitempty"`
+	PrivateKey   string `json:"private_key,omitempty"`
+	Address      string `json:"address,omitempty"`
+}
+
+// printBIP38Result 按格式输出结果
+func printBIP38Result(format string, result bip38Result) int {
+	if format == formatJSON {
+		if err := writeJSON(os.Stdout, result); err != nil {
+			return exitError
+		}
+		return exitOK
+	}
+	for _, field := range []struct{ name, value string }{
+		{"中间码", result.Intermediate},
+		{"加密私钥", result.Encrypted},
+		{"确认码", result.Confirmation},
+		{"WIF", result.WIF},
+		{"私钥", result.PrivateKey},
+		{"地址", result.Address},
+	} {
+		if field.value != "" {
+			fmt.Printf("%s: %s\n", field.name, field.value)
+		}
+	}
+	return exitOK
+}
+
+// parseBitcoinKey 解析 WIF 或十六进制私钥，十六进制私钥按压缩公钥处理（与生成的 BTC 地址一致）
+func parseBitcoinKey(value string) (*btcec.PrivateKey, bool, error) {
+	if wif, err := btcutil.DecodeWIF(value); err == nil {
+		return wif.PrivKey, wif.CompressPubKey, nil
+	}
+	data, err := hex.DecodeString(strings.TrimPrefix(value, "0x"))
+	if err != nil || len(data) != 32 {
+		return nil, false, errors.New("私钥应为 WIF 或 64 位十六进制")
+	}
+	privateKey, _ := btcec.PrivKeyFromBytes(data)
+	return privateKey, true, nil
+}
+
+func runBIP38Encrypt(args []string) int {
+	cf := newCLIFlags("bip38 encrypt", formatText, formatJSON)
+	keyFlag := cf.fs.String("key", "", "私钥（WIF 或十六进制）；与 --mnemonic 都未指定时从标准输入读取")
+	mnemonicFlag := cf.fs.String("mnemonic", "", "助记词，配合 --path 使用")
+	path := cf.fs.String("path", wallet.DefaultBasePath+"/0", "助记词派生路径")
+	uncompressed := cf.fs.Bool("uncompressed", false, "十六进制或助记词私钥使用未压缩公钥地址")
+	if code := cf.parse(args); code >= 0 {
+		return code
+	}
+
+	var privateKey *btcec.PrivateKey
+	compressed := true
+	if *mnemonicFlag != "" {
+		w, err := wallet.NewWalletGenerator().GenerateWalletFromMnemonicPath(strings.TrimSpace(*mnemonicFlag), *path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			return exitUsage
+		}
+		privateKey, _, _ = parseBitcoinKey(w.PrivateKey)
+	} else {
+		value, err := readSecret(*keyFlag, "输入私钥: ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			return exitError
+		}
+		if privateKey, compressed, err = parseBitcoinKey(value); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			return exitUsage
+		}
+	}
+	if *uncompressed {
+		compressed = false
+	}
+
+	passphrase, err := bip38Passphrase(true)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitError
+	}
+	encrypted, err := bip38.Encrypt(privateKey, passphrase, compressed)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ 加密失败: %v\n", err)
+		return exitError
+	}
+	key, err := bip38.Decrypt(encrypted, passphrase)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ 加密结果校验失败: %v\n", err)
+		return exitError
+	}
+	return printBIP38Result(cf.format, bip38Result{Encrypted: encrypted, Address: key.Address})
+}
+
+func runBIP38Decrypt(args []string) int {
+	cf := newCLIFlags("bip38 decrypt", formatText, formatJSON)
+	showKey := cf.fs.Bool("show-key", true, "输出解密后的私钥；为 false 时只校验口令并输出地址")
+	if code := cf.parse(args); code >= 0 {
+		return code
+	}
+	if cf.fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "用法: wallet_generator bip38 decrypt [--show-key=false] <6P...>")
+		return exitUsage
+	}
+
+	passphrase, err := bip38Passphrase(false)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitError
+	}
+	key, err := bip38.Decrypt(cf.fs.Arg(0), passphrase)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		if errors.Is(err, bip38.ErrWrongPassphrase) {
+			return exitNegative
+		}
+		return exitUsage
+	}
+
+	result := bip38Result{Address: key.Address}
+	if *showKey {
+		if result.WIF, err = key.WIF(); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			return exitError
+		}
+		result.PrivateKey = hex.EncodeToString(key.PrivateKey.Serialize())
+	}
+	return printBIP38Result(cf.format, result)
+}
+
+func runBIP38Intermediate(args []string) int {
+	cf := newCLIFlags("bip38 intermediate", formatText, formatJSON)
+	lot := cf.fs.Int("lot", -1, "批号 0-1048575（与 --sequence 一起指定时写入中间码）")
+	sequence := cf.fs.Int("sequence", -1, "序号 0-4095")
+	if code := cf.parse(args); code >= 0 {
+		return code
+	}
+	useLotSequence := *lot >= 0 || *sequence >= 0
+	if useLotSequence && (*lot < 0 || *sequence < 0) {
+		fmt.Fprintln(os.Stderr, "❌ --lot 和 --sequence 需要同时指定")
+		return exitUsage
+	}
+
+	passphrase, err := bip38Passphrase(true)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitError
+	}
+	code, err := bip38.NewIntermediateCode(passphrase, uint32(max(*lot, 0)), uint32(max(*sequence, 0)), useLotSequence)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitUsage
+	}
+	return printBIP38Result(cf.format, bip38Result{Intermediate: code})
+}
+
+func runBIP38Generate(args []string) int {
+	cf := newCLIFlags("bip38 generate", formatText, formatJSONL)
+	code := cf.fs.String("code", "", "口令持有者提供的中间码（passphrase 开头）")
+	count := cf.fs.Int("count", 1, "生成数量")
+	uncompressed := cf.fs.Bool("uncompressed", false, "使用未压缩公钥地址")
+	if exit := cf.parse(args); exit >= 0 {
+		return exit
+	}
+	if *code == "" || *count < 1 {
+		fmt.Fprintln(os.Stderr, "❌ 需要 --code，且 --count 必须大于0")
+		return exitUsage
+	}
+
+	for i := 0; i < *count; i++ {
+		result, err := bip38.GenerateEncrypted(strings.TrimSpace(*code), !*uncompressed)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			return exitUsage
+		}
+		if cf.format == formatJSONL {
+			if err := writeJSONLine(os.Stdout, result); err != nil {
+				return exitError
+			}
+			continue
+		}
+		fmt.Printf("地址: %s\n加密私钥: %s\n确认码: %s\n\n", result.Address, result.Encrypted, result.Confirmation)
+	}
+	return exitOK
+}
+
+func runBIP38Verify(args []string) int {
+	cf := newCLIFlags("bip38 verify", formatText, formatJSON)
+	if code := cf.parse(args); code >= 0 {
+		return code
+	}
+	if cf.fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "用法: wallet_generator bip38 verify <cfrm38...>")
+		return exitUsage
+	}
+
+	passphrase, err := bip38Passphrase(false)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitError
+	}
+	address, err := bip38.VerifyConfirmation(cf.fs.Arg(0), passphrase)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		if errors.Is(err, bip38.ErrWrongPassphrase) {
+			return exitNegative
+		}
+		return exitUsage
+	}
+	return printBIP38Result(cf.format, bip38Result{Confirmation: cf.fs.Arg(0), Address: address})
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/tyler-smith/go-bip32"
+
+	"wallet_create_address/pkg/bip85"
+	"wallet_create_address/pkg/wallet"
+)
+
+// bip85Commands bip85 的子命令
+var bip85Commands = []command{
+	{"mnemonic", "派生子 BIP39 助记词（任意长度与语言）", runBIP85Mnemonic},
+	{"hex", "派生十六进制熵", runBIP85Hex},
+	{"wif", "派生 WIF 私钥", runBIP85WIF},
+	{"xprv", "派生新的主扩展私钥", runBIP85XPRV},
+	{"password", "派生 Base64 或 Base85 密码", runBIP85Password},
+}
+
+// runBIP85Command bip85 子命令：由主助记词确定性地派生子助记词、密钥与密码
+func runBIP85Command(args []string) int {
+	return runSubcommand("bip85", bip85Commands, args)
+}
+
+// bip85Flags 各 bip85 子命令共用的参数：主密钥来源与序号
+type bip85Flags struct {
+	*cliFlags
+	mnemonic *string
+	xprv     *string
+	index    *int
+}
+
+func newBIP85Flags(name string) *bip85Flags {
+	cf := newCLIFlags("bip85 "+name, formatText, formatJSON)
+	return &bip85Flags{
+		cliFlags: cf,
+		mnemonic: cf.fs.String("mnemonic", "", "主助记词（与 --xprv 都未指定时从标准输入读取）"),
+		xprv:     cf.fs.String("xprv", "", "主扩展私钥，代替助记词"),
+		index:    cf.fs.Int("index", 0, "子密钥序号，不同序号得到互不相关的结果"),
+	}
+}
+
+// masterKey 读取主密钥：--xprv 或助记词（与 GenerateWalletFromMnemonic 使用相同的 BIP32 主密钥）
+func (bf *bip85Flags) masterKey() (*bip32.Key, int) {
+	if *bf.index < 0 {
+		fmt.Fprintln(os.Stderr, "❌ --index 不能为负数")
+		return nil, exitUsage
+	}
+	if *bf.xprv != "" {
+		key, err := bip32.B58Deserialize(*bf.xprv)
+		if err != nil || !key.IsPrivate {
+			fmt.Fprintln(os.Stderr, "❌ --xprv 不是有效的扩展私钥")
+			return nil, exitUsage
+		}
+		return key, -1
+	}
+
+	mnemonic, err := readSecret(*bf.mnemonic, "输入主助记词: ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return nil, exitError
+	}
+	key, err := wallet.MasterKeyFromMnemonic(mnemonic)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return nil, exitUsage
+	}
+	return key, -1
+}
+
+// print 按格式输出派生结果
+func (bf *bip85Flags) print(result bip85.Result, err error) int {
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitUsage
+	}
+	if bf.format == formatJSON {
+		if err := writeJSON(os.Stdout, result); err != nil {
+			return exitError
+		}
+		return exitOK
+	}
+	fmt.Printf("路径: %s\n%s: %s\n", result.Path, result.Application, result.Value)
+	return exitOK
+}
+
+func runBIP85Mnemonic(args []string) int {
+	bf := newBIP85Flags("mnemonic")
+	words := bf.fs.Int("words", 12, "单词数: 12|15|18|21|24")
+	language := bf.fs.String("language", "english", "语言: english|japanese|korean|spanish|chinese_simplified|chinese_traditional|french|italian|czech")
+	if code := bf.parse(args); code >= 0 {
+		return code
+	}
+	lang, err := bip85.LookupLanguage(*language)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitUsage
+	}
+	master, code := bf.masterKey()
+	if code >= 0 {
+		return code
+	}
+	return bf.print(bip85.Mnemonic(master, lang, *words, uint32(*bf.index)))
+}
+
+func runBIP85Hex(args []string) int {
+	bf := newBIP85Flags("hex")
+	numBytes := bf.fs.Int("bytes", 32, "字节数 16-64")
+	if code := bf.parse(args); code >= 0 {
+		return code
+	}
+	master, code := bf.masterKey()
+	if code >= 0 {
+		return code
+	}
+	return bf.print(bip85.Hex(master, *numBytes, uint32(*bf.index)))
+}
+
+func runBIP85WIF(args []string) int {
+	bf := newBIP85Flags("wif")
+	if code := bf.parse(args); code >= 0 {
+		return code
+	}
+	master, code := bf.masterKey()
+	if code >= 0 {
+		return code
+	}
+	return bf.print(bip85.WIF(master, uint32(*bf.index)))
+}
+
+func runBIP85XPRV(args []string) int {
+	bf := newBIP85Flags("xprv")
+	if code := bf.parse(args); code >= 0 {
+		return code
+	}
+	master, code := bf.masterKey()
+	if code >= 0 {
+		return code
+	}
+	return bf.print(bip85.XPRV(master, uint32(*bf.index)))
+}
+
+func runBIP85Password(args []string) int {
+	bf := newBIP85Flags("password")
+	length := bf.fs.Int("length", 21, "密码长度（base64: 20-86，base85: 10-80）")
+	encoding := bf.fs.String("encoding", "base64", "编码: base64|base85")
+	if code := bf.parse(args); code >= 0 {
+		return code
+	}
+	if *encoding != "base64" && *encoding != "base85" {
+		fmt.Fprintf(os.Stderr, "❌ 不支持的编码: %s\n", *encoding)
+		return exitUsage
+	}
+	master, code := bf.masterKey()
+	if code >= 0 {
+		return code
+	}
+	if *encoding == "base85" {
+		return bf.print(bip85.PasswordBase85(master, *length, uint32(*bf.index)))
+	}
+	return bf.print(bip85.PasswordBase64(master, *length, uint32(*bf.index)))
+}
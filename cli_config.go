@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// configCommands config 的子命令
+var configCommands = []command{
+	{"show", "查看合并后的配置，--effective 时标注每项的来源", runConfigShow},
+	{"init", "把默认配置写入配置文件", runConfigInit},
+}
+
+// runConfigCommand config 子命令：查看配置或生成默认配置文件
+func runConfigCommand(args []string) int {
+	return runSubcommand("config", configCommands, args)
+}
+
+// runConfigShow config show：查看合并后的配置
+func runConfigShow(args []string) int {
+	cf := newCLIFlags("config show", formatText, formatJSON)
+	effective := cf.fs.Bool("effective", false, "显示叠加环境变量和命令行参数后的最终配置，并标注每项的来源")
+	if code := cf.parse(args); code >= 0 {
+		return code
+	}
+
+	// 未指定 --effective 时只显示 默认值+配置文件 的结果
+	var layered *LayeredConfig
+	if *effective {
+		var code int
+		if layered, code = cf.loadLayered(); code >= 0 {
+			return code
+		}
+	} else {
+		var err error
+		if layered, err = LoadLayeredConfig(cf.configPath, nil); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ 加载配置失败: %v\n", err)
+			return exitError
+		}
+		if cf.format == formatText {
+			data, err := yaml.Marshal(layered.Config)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "❌ 序列化配置失败: %v\n", err)
+				return exitError
+			}
+			os.Stdout.Write(data)
+			return exitOK
+		}
+	}
+
+	fields := layered.Fields()
+	if cf.format == formatJSON {
+		if err := writeJSON(os.Stdout, fields); err != nil {
+			return exitError
+		}
+		return exitOK
+	}
+
+	fmt.Printf("📋 最终配置（%s）\n", layered.Path)
+	for _, field := range fields {
+		source := field.Source.Layer
+		if field.Source.Detail != "" {
+			source += ": " + field.Source.Detail
+		}
+		fmt.Printf("  %-40s = %-20s [%s]\n", field.Key, formatConfigValue(field.Value), source)
+	}
+	return exitOK
+}
+
+// runConfigInit config init：写入默认配置，已存在的文件需 --force 才会覆盖
+func runConfigInit(args []string) int {
+	cf := newCLIFlags("config init", formatText)
+	force := cf.fs.Bool("force", false, "覆盖已存在的配置文件")
+	if code := cf.parse(args); code >= 0 {
+		return code
+	}
+
+	if _, err := os.Stat(cf.configPath); err == nil && !*force {
+		fmt.Fprintf(os.Stderr, "❌ 配置文件 %s 已存在（使用 --force 覆盖）\n", cf.configPath)
+		return exitUsage
+	}
+	if err := SaveConfig(getDefaultConfig(), cf.configPath); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitError
+	}
+	fmt.Fprintf(os.Stderr, "✅ 已写入默认配置: %s\n", cf.configPath)
+	return exitOK
+}
+
+// formatConfigValue 格式化配置值，列表以逗号连接（与环境变量格式一致）
+func formatConfigValue(v any) string {
+	switch value := v.(type) {
+	case []string:
+		return "[" + strings.Join(value, ",") + "]"
+	case string:
+		return fmt.Sprintf("%q", value)
+	default:
+		return fmt.Sprint(value)
+	}
+}
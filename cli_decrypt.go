@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"wallet_create_address/pkg/output"
+)
+
+// runDecryptCommand decrypt/cat 子命令：解密输出文件并写到标准输出
+// 未加密的文件（text/jsonl/csv/json）按原样输出，便于脚本统一处理
+func runDecryptCommand(args []string) int {
+	cf := newCLIFlags("decrypt", formatText)
+	outPath := cf.fs.String("output", "", "写入指定文件（默认标准输出）")
+	cf.fs.Usage = func() {
+		fmt.Fprintln(cf.fs.Output(), "用法: wallet_generator decrypt [--output 文件] <文件>...")
+		fmt.Fprintf(cf.fs.Output(), "口令来自 %s 环境变量或交互输入。\n", passphraseEnv)
+		cf.fs.PrintDefaults()
+	}
+	if code := cf.parse(args); code >= 0 {
+		return code
+	}
+	if cf.fs.NArg() == 0 {
+		cf.fs.Usage()
+		return exitUsage
+	}
+
+	var dst io.Writer = os.Stdout
+	if *outPath != "" {
+		file, err := os.OpenFile(*outPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ 打开文件失败: %v\n", err)
+			return exitError
+		}
+		defer file.Close()
+		dst = file
+	}
+
+	for _, path := range cf.fs.Args() {
+		if err := decryptFile(dst, path); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %s: %v\n", path, err)
+			if errors.Is(err, output.ErrWrongPassphrase) {
+				return exitNegative
+			}
+			return exitError
+		}
+	}
+	return exitOK
+}
+
+// decryptFile 把文件的明文内容写到 dst
+func decryptFile(dst io.Writer, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	src := bufio.NewReader(file)
+	var r io.Reader = src
+	if output.IsEncrypted(src) {
+		passphrase := os.Getenv(passphraseEnv)
+		if passphrase == "" {
+			if passphrase, err = readPassphrase("输入解密口令: "); err != nil {
+				return err
+			}
+		}
+		if r, err = output.NewDecryptReader(src, passphrase); err != nil {
+			return err
+		}
+	}
+
+	_, err = io.Copy(dst, r)
+	return err
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"wallet_create_address/pkg/wallet"
+)
+
+// formatImport 输出 Bitcoin Core importdescriptors 的请求参数
+const formatImport = "import"
+
+// descriptorsResult descriptors 命令的 JSON 输出
+type descriptorsResult struct {
+	MasterFingerprint string              `json:"master_fingerprint"`
+	Network           string              `json:"network"`
+	Account           int                 `json:"account"`
+	Descriptors       []wallet.Descriptor `json:"descriptors"`
+}
+
+// importRequest importdescriptors 的单个请求
+type importRequest struct {
+	Desc      string `json:"desc"`
+	Timestamp any    `json:"timestamp"` // "now" 或 Unix 时间戳
+	Active    bool   `json:"active"`
+	Internal  bool   `json:"internal"`
+}
+
+// runDescriptorsCommand descriptors 子命令：输出助记词钱包的比特币输出描述符
+func runDescriptorsCommand(args []string) int {
+	cf := newCLIFlags("descriptors", formatText, formatJSON, formatImport)
+	mnemonicFlag := cf.fs.String("mnemonic", "", "助记词（未指定时从标准输入读取）")
+	account := cf.fs.Int("account", 0, "账户编号（路径中的 account'）")
+	network := cf.fs.String("network", "", "网络: mainnet|testnet（默认使用配置 generator.network）")
+	private := cf.fs.Bool("private", false, "使用扩展私钥 xprv（可签名钱包），默认使用 xpub（观察钱包）")
+	timestamp := cf.fs.String("timestamp", "now", "import 格式的重新扫描起点: now 或 Unix 时间戳（0 表示全部扫描）")
+	if code := cf.parse(args); code >= 0 {
+		return code
+	}
+	if *account < 0 {
+		fmt.Fprintln(os.Stderr, "❌ --account 不能为负数")
+		return exitUsage
+	}
+	var rescan any = *timestamp
+	if *timestamp != "now" {
+		n, err := strconv.ParseInt(*timestamp, 10, 64)
+		if err != nil || n < 0 {
+			fmt.Fprintf(os.Stderr, "❌ --timestamp 应为 now 或 Unix 时间戳: %s\n", *timestamp)
+			return exitUsage
+		}
+		rescan = n
+	}
+	if *network != "" {
+		cf.override("generator.network", *network, "network")
+	}
+
+	mnemonic, err := readSecret(*mnemonicFlag, "输入助记词: ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitError
+	}
+
+	config, code := cf.loadConfig()
+	if code >= 0 {
+		return code
+	}
+
+	masterKey, err := wallet.MasterKeyFromMnemonic(mnemonic)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitError
+	}
+	descriptors, err := wallet.AccountDescriptors(masterKey, config.Generator.Network, uint32(*account), *private)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitError
+	}
+
+	switch cf.format {
+	case formatImport:
+		// bitcoin-cli importdescriptors "$(wallet_generator descriptors --format import)"
+		var requests []importRequest
+		for _, d := range descriptors {
+			requests = append(requests,
+				importRequest{Desc: d.Receive, Timestamp: rescan, Active: true, Internal: false},
+				importRequest{Desc: d.Change, Timestamp: rescan, Active: true, Internal: true})
+		}
+		if err := writeJSON(os.Stdout, requests); err != nil {
+			return exitError
+		}
+	case formatJSON:
+		result := descriptorsResult{
+			MasterFingerprint: wallet.MasterFingerprint(masterKey),
+			Network:           config.Generator.Network,
+			Account:           *account,
+			Descriptors:       descriptors,
+		}
+		if err := writeJSON(os.Stdout, result); err != nil {
+			return exitError
+		}
+	default:
+		fmt.Printf("🔑 主密钥指纹: %s\n", wallet.MasterFingerprint(masterKey))
+		for _, d := range descriptors {
+			fmt.Printf("\n📋 %s  %s\n", d.Type, d.AccountPath)
+			fmt.Printf("收款: %s\n", d.Receive)
+			fmt.Printf("找零: %s\n", d.Change)
+		}
+		if *private {
+			fmt.Fprintln(os.Stderr, "\n⚠️  描述符包含扩展私钥，请安全保存!")
+		}
+	}
+	return exitOK
+}
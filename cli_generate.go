@@ -0,0 +1,393 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+
+	"wallet_create_address/pkg/chain"
+	"wallet_create_address/pkg/output"
+	"wallet_create_address/pkg/wallet"
+)
+
+// walletFormats 钱包类命令支持的输出格式
+var walletFormats = []string{formatText, formatJSONL, formatCSV, formatJSON}
+
+// newWalletEmitter 按输出格式把钱包逐个写到标准输出，返回的 finish 用于补全输出（如 JSON 数组结尾）
+func newWalletEmitter(format string, columns []string) (emit func(wallet.MultiChainWallet) error, finish func() error, err error) {
+	if format == formatText {
+		emit = func(w wallet.MultiChainWallet) error {
+			PrintWalletSimple(w)
+			return nil
+		}
+		return emit, func() error { return nil }, nil
+	}
+
+	writer, err := output.NewWriter(os.Stdout, output.Options{Format: format, Columns: columns})
+	if err != nil {
+		return nil, nil, err
+	}
+	return writer.Write, writer.Close, nil
+}
+
+// runGenerateCommand generate 子命令：生成钱包
+func runGenerateCommand(args []string) int {
+	cf := newCLIFlags("generate", walletFormats...)
+	count := cf.fs.Int("count", 1, "生成数量")
+	workers := cf.fs.Int("workers", 0, "并发协程数（0表示使用配置的最优值）")
+	useMnemonic := cf.fs.Bool("mnemonic", false, "使用助记词（未指定时使用配置 generator.use_mnemonic）")
+	entropySource := cf.fs.String("entropy", "", "使用自备熵生成助记词: dice|coin|hex（仅生成 1 个钱包）")
+	entropyInput := cf.fs.String("entropy-input", "", "骰子点数、硬币正反（H/T）或十六进制（未指定时从标准输入读取）")
+	words := cf.fs.Int("words", 12, "自备熵生成的助记词单词数: 12|15|18|21|24")
+	mixOS := cf.fs.Bool("mix-os", false, "把自备熵与系统随机数一起做 SHA-256（结果不再可手工复核）")
+	applyExtended := cf.extendedFlags()
+	if code := cf.parse(args); code >= 0 {
+		return code
+	}
+	applyExtended()
+
+	if *count < 1 {
+		fmt.Fprintln(os.Stderr, "❌ --count 必须大于0")
+		return exitUsage
+	}
+
+	// 自备熵：生成的助记词走与 --mnemonic 相同的派生流程
+	var mnemonic string
+	if *entropySource != "" {
+		if *count != 1 {
+			fmt.Fprintln(os.Stderr, "❌ --entropy 只能生成 1 个钱包")
+			return exitUsage
+		}
+		if cf.isSet("mnemonic") && !*useMnemonic {
+			fmt.Fprintln(os.Stderr, "❌ --entropy 生成的是助记词钱包，不能与 --mnemonic=false 同时使用")
+			return exitUsage
+		}
+		prompts := map[string]string{
+			wallet.EntropyDice: "输入骰子点数（1-6）: ",
+			wallet.EntropyCoin: "输入抛硬币结果（H/T）: ",
+			wallet.EntropyHex:  "输入十六进制熵: ",
+		}
+		// 先校验参数，避免在读取标准输入之后才报错
+		prompt, ok := prompts[*entropySource]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "❌ %v: %s (可选: %s)\n", wallet.ErrUnknownEntropySource, *entropySource, strings.Join(wallet.EntropySources, "|"))
+			return exitUsage
+		}
+		bits, err := wallet.EntropyBits(*words)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			return exitUsage
+		}
+		input, err := readSecret(*entropyInput, prompt)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			return exitError
+		}
+		entropy, err := wallet.UserEntropy(*entropySource, input, bits, *mixOS)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			return exitUsage
+		}
+		if mnemonic, err = wallet.MnemonicFromEntropy(entropy); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			return exitError
+		}
+		if *mixOS {
+			fmt.Fprintf(os.Stderr, "🎲 已使用 %d 位自备熵（%s），并混合系统随机数\n", bits, *entropySource)
+		} else {
+			fmt.Fprintf(os.Stderr, "🎲 已使用 %d 位自备熵（%s），可按输入手工复核助记词\n", bits, *entropySource)
+		}
+		cf.override("generator.use_mnemonic", "true", "entropy")
+	}
+	if cf.isSet("mnemonic") {
+		cf.override("generator.use_mnemonic", strconv.FormatBool(*useMnemonic), "mnemonic")
+	}
+	cf.overrideWorkers(*workers)
+
+	config, code := cf.loadConfig()
+	if code >= 0 {
+		return code
+	}
+
+	opts := wallet.Options{
+		Count:          *count,
+		UseMnemonic:    config.Generator.UseMnemonic,
+		Mnemonic:       mnemonic,
+		ConcurrentMode: *count > 1,
+		WorkerCount:    config.GetOptimalWorkerCount(),
+	}
+
+	emit, finish, err := newWalletEmitter(cf.format, config.Output.CSVColumns)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitUsage
+	}
+
+	// 单个钱包的文本输出使用完整格式
+	if *count == 1 && cf.format == formatText {
+		emit = func(w wallet.MultiChainWallet) error {
+			PrintWallet(w)
+			return nil
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	result, err := runBatch(ctx, config, opts, emit, os.Stderr)
+	if finishErr := finish(); finishErr != nil && err == nil {
+		err = finishErr
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ 生成已中止: %v\n", err)
+		return exitError
+	}
+	if *count > 1 {
+		printBatchSummary(os.Stderr, config, result)
+	}
+	if result.Failed > 0 {
+		return exitError
+	}
+	return exitOK
+}
+
+// runDeriveCommand derive 子命令：从指定助记词派生多个地址
+func runDeriveCommand(args []string) int {
+	cf := newCLIFlags("derive", walletFormats...)
+	mnemonicFlag := cf.fs.String("mnemonic", "", "助记词（未指定时从标准输入读取）")
+	count := cf.fs.Int("count", 1, "派生地址数量")
+	start := cf.fs.Int("start", 0, "起始索引")
+	path := cf.fs.String("path", wallet.DefaultBasePath, "派生基础路径，完整路径为 <path>/<索引>")
+	applyExtended := cf.extendedFlags()
+	if code := cf.parse(args); code >= 0 {
+		return code
+	}
+	applyExtended()
+	if *count < 1 || *start < 0 {
+		fmt.Fprintln(os.Stderr, "❌ --count 必须大于0，--start 不能为负数")
+		return exitUsage
+	}
+	if _, err := wallet.ParsePath(*path); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitUsage
+	}
+
+	mnemonic, err := readSecret(*mnemonicFlag, "输入助记词: ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitError
+	}
+
+	config, code := cf.loadConfig()
+	if code >= 0 {
+		return code
+	}
+	opts := config.WalletOptions(wallet.Options{
+		Count:       *count,
+		UseMnemonic: true,
+		Mnemonic:    mnemonic,
+		BasePath:    *path,
+		StartIndex:  *start,
+	})
+	emit, finish, err := newWalletEmitter(cf.format, config.Output.CSVColumns)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitUsage
+	}
+	defer finish()
+
+	generator := wallet.NewWalletGenerator()
+	for w, err := range generator.Stream(context.Background(), opts) {
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			return exitError
+		}
+		if err := emit(w); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ 输出失败: %v\n", err)
+			return exitError
+		}
+	}
+	return exitOK
+}
+
+// runWatchCommand watch 子命令：从账户扩展公钥派生观察地址，不需要助记词或私钥
+func runWatchCommand(args []string) int {
+	cf := newCLIFlags("watch", walletFormats...)
+	xpubFlag := cf.fs.String("xpub", "", "账户扩展公钥 xpub/ypub/zpub（未指定时从标准输入读取）")
+	count := cf.fs.Int("count", 1, "派生地址数量")
+	start := cf.fs.Int("start", 0, "起始索引")
+	path := cf.fs.String("path", wallet.DefaultWatchPath, "相对于扩展公钥的基础路径（不能包含强化层级），完整路径为 <path>/<索引>")
+	if code := cf.parse(args); code >= 0 {
+		return code
+	}
+	if *count < 1 || *start < 0 {
+		fmt.Fprintln(os.Stderr, "❌ --count 必须大于0，--start 不能为负数")
+		return exitUsage
+	}
+	if _, err := wallet.ParseWatchPath(*path); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitUsage
+	}
+
+	xpub, err := readSecret(*xpubFlag, "输入扩展公钥: ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitError
+	}
+	if _, err := wallet.ParseWatchKey(xpub); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitUsage
+	}
+
+	config, code := cf.loadConfig()
+	if code >= 0 {
+		return code
+	}
+	opts := wallet.Options{
+		Count:             *count,
+		BasePath:          *path,
+		StartIndex:        *start,
+		ExtendedPublicKey: xpub,
+	}
+	emit, finish, err := newWalletEmitter(cf.format, config.Output.CSVColumns)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitUsage
+	}
+	defer finish()
+
+	generator := wallet.NewWalletGenerator()
+	for w, err := range generator.Stream(context.Background(), opts) {
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			return exitError
+		}
+		if err := emit(w); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ 输出失败: %v\n", err)
+			return exitError
+		}
+	}
+	return exitOK
+}
+
+// runMatchCommand match 子命令：地址匹配模式，规则来自配置文件
+func runMatchCommand(args []string) int {
+	cf := newCLIFlags("match", walletFormats...)
+	chainName := cf.fs.String("chain", "", "匹配的目标链: eth|btc|tron|bsc|polygon（默认使用配置）")
+	workers := cf.fs.Int("workers", 0, "并发协程数（0表示使用配置的最优值）")
+	maxMatch := cf.fs.Int("max-match", -1, "最大匹配次数，0表示无限制（默认使用配置）")
+	maxAttempts := cf.fs.Int("max-attempts", -1, "最大尝试次数，0表示无限制（默认使用配置）")
+	if code := cf.parse(args); code >= 0 {
+		return code
+	}
+
+	if *chainName != "" {
+		if !chain.IsSupported(*chainName) {
+			fmt.Fprintf(os.Stderr, "❌ 不支持的链: %s\n", *chainName)
+			return exitUsage
+		}
+		cf.override("address_matching.target_chains", *chainName, "chain")
+	}
+	if *maxMatch >= 0 {
+		cf.override("address_matching.max_match", strconv.Itoa(*maxMatch), "max-match")
+	}
+	if *maxAttempts >= 0 {
+		cf.override("address_matching.max_attempts", strconv.Itoa(*maxAttempts), "max-attempts")
+	}
+	cf.overrideWorkers(*workers)
+	// 命令本身即表示启用匹配
+	cf.overrides = append(cf.overrides, setting{key: "address_matching.enabled", value: "true", flag: "match 命令"})
+
+	config, code := cf.loadConfig()
+	if code >= 0 {
+		return code
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	emit, finish, err := newWalletEmitter(cf.format, config.Output.CSVColumns)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitUsage
+	}
+
+	result, err := runMatching(ctx, config, config.GetOptimalWorkerCount(), emit, os.Stderr)
+	if finishErr := finish(); finishErr != nil && err == nil {
+		err = finishErr
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitError
+	}
+	if len(result.Wallets) == 0 {
+		return exitNegative
+	}
+	return exitOK
+}
+
+// runBenchCommand bench 子命令：性能基准测试
+func runBenchCommand(args []string) int {
+	cf := newCLIFlags("bench", formatText, formatJSON)
+	mode := cf.fs.String("mode", "quick", "测试模式: full（完整基准测试）|quick（测试当前配置）|auto（选择最优协程数）")
+	workers := cf.fs.Int("workers", 0, "quick 模式的协程数（0表示使用配置的最优值）")
+	save := cf.fs.Bool("save", false, "auto 模式下把最优协程数保存到配置文件")
+	if code := cf.parse(args); code >= 0 {
+		return code
+	}
+
+	if *mode == "quick" {
+		cf.overrideWorkers(*workers)
+	}
+
+	config, code := cf.loadConfig()
+	if code >= 0 {
+		return code
+	}
+
+	tester := NewPerformanceTester(config)
+	if cf.format == formatJSON {
+		tester.SetOutput(os.Stderr)
+	}
+
+	var result *BenchmarkResult
+	var err error
+	switch *mode {
+	case "full", "auto":
+		result, err = tester.RunBenchmark()
+	case "quick":
+		result, err = tester.QuickBenchmark(config.GetOptimalWorkerCount())
+	default:
+		fmt.Fprintf(os.Stderr, "❌ 未知测试模式: %s\n", *mode)
+		return exitUsage
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ 基准测试失败: %v\n", err)
+		return exitError
+	}
+
+	if *mode == "auto" && *save {
+		if err := SaveWorkerCount(cf.configPath, result.WorkerCount); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ 保存配置失败: %v\n", err)
+			return exitError
+		}
+		fmt.Fprintln(os.Stderr, "✅ 配置已保存")
+	}
+
+	if cf.format == formatJSON {
+		if err := writeJSON(os.Stdout, result); err != nil {
+			return exitError
+		}
+		return exitOK
+	}
+
+	if *mode == "auto" {
+		fmt.Printf("🎯 建议协程数: %d\n", result.WorkerCount)
+	} else {
+		fmt.Printf("%d 个协程: %.2f 钱包/秒\n", result.WorkerCount, result.WalletsPerSec)
+	}
+	return exitOK
+}
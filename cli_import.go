@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"wallet_create_address/pkg/chain"
+	"wallet_create_address/pkg/wallet"
+)
+
+// runImportCommand import 子命令：批量导入已有私钥和助记词，计算完整的多链地址
+func runImportCommand(args []string) int {
+	cf := newCLIFlags("import", walletFormats...)
+	path := cf.fs.String("path", wallet.DefaultBasePath, "助记词行的派生基础路径，完整路径为 <path>/<index>")
+	index := cf.fs.Int("index", 0, "助记词行的派生索引")
+	workers := cf.fs.Int("workers", 0, "并发协程数（0表示使用配置的最优值）")
+	applyExtended := cf.extendedFlags()
+	if code := cf.parse(args); code >= 0 {
+		return code
+	}
+	applyExtended()
+	if cf.fs.NArg() > 1 {
+		fmt.Fprintln(os.Stderr, "用法: wallet_generator import [参数] [文件]（每行一个十六进制私钥、WIF 或助记词，未指定文件或为 - 时读取标准输入）")
+		return exitUsage
+	}
+	if *index < 0 {
+		fmt.Fprintln(os.Stderr, "❌ --index 不能为负数")
+		return exitUsage
+	}
+	if _, err := wallet.ParsePath(*path); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitUsage
+	}
+	cf.overrideWorkers(*workers)
+
+	config, code := cf.loadConfig()
+	if code >= 0 {
+		return code
+	}
+
+	var input io.Reader = stdinReader
+	if name := cf.fs.Arg(0); name != "" && name != "-" {
+		file, err := os.Open(name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			return exitError
+		}
+		defer file.Close()
+		input = file
+	}
+
+	writer, err := openOutputFile(config, chain.All, false)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitError
+	}
+	if writer != nil {
+		defer writer.Close()
+	}
+	emit, finish, err := newWalletEmitter(cf.format, config.Output.CSVColumns)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitUsage
+	}
+	defer finish()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	opts := config.WalletOptions(wallet.Options{
+		BasePath:    *path,
+		StartIndex:  *index,
+		WorkerCount: config.GetOptimalWorkerCount(),
+	})
+	start := time.Now()
+	imported, skipped := 0, 0
+	for w, err := range wallet.NewWalletGenerator().Import(ctx, input, opts) {
+		var lineErr *wallet.LineError
+		if errors.As(err, &lineErr) {
+			fmt.Fprintf(os.Stderr, "⚠️  跳过%v\n", lineErr)
+			skipped++
+			continue
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ 导入已中止: %v\n", err)
+			return exitError
+		}
+		if writer != nil {
+			if err := writer.Write(w); err != nil {
+				fmt.Fprintf(os.Stderr, "❌ 保存失败: %v\n", err)
+				return exitError
+			}
+		}
+		if err := emit(w); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ 输出失败: %v\n", err)
+			return exitError
+		}
+		imported++
+	}
+
+	fmt.Fprintf(os.Stderr, "\n✅ 导入 %d 个钱包，跳过 %d 个无效行，耗时: %v\n", imported, skipped, time.Since(start))
+	if writer != nil {
+		fmt.Fprintf(os.Stderr, "已保存到 %s\n", config.Output.OutputFile)
+	}
+	if skipped > 0 {
+		return exitError
+	}
+	return exitOK
+}
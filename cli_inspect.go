@@ -0,0 +1,261 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"wallet_create_address/pkg/chain"
+	"wallet_create_address/pkg/wallet"
+)
+
+// runInspectCommand inspect 子命令：查看助记词在指定路径下的钱包
+func runInspectCommand(args []string) int {
+	cf := newCLIFlags("inspect", formatText, formatJSON)
+	mnemonicFlag := cf.fs.String("mnemonic", "", "助记词（未指定时从标准输入读取）")
+	path := cf.fs.String("path", wallet.DefaultBasePath+"/0", "完整派生路径")
+	applyExtended := cf.extendedFlags()
+	if code := cf.parse(args); code >= 0 {
+		return code
+	}
+	applyExtended()
+	if _, err := wallet.ParsePath(*path); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitUsage
+	}
+
+	mnemonic, err := readSecret(*mnemonicFlag, "输入助记词: ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitError
+	}
+
+	config, code := cf.loadConfig()
+	if code >= 0 {
+		return code
+	}
+
+	w, err := wallet.NewWalletGenerator().GenerateWalletFromMnemonicPath(mnemonic, *path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitError
+	}
+	if config.Generator.ExtendedKeys {
+		masterKey, _ := wallet.MasterKeyFromMnemonic(mnemonic)
+		if err := wallet.AddExtendedKeys(&w, masterKey, config.Generator.Network); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			return exitError
+		}
+	}
+
+	if cf.format == formatJSON {
+		if err := writeJSON(os.Stdout, w); err != nil {
+			return exitError
+		}
+		return exitOK
+	}
+	PrintWallet(w)
+	return exitOK
+}
+
+// runKeyInfoCommand keyinfo 子命令：查看已有私钥（十六进制/WIF）或助记词路径对应的全部地址与编码
+func runKeyInfoCommand(args []string) int {
+	cf := newCLIFlags("keyinfo", formatText, formatJSON)
+	network := cf.fs.String("network", "", "WIF 与比特币地址的网络: mainnet|testnet（默认取自 WIF，其余为主网）")
+	loadKey := cf.keyFlags(wallet.DefaultBasePath + "/0")
+	if code := cf.parse(args); code >= 0 {
+		return code
+	}
+
+	key, code := loadKey()
+	if code >= 0 {
+		return code
+	}
+	if *network == "" {
+		*network = key.network
+	}
+	info, err := wallet.NewWalletGenerator().InspectKey(key.privateKey, *network)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitUsage
+	}
+	info.Mnemonic, info.DerivePath = key.mnemonic, key.path
+
+	if cf.format == formatJSON {
+		if err := writeJSON(os.Stdout, info); err != nil {
+			return exitError
+		}
+		return exitOK
+	}
+	PrintKeyInfo(info)
+	return exitOK
+}
+
+// validateResult 地址校验结果
+type validateResult struct {
+	Address  string `json:"address"`
+	Valid    bool   `json:"valid"`
+	Chain    string `json:"chain,omitempty"`
+	Type     string `json:"type,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+	Network  string `json:"network,omitempty"`
+	Payload  string `json:"payload,omitempty"`
+	// Conversions EVM 与波场地址共享同一哈希的其他形式
+	Conversions *addressForms `json:"conversions,omitempty"`
+	Error       string        `json:"error,omitempty"`
+}
+
+// addressForms EVM 与波场地址的等价形式
+type addressForms struct {
+	Address string `json:"address,omitempty"`
+	Eth     string `json:"eth"`
+	Tron    string `json:"tron"`
+	TronHex string `json:"tron_hex"`
+}
+
+// convertResult convert --to 的 JSON 输出
+type convertResult struct {
+	Address   string `json:"address"`
+	Format    string `json:"format"`
+	Converted string `json:"converted"`
+}
+
+// convertAddressForms 计算 EVM 或波场地址的全部等价形式
+func convertAddressForms(address string) (*addressForms, error) {
+	hash, err := chain.AddressHash(address)
+	if err != nil {
+		return nil, err
+	}
+	return &addressForms{
+		Eth:     chain.EthereumAddressFromHash(hash),
+		Tron:    chain.TronAddressFromHash(hash),
+		TronHex: chain.TronHexAddressFromHash(hash),
+	}, nil
+}
+
+// runValidateCommand validate 子命令：校验地址并识别所属链
+func runValidateCommand(args []string) int {
+	cf := newCLIFlags("validate", formatText, formatJSON)
+	expectChain := cf.fs.String("chain", "", "要求地址属于指定链: eth|btc|tron|bsc|polygon")
+	if code := cf.parse(args); code >= 0 {
+		return code
+	}
+	if cf.fs.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "用法: validate [参数] <地址>...")
+		return exitUsage
+	}
+	if *expectChain != "" && !chain.IsSupported(*expectChain) {
+		fmt.Fprintf(os.Stderr, "❌ 不支持的链: %s\n", *expectChain)
+		return exitUsage
+	}
+
+	// BSC 与 Polygon 地址与以太坊相同
+	expect := *expectChain
+	if expect == chain.BSC || expect == chain.Polygon {
+		expect = chain.ETH
+	}
+
+	code := exitOK
+	var results []validateResult
+	for _, address := range cf.fs.Args() {
+		result := validateResult{Address: address}
+		info, err := chain.ValidateAddress(address)
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			result.Chain, result.Type, result.Encoding = info.Chain, info.Type, info.Encoding
+			result.Network, result.Payload = info.Network, info.Payload
+			if expect != "" && info.Chain != expect {
+				result.Error = fmt.Sprintf("地址属于 %s，而不是 %s", info.Chain, *expectChain)
+			} else {
+				result.Valid = true
+			}
+			if info.Chain == chain.ETH || info.Chain == chain.Tron {
+				result.Conversions, _ = convertAddressForms(address)
+			}
+		}
+		if !result.Valid {
+			code = exitNegative
+		}
+		results = append(results, result)
+	}
+
+	if cf.format == formatJSON {
+		if err := writeJSON(os.Stdout, results); err != nil {
+			return exitError
+		}
+		return code
+	}
+
+	for _, result := range results {
+		if !result.Valid {
+			fmt.Printf("❌ %s  %s\n", result.Address, result.Error)
+			continue
+		}
+		fmt.Printf("✅ %s  链=%s 类型=%s 编码=%s", result.Address, result.Chain, result.Type, result.Encoding)
+		if result.Network != "" {
+			fmt.Printf(" 网络=%s", result.Network)
+		}
+		fmt.Printf("\n   载荷: %s\n", result.Payload)
+		if forms := result.Conversions; forms != nil {
+			fmt.Printf("   EVM: %s  Tron: %s  Tron hex: %s\n", forms.Eth, forms.Tron, forms.TronHex)
+		}
+	}
+	return code
+}
+
+// runConvertCommand convert 子命令：EVM 地址与波场地址（T 开头和 41 十六进制）互相转换
+func runConvertCommand(args []string) int {
+	cf := newCLIFlags("convert", formatText, formatJSON)
+	to := cf.fs.String("to", "", "只输出指定格式: "+strings.Join(chain.ConvertFormats, "|")+"（默认输出全部形式）")
+	if code := cf.parse(args); code >= 0 {
+		return code
+	}
+	if cf.fs.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "用法: convert [--to 格式] <地址>...")
+		return exitUsage
+	}
+
+	var results []*addressForms
+	for _, address := range cf.fs.Args() {
+		forms, err := convertAddressForms(address)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %s: %v\n", address, err)
+			return exitUsage
+		}
+		forms.Address = address
+		results = append(results, forms)
+	}
+
+	if *to != "" {
+		converted := make([]convertResult, 0, len(results))
+		for _, forms := range results {
+			address, err := chain.ConvertAddress(forms.Address, *to)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+				return exitUsage
+			}
+			converted = append(converted, convertResult{Address: forms.Address, Format: *to, Converted: address})
+		}
+		if cf.format == formatJSON {
+			if err := writeJSON(os.Stdout, converted); err != nil {
+				return exitError
+			}
+			return exitOK
+		}
+		for _, c := range converted {
+			fmt.Println(c.Converted)
+		}
+		return exitOK
+	}
+	if cf.format == formatJSON {
+		if err := writeJSON(os.Stdout, results); err != nil {
+			return exitError
+		}
+		return exitOK
+	}
+	for _, forms := range results {
+		fmt.Printf("%s\n  EVM:      %s\n  Tron:     %s\n  Tron hex: %s\n", forms.Address, forms.Eth, forms.Tron, forms.TronHex)
+	}
+	return exitOK
+}
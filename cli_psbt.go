@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"wallet_create_address/pkg/signing"
+	"wallet_create_address/pkg/wallet"
+)
+
+// psbtCommands psbt 的子命令
+var psbtCommands = []command{
+	{"sign", "用助记词（按 BIP32 派生信息）或单个私钥签名 PSBT 输入", runPSBTSign},
+}
+
+// runPSBTCommand psbt 子命令：比特币 BIP174 部分签名交易
+func runPSBTCommand(args []string) int {
+	return runSubcommand("psbt", psbtCommands, args)
+}
+
+// runPSBTSign psbt sign：签名 P2PKH、P2SH-P2WPKH、P2WPKH 与 P2TR 密钥路径输入，输出更新后的 PSBT
+func runPSBTSign(args []string) int {
+	cf := newCLIFlags("psbt sign", formatText, formatJSON)
+	mnemonicFlag := cf.fs.String("mnemonic", "", "助记词：签名 BIP32 派生信息中主密钥指纹匹配的输入")
+	keyFlag := cf.fs.String("key", "", "私钥（十六进制或 WIF）：签名输出脚本属于该私钥的输入")
+	finalize := cf.fs.Bool("finalize", false, "完成已签名的输入，全部输入完成时输出可广播的交易")
+	outputFile := cf.fs.String("output", "", "把更新后的 PSBT（Base64）写入文件")
+	if code := cf.parse(args); code >= 0 {
+		return code
+	}
+	if cf.fs.NArg() != 1 || (*mnemonicFlag != "" && *keyFlag != "") {
+		fmt.Fprintln(os.Stderr, "用法: wallet_generator psbt sign [--mnemonic 助记词 | --key 私钥] [参数] <文件>（二进制、Base64 或十六进制 PSBT，- 表示标准输入）")
+		return exitUsage
+	}
+
+	document, err := readDocument(cf.fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitError
+	}
+	packet, err := signing.ParsePSBT(document)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitUsage
+	}
+
+	// 都未指定时从标准输入读取，含空格的按助记词处理
+	secret := *keyFlag
+	if *mnemonicFlag != "" {
+		secret = *mnemonicFlag
+	}
+	if secret, err = readSecret(secret, "输入助记词或私钥: "); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitError
+	}
+	var signer *signing.PSBTSigner
+	if strings.ContainsAny(secret, " \t") {
+		masterKey, err := wallet.MasterKeyFromMnemonic(strings.Join(strings.Fields(secret), " "))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			return exitUsage
+		}
+		signer = signing.NewPSBTSignerFromMaster(masterKey)
+	} else {
+		privateKey, _, err := wallet.ParsePrivateKey(secret)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			return exitUsage
+		}
+		signer = signing.NewPSBTSignerFromKey(privateKey)
+	}
+
+	result, err := signing.SignPSBT(packet, signer, *finalize)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ 签名失败: %v\n", err)
+		return exitError
+	}
+	if *outputFile != "" {
+		if err := os.WriteFile(*outputFile, []byte(result.PSBT+"\n"), 0o600); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			return exitError
+		}
+	}
+
+	if cf.format == formatJSON {
+		if err := writeJSON(os.Stdout, result); err != nil {
+			return exitError
+		}
+	} else {
+		for _, input := range result.Inputs {
+			switch input.Status {
+			case signing.PSBTInputSigned:
+				fmt.Fprintf(os.Stderr, "✅ 输入 %d  %-12s 已签名 %s\n", input.Index, input.Type, input.Path)
+			case signing.PSBTInputFinalized:
+				fmt.Fprintf(os.Stderr, "   输入 %d  已完成，跳过\n", input.Index)
+			default:
+				fmt.Fprintf(os.Stderr, "⚠️  输入 %d  %-12s 跳过: %s\n", input.Index, input.Type, input.Reason)
+			}
+		}
+		if result.Transaction != "" {
+			fmt.Fprintln(os.Stderr, "🎯 全部输入已完成，可广播的交易:")
+			fmt.Println(result.Transaction)
+		} else {
+			fmt.Println(result.PSBT)
+		}
+	}
+
+	if result.Signed == 0 {
+		fmt.Fprintln(os.Stderr, "⚠️  没有可签名的输入")
+		return exitNegative
+	}
+	return exitOK
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"wallet_create_address/pkg/recovery"
+	"wallet_create_address/pkg/wallet"
+)
+
+// recoverResult recover 的 JSON 输出
+type recoverResult struct {
+	Found       bool                  `json:"found"`
+	Mnemonic    string                `json:"mnemonic,omitempty"`
+	Address     string                `json:"address"`
+	Path        string                `json:"path"`
+	Suggestions []recovery.Suggestion `json:"suggestions,omitempty"`
+	Stats       recovery.Stats        `json:"stats"`
+}
+
+// runRecoverCommand recover 子命令：由不完整或有误的助记词和一个已知地址找回正确的助记词
+func runRecoverCommand(args []string) int {
+	cf := newCLIFlags("recover", formatText, formatJSON)
+	mnemonicFlag := cf.fs.String("mnemonic", "", "不完整的助记词，未知单词写 ?（未指定时从标准输入读取）")
+	address := cf.fs.String("address", "", "该助记词派生出的任一已知地址（ETH/BSC/Polygon、Tron 或 BTC 1/3/bc1q）")
+	path := cf.fs.String("path", wallet.DefaultBasePath+"/0", "已知地址的完整派生路径，如 BTC bc1q 地址用 m/84'/0'/0'/0/0")
+	maxDistance := cf.fs.Int("max-distance", 2, "拼写纠错的最大编辑距离")
+	swaps := cf.fs.Bool("swaps", false, "额外尝试相邻单词互换（候选数乘以单词数）")
+	workers := cf.fs.Int("workers", 0, "并发协程数（0表示使用配置的最优值）")
+	if code := cf.parse(args); code >= 0 {
+		return code
+	}
+	if *address == "" {
+		fmt.Fprintln(os.Stderr, "❌ 必须用 --address 指定一个已知地址")
+		return exitUsage
+	}
+	cf.overrideWorkers(*workers)
+
+	config, code := cf.loadConfig()
+	if code >= 0 {
+		return code
+	}
+
+	mnemonic, err := readSecret(*mnemonicFlag, "输入助记词（未知单词写 ?）: ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitError
+	}
+
+	workerCount := config.GetOptimalWorkerCount()
+	recoverer, err := recovery.New(recovery.Config{
+		Words:         strings.Fields(mnemonic),
+		Address:       *address,
+		Path:          *path,
+		MaxDistance:   *maxDistance,
+		TrySwaps:      *swaps,
+		WorkerCount:   workerCount,
+		StatsInterval: 5 * time.Second,
+		OnStats: func(stats recovery.Stats) {
+			fmt.Fprintf(os.Stderr, "⏳ 已检查 %d/%d 个候选（%d 个通过校验和），耗时 %v\n",
+				stats.Tried, stats.Total, stats.Checksum, stats.Duration.Round(time.Second))
+		},
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitUsage
+	}
+
+	for _, s := range recoverer.Suggestions() {
+		fmt.Fprintf(os.Stderr, "🔑 第 %d 个单词 %q 不在词表中，候选: %s\n", s.Position, s.Word, strings.Join(s.Candidates, ", "))
+	}
+	fmt.Fprintf(os.Stderr, "开始恢复，共 %d 个候选，使用 %d 个协程...\n", recoverer.Total(), workerCount)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	result, err := recoverer.Run(ctx)
+	if err != nil && !errors.Is(err, recovery.ErrNotFound) {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitError
+	}
+
+	out := recoverResult{
+		Found:       err == nil,
+		Mnemonic:    result.Mnemonic,
+		Address:     *address,
+		Path:        *path,
+		Suggestions: recoverer.Suggestions(),
+		Stats:       result.Stats,
+	}
+	if cf.format == formatJSON {
+		if err := writeJSON(os.Stdout, out); err != nil {
+			return exitError
+		}
+	} else if out.Found {
+		fmt.Fprintf(os.Stderr, "✅ 找到匹配的助记词（检查了 %d 个候选，耗时 %v）\n", out.Stats.Tried, out.Stats.Duration.Round(time.Millisecond))
+		fmt.Printf("助记词: %s\n", out.Mnemonic)
+	}
+	if !out.Found {
+		fmt.Fprintf(os.Stderr, "❌ %v（检查了 %d 个候选，%d 个通过校验和）\n", err, out.Stats.Tried, out.Stats.Checksum)
+		return exitNegative
+	}
+	return exitOK
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/tyler-smith/go-bip39"
+
+	"wallet_create_address/pkg/slip39"
+)
+
+// shamirCommands shamir 的子命令
+var shamirCommands = []command{
+	{"split", "把 BIP39 助记词拆分为 SLIP-39 份额助记词（支持分组）", runShamirSplit},
+	{"combine", "由 SLIP-39 份额恢复 BIP39 助记词", runShamirCombine},
+}
+
+// runShamirCommand shamir 子命令：SLIP-39 Shamir 秘密分享备份
+func runShamirCommand(args []string) int {
+	return runSubcommand("shamir", shamirCommands, args)
+}
+
+// shamirGroup 拆分结果中的一组
+type shamirGroup struct {
+	Index     int      `json:"index"`
+	Threshold int      `json:"threshold"`
+	Count     int      `json:"count"`
+	Shares    []string `json:"shares"`
+}
+
+// shamirSplitResult split 的 JSON 输出
+type shamirSplitResult struct {
+	GroupThreshold int           `json:"group_threshold"`
+	Groups         []shamirGroup `json:"groups"`
+}
+
+// shamirCombineResult combine 的 JSON 输出
+type shamirCombineResult struct {
+	Mnemonic     string `json:"mnemonic"`
+	MasterSecret string `json:"master_secret"`
+}
+
+// parseShamirGroups 解析 "2-of-3,3-of-5" 形式的分组参数
+func parseShamirGroups(value string) ([]slip39.Group, error) {
+	var groups []slip39.Group
+	for _, item := range strings.Split(value, ",") {
+		threshold, count, ok := strings.Cut(strings.TrimSpace(item), "-of-")
+		t, err1 := strconv.Atoi(threshold)
+		n, err2 := strconv.Atoi(count)
+		if !ok || err1 != nil || err2 != nil {
+			return nil, fmt.Errorf("分组格式应为 M-of-N，多个组用逗号分隔: %q", item)
+		}
+		groups = append(groups, slip39.Group{Threshold: t, Count: n})
+	}
+	return groups, nil
+}
+
+// shamirPassphrase 读取可选的 SLIP-39 口令，未启用时为空
+func shamirPassphrase(enabled, confirm bool) ([]byte, error) {
+	if !enabled {
+		return nil, nil
+	}
+	passphrase, err := commandPassphrase("输入 SLIP-39 口令: ", confirm)
+	return []byte(passphrase), err
+}
+
+func runShamirSplit(args []string) int {
+	cf := newCLIFlags("shamir split", formatText, formatJSON)
+	mnemonicFlag := cf.fs.String("mnemonic", "", "BIP39 助记词（未指定时从标准输入读取）")
+	threshold := cf.fs.Int("threshold", 2, "单组模式下恢复所需的份额数")
+	shares := cf.fs.Int("shares", 3, "单组模式下的份额总数")
+	groupsFlag := cf.fs.String("groups", "", "分组模式：各组的 M-of-N，如 2-of-3,3-of-5（指定后忽略 --threshold/--shares）")
+	groupThreshold := cf.fs.Int("group-threshold", 1, "分组模式下恢复所需的组数")
+	usePassphrase := cf.fs.Bool("passphrase", false, "用口令加密主密钥（取自 "+passphraseEnv+" 或交互输入），恢复时需要相同口令")
+	exponent := cf.fs.Int("iteration-exponent", 1, "PBKDF2 迭代次数指数，迭代次数为 10000 << 指数")
+	if code := cf.parse(args); code >= 0 {
+		return code
+	}
+
+	groups := []slip39.Group{{Threshold: *threshold, Count: *shares}}
+	if *groupsFlag != "" {
+		var err error
+		if groups, err = parseShamirGroups(*groupsFlag); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			return exitUsage
+		}
+	} else {
+		*groupThreshold = 1
+	}
+
+	mnemonic, err := readSecret(*mnemonicFlag, "输入助记词: ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitError
+	}
+	// 主密钥为 BIP39 助记词的熵，恢复后重新编码为同一助记词
+	entropy, err := bip39.EntropyFromMnemonic(mnemonic)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ 无效的助记词: %v\n", err)
+		return exitUsage
+	}
+	passphrase, err := shamirPassphrase(*usePassphrase, true)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitError
+	}
+
+	split, err := slip39.Split(entropy, *groupThreshold, groups, slip39.Options{
+		Passphrase:        passphrase,
+		IterationExponent: *exponent,
+		Extendable:        true,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitUsage
+	}
+
+	result := shamirSplitResult{GroupThreshold: *groupThreshold}
+	for i, mnemonics := range split {
+		result.Groups = append(result.Groups, shamirGroup{
+			Index:     i + 1,
+			Threshold: groups[i].Threshold,
+			Count:     groups[i].Count,
+			Shares:    mnemonics,
+		})
+	}
+
+	if cf.format == formatJSON {
+		if err := writeJSON(os.Stdout, result); err != nil {
+			return exitError
+		}
+		return exitOK
+	}
+	fmt.Printf("🔐 SLIP-39 份额：恢复需要 %d/%d 个组\n", result.GroupThreshold, len(result.Groups))
+	for _, g := range result.Groups {
+		fmt.Printf("\n📋 第 %d 组（需要 %d/%d 个份额）\n", g.Index, g.Threshold, g.Count)
+		for i, share := range g.Shares {
+			fmt.Printf("%2d. %s\n", i+1, share)
+		}
+	}
+	fmt.Fprintln(os.Stderr, "\n⚠️  请把各份额分开保存，任何人集齐阈值数量的份额即可恢复助记词!")
+	return exitOK
+}
+
+func runShamirCombine(args []string) int {
+	cf := newCLIFlags("shamir combine", formatText, formatJSON)
+	usePassphrase := cf.fs.Bool("passphrase", false, "拆分时使用了口令（取自 "+passphraseEnv+" 或交互输入）")
+	if code := cf.parse(args); code >= 0 {
+		return code
+	}
+
+	// 份额来自参数（每个参数一个份额），未指定时从标准输入按行读取
+	mnemonics := cf.fs.Args()
+	if len(mnemonics) == 0 {
+		fmt.Fprintln(os.Stderr, "每行输入一个份额，以空行或 EOF 结束:")
+		for {
+			line, err := stdinReader.ReadString('\n')
+			line = strings.TrimSpace(line)
+			if line != "" && !strings.HasPrefix(line, "#") {
+				mnemonics = append(mnemonics, line)
+			} else if line == "" && len(mnemonics) > 0 && err == nil {
+				break
+			}
+			if err != nil {
+				if !errors.Is(err, io.EOF) {
+					fmt.Fprintf(os.Stderr, "❌ 读取输入失败: %v\n", err)
+					return exitError
+				}
+				break
+			}
+		}
+	}
+	if len(mnemonics) == 0 {
+		fmt.Fprintln(os.Stderr, "用法: wallet_generator shamir combine [--passphrase] [份额...]")
+		return exitUsage
+	}
+
+	passphrase, err := shamirPassphrase(*usePassphrase, false)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitError
+	}
+	secret, err := slip39.Combine(mnemonics, passphrase)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		if errors.Is(err, slip39.ErrInsufficientShares) {
+			return exitNegative
+		}
+		return exitUsage
+	}
+	mnemonic, err := bip39.NewMnemonic(secret)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ 主密钥无法编码为 BIP39 助记词: %v\n", err)
+		return exitError
+	}
+
+	if cf.format == formatJSON {
+		if err := writeJSON(os.Stdout, shamirCombineResult{Mnemonic: mnemonic, MasterSecret: hex.EncodeToString(secret)}); err != nil {
+			return exitError
+		}
+		return exitOK
+	}
+	fmt.Printf("助记词: %s\n", mnemonic)
+	return exitOK
+}
@@ -0,0 +1,332 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"wallet_create_address/pkg/chain"
+	"wallet_create_address/pkg/signing"
+	"wallet_create_address/pkg/wallet"
+)
+
+// signingKey 从命令行参数得到的私钥
+type signingKey struct {
+	privateKey *ecdsa.PrivateKey
+	network    string // WIF 中的网络，十六进制私钥和助记词为空
+	mnemonic   string
+	path       string
+}
+
+// keyFlags 注册 --key、--mnemonic 和 --path，返回的函数在解析后读取私钥
+// 两者都未指定时从标准输入读取私钥，--mnemonic 为 - 时从标准输入读取助记词；返回非负退出码表示应立即退出
+func (cf *cliFlags) keyFlags(defaultPath string) func() (signingKey, int) {
+	keyFlag := cf.fs.String("key", "", "私钥（十六进制或 WIF）；与 --mnemonic 都未指定时从标准输入读取")
+	mnemonicFlag := cf.fs.String("mnemonic", "", "助记词，配合 --path 使用（- 表示从标准输入读取，避免留在 shell 历史中）")
+	path := cf.fs.String("path", defaultPath, "助记词派生路径")
+	return func() (signingKey, int) {
+		if *mnemonicFlag != "" {
+			mnemonic := strings.TrimSpace(*mnemonicFlag)
+			if mnemonic == "-" {
+				var err error
+				if mnemonic, err = readSecret("", "输入助记词: "); err != nil {
+					fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+					return signingKey{}, exitError
+				}
+			}
+			masterKey, err := wallet.MasterKeyFromMnemonic(mnemonic)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+				return signingKey{}, exitUsage
+			}
+			childKey, err := wallet.DeriveKey(masterKey, *path)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+				return signingKey{}, exitUsage
+			}
+			privateKey, err := crypto.ToECDSA(childKey.Key)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+				return signingKey{}, exitError
+			}
+			return signingKey{privateKey: privateKey, mnemonic: mnemonic, path: *path}, -1
+		}
+
+		value, err := readSecret(*keyFlag, "输入私钥: ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			return signingKey{}, exitError
+		}
+		privateKey, network, err := wallet.ParsePrivateKey(value)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			return signingKey{}, exitUsage
+		}
+		return signingKey{privateKey: privateKey, network: network}, -1
+	}
+}
+
+// readMessage 读取待签名消息：--message 原样使用，--message-file 为 - 时读取标准输入
+func readMessage(message, file string) ([]byte, error) {
+	switch {
+	case message != "" && file != "":
+		return nil, fmt.Errorf("--message 与 --message-file 只能指定一个")
+	case message != "":
+		return []byte(message), nil
+	case file == "-":
+		return io.ReadAll(stdinReader)
+	case file != "":
+		return os.ReadFile(file)
+	}
+	return nil, fmt.Errorf("需要 --message 或 --message-file")
+}
+
+// readDocument 读取命令参数指定的文件，- 表示标准输入
+func readDocument(name string) ([]byte, error) {
+	if name == "-" {
+		return io.ReadAll(stdinReader)
+	}
+	return os.ReadFile(name)
+}
+
+// runSignMessageCommand sign-message 子命令：用私钥对消息签名，证明地址所有权
+func runSignMessageCommand(args []string) int {
+	cf := newCLIFlags("sign-message", formatText, formatJSON)
+	chainName := cf.fs.String("chain", chain.ETH, "链类型: eth|bsc|polygon|tron|btc")
+	message := cf.fs.String("message", "", "待签名的消息")
+	messageFile := cf.fs.String("message-file", "", "从文件读取消息（- 表示标准输入），内容按字节原样签名")
+	addressType := cf.fs.String("type", chain.BTCP2PKH, "比特币地址类型: p2pkh|p2pkh-uncompressed|p2sh-p2wpkh|p2wpkh")
+	network := cf.fs.String("network", "", "比特币网络: mainnet|testnet（默认取自 WIF，其余为主网）")
+	electrum := cf.fs.Bool("electrum", false, "比特币隔离见证地址使用 Electrum 写法的签名首字节")
+	loadKey := cf.keyFlags(wallet.DefaultBasePath + "/0")
+	if code := cf.parse(args); code >= 0 {
+		return code
+	}
+	if *chainName == chain.All || !chain.IsSupported(*chainName) {
+		fmt.Fprintf(os.Stderr, "❌ 不支持的链类型: %s\n", *chainName)
+		return exitUsage
+	}
+
+	msg, err := readMessage(*message, *messageFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitUsage
+	}
+	key, code := loadKey()
+	if code >= 0 {
+		return code
+	}
+	if *network == "" {
+		*network = key.network
+	}
+
+	signed, err := signing.SignMessage(key.privateKey, msg, signing.MessageOptions{
+		Chain:       *chainName,
+		AddressType: *addressType,
+		Network:     *network,
+		Electrum:    *electrum,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ 签名失败: %v\n", err)
+		return exitUsage
+	}
+
+	if cf.format == formatJSON {
+		if err := writeJSON(os.Stdout, signed); err != nil {
+			return exitError
+		}
+		return exitOK
+	}
+	fmt.Printf("地址: %s\n", signed.Address)
+	fmt.Printf("方案: %s\n", signed.Scheme)
+	fmt.Printf("签名: %s\n", signed.Signature)
+	return exitOK
+}
+
+// runVerifyMessageCommand verify-message 子命令：从签名恢复地址并与给定地址比较
+func runVerifyMessageCommand(args []string) int {
+	cf := newCLIFlags("verify-message", formatText, formatJSON)
+	address := cf.fs.String("address", "", "签名者地址（链由地址格式识别）")
+	signature := cf.fs.String("signature", "", "签名（EVM/波场为十六进制，比特币为 Base64）")
+	message := cf.fs.String("message", "", "已签名的消息")
+	messageFile := cf.fs.String("message-file", "", "从文件读取消息（- 表示标准输入）")
+	if code := cf.parse(args); code >= 0 {
+		return code
+	}
+	if *address == "" || *signature == "" {
+		fmt.Fprintln(os.Stderr, "用法: wallet_generator verify-message --address <地址> --signature <签名> --message <消息>")
+		return exitUsage
+	}
+	msg, err := readMessage(*message, *messageFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitUsage
+	}
+
+	result, err := signing.VerifyMessage(*address, msg, *signature)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitUsage
+	}
+
+	if cf.format == formatJSON {
+		if err := writeJSON(os.Stdout, result); err != nil {
+			return exitError
+		}
+	} else if result.Valid {
+		fmt.Printf("✅ 签名有效  链=%s 方案=%s 地址=%s\n", result.Chain, result.Scheme, result.Address)
+	} else {
+		fmt.Printf("❌ 签名与地址不匹配  恢复出的地址: %s\n", result.Recovered)
+	}
+	if !result.Valid {
+		return exitNegative
+	}
+	return exitOK
+}
+
+// runSignTypedDataCommand sign-typed-data 子命令：对 EIP-712 结构化数据签名
+func runSignTypedDataCommand(args []string) int {
+	cf := newCLIFlags("sign-typed-data", formatText, formatJSON)
+	loadKey := cf.keyFlags(wallet.DefaultBasePath + "/0")
+	if code := cf.parse(args); code >= 0 {
+		return code
+	}
+	if cf.fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "用法: wallet_generator sign-typed-data [参数] <文件>（eth_signTypedData_v4 格式的 JSON，- 表示标准输入）")
+		return exitUsage
+	}
+
+	document, err := readDocument(cf.fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitError
+	}
+	key, code := loadKey()
+	if code >= 0 {
+		return code
+	}
+
+	signed, err := signing.SignTypedData(key.privateKey, document)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ 签名失败: %v\n", err)
+		return exitUsage
+	}
+
+	if cf.format == formatJSON {
+		if err := writeJSON(os.Stdout, signed); err != nil {
+			return exitError
+		}
+		return exitOK
+	}
+	fmt.Printf("签名者:     %s\n", signed.Signer)
+	fmt.Printf("主类型:     %s\n", signed.PrimaryType)
+	fmt.Printf("域分隔符:   %s\n", signed.DomainSeparator)
+	fmt.Printf("结构哈希:   %s\n", signed.StructHash)
+	fmt.Printf("签名摘要:   %s\n", signed.Digest)
+	fmt.Printf("签名:       %s\n", signed.Signature)
+	fmt.Printf("v/r/s:      %d %s %s\n", signed.V, signed.R, signed.S)
+	return exitOK
+}
+
+// runSignTxCommand sign-tx 子命令：离线签名以太坊交易（EIP-1559、EIP-2930 与传统交易）
+func runSignTxCommand(args []string) int {
+	cf := newCLIFlags("sign-tx", formatText, formatJSON)
+	loadKey := cf.keyFlags(wallet.DefaultBasePath + "/0")
+	if code := cf.parse(args); code >= 0 {
+		return code
+	}
+	if cf.fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "用法: wallet_generator sign-tx [参数] <文件>（未签名交易的 JSON 描述，- 表示标准输入）")
+		return exitUsage
+	}
+
+	document, err := readDocument(cf.fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitError
+	}
+	request, err := signing.ParseEthTransaction(document)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitUsage
+	}
+	key, code := loadKey()
+	if code >= 0 {
+		return code
+	}
+
+	signed, err := signing.SignEthTransaction(key.privateKey, request)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ 签名失败: %v\n", err)
+		return exitUsage
+	}
+
+	if cf.format == formatJSON {
+		if err := writeJSON(os.Stdout, signed); err != nil {
+			return exitError
+		}
+		return exitOK
+	}
+	fmt.Printf("类型:     %s（chainId %s）\n", signed.Type, signed.ChainID)
+	fmt.Printf("发送方:   %s\n", signed.From)
+	fmt.Printf("交易哈希: %s\n", signed.Hash)
+	fmt.Printf("已签名交易:\n%s\n", signed.RawTransaction)
+	return exitOK
+}
+
+// runSignTronTxCommand sign-tron-tx 子命令：离线签名波场交易（TRX 转账与 TRC20 转账）
+func runSignTronTxCommand(args []string) int {
+	cf := newCLIFlags("sign-tron-tx", formatText, formatJSON)
+	loadKey := cf.keyFlags(wallet.DefaultBasePath + "/0")
+	if code := cf.parse(args); code >= 0 {
+		return code
+	}
+	if cf.fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "用法: wallet_generator sign-tron-tx [参数] <文件>（raw_data 十六进制或交易 JSON，- 表示标准输入）")
+		return exitUsage
+	}
+
+	document, err := readDocument(cf.fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitError
+	}
+	tx, err := signing.ParseTronTransaction(document)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitUsage
+	}
+	summaries, err := tx.Summary()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitUsage
+	}
+	key, code := loadKey()
+	if code >= 0 {
+		return code
+	}
+	if err := signing.SignTronTransaction(key.privateKey, tx); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ 签名失败: %v\n", err)
+		return exitUsage
+	}
+
+	if cf.format == formatText {
+		fmt.Println("📋 交易内容:")
+		for _, s := range summaries {
+			fmt.Printf("  %s  %s -> %s  数量: %s\n", s.Type, s.From, s.To, s.Amount)
+			if s.Contract != "" {
+				fmt.Printf("  合约: %s\n", s.Contract)
+			}
+		}
+		fmt.Printf("txID: %s\n\n", tx.TxID)
+		fmt.Println("已签名交易（/wallet/broadcasttransaction）:")
+	}
+	if err := writeJSON(os.Stdout, tx); err != nil {
+		return exitError
+	}
+	return exitOK
+}
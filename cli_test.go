@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"wallet_create_address/pkg/wallet"
+)
+
+// testMnemonic BIP39 测试向量中的全零熵助记词
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+// runCLI 执行子命令，返回退出码与标准输出、标准错误的内容
+func runCLI(t *testing.T, args ...string) (int, string, string) {
+	t.Helper()
+	stdout, stderr := os.Stdout, os.Stderr
+	outR, outW, _ := os.Pipe()
+	errR, errW, _ := os.Pipe()
+	os.Stdout, os.Stderr = outW, errW
+	outCh, errCh := make(chan string), make(chan string)
+	go func() { b, _ := io.ReadAll(outR); outCh <- string(b) }()
+	go func() { b, _ := io.ReadAll(errR); errCh <- string(b) }()
+
+	code := RunCLI(args)
+
+	outW.Close()
+	errW.Close()
+	os.Stdout, os.Stderr = stdout, stderr
+	return code, <-outCh, <-errCh
+}
+
+// withStdin 把命令读取的标准输入替换为 input，返回底层读取器以便检查是否被读取
+func withStdin(t *testing.T, input string) *strings.Reader {
+	t.Helper()
+	saved := stdinReader
+	r := strings.NewReader(input)
+	stdinReader = bufio.NewReader(r)
+	t.Cleanup(func() { stdinReader = saved })
+	return r
+}
+
+func TestRunCLIUsage(t *testing.T) {
+	if code, out, _ := runCLI(t, "help"); code != exitOK || !strings.Contains(out, "generate") {
+		t.Errorf("help: %d %q", code, out)
+	}
+	if code, _, errOut := runCLI(t, "no-such-command"); code != exitUsage || !strings.Contains(errOut, "未知命令") {
+		t.Errorf("未知命令: %d %q", code, errOut)
+	}
+	if code, _, _ := runCLI(t, "generate", "--no-such-flag"); code != exitUsage {
+		t.Errorf("未知参数退出码 = %d", code)
+	}
+	if code, _, _ := runCLI(t, "generate", "--format", "xml"); code != exitUsage {
+		t.Errorf("不支持的格式退出码 = %d", code)
+	}
+}
+
+func TestRunCLIInspect(t *testing.T) {
+	code, out, errOut := runCLI(t, "inspect", "--mnemonic", testMnemonic, "--format", "json")
+	if code != exitOK {
+		t.Fatalf("退出码 %d: %s", code, errOut)
+	}
+	var w wallet.MultiChainWallet
+	if err := json.Unmarshal([]byte(out), &w); err != nil {
+		t.Fatal(err)
+	}
+	if w.EthAddress != "0x9858EfFD232B4033E47d90003D41EC34EcaEda94" || w.DerivePath != "m/44'/60'/0'/0/0" {
+		t.Errorf("inspect = %s %s", w.EthAddress, w.DerivePath)
+	}
+
+	if code, _, _ := runCLI(t, "inspect", "--mnemonic", testMnemonic, "--path", "m/44'/x"); code != exitUsage {
+		t.Errorf("无效路径退出码 = %d", code)
+	}
+	if code, _, _ := runCLI(t, "inspect", "--mnemonic", "abandon abandon"); code != exitError {
+		t.Errorf("无效助记词退出码 = %d", code)
+	}
+}
+
+func TestRunCLIDerive(t *testing.T) {
+	code, out, errOut := runCLI(t, "derive", "--mnemonic", testMnemonic, "--count", "2", "--start", "1", "--format", "json")
+	if code != exitOK {
+		t.Fatalf("退出码 %d: %s", code, errOut)
+	}
+	var wallets []wallet.MultiChainWallet
+	if err := json.Unmarshal([]byte(out), &wallets); err != nil {
+		t.Fatal(err)
+	}
+	if len(wallets) != 2 || wallets[0].Index != 1 || wallets[1].EthAddress != "0xb6716976A3ebe8D39aCEB04372f22Ff8e6802D7A" {
+		t.Errorf("derive = %+v", wallets)
+	}
+}
+
+func TestRunCLIValidate(t *testing.T) {
+	if code, _, _ := runCLI(t, "validate", "0x9858EfFD232B4033E47d90003D41EC34EcaEda94"); code != exitOK {
+		t.Errorf("有效地址退出码 = %d", code)
+	}
+	if code, _, _ := runCLI(t, "validate", "0x9858EfFD232B4033E47d90003D41EC34EcaEda95"); code != exitNegative {
+		t.Errorf("无效地址退出码 = %d", code)
+	}
+}
+
+func TestRunCLIGenerateEntropy(t *testing.T) {
+	// 128 位十六进制熵 → BIP39 测试向量助记词
+	code, out, errOut := runCLI(t, "generate", "--entropy", "hex", "--entropy-input", "00000000000000000000000000000000", "--format", "jsonl")
+	if code != exitOK {
+		t.Fatalf("退出码 %d: %s", code, errOut)
+	}
+	var w wallet.MultiChainWallet
+	if err := json.Unmarshal([]byte(out), &w); err != nil || w.Mnemonic != testMnemonic {
+		t.Errorf("自备熵钱包 = %+v, %v", w, err)
+	}
+
+	// 参数错误应在提示输入之前返回，不读取标准输入
+	for _, args := range [][]string{
+		{"generate", "--entropy", "dice", "--mnemonic=false"},
+		{"generate", "--entropy", "cards"},
+		{"generate", "--entropy", "dice", "--words", "13"},
+	} {
+		stdin := withStdin(t, "123456\n")
+		code, _, errOut := runCLI(t, args...)
+		if code != exitUsage {
+			t.Errorf("%v: 退出码 %d: %s", args, code, errOut)
+		}
+		if strings.Contains(errOut, "输入骰子点数") || stdin.Len() != len("123456\n") {
+			t.Errorf("%v: 不应读取标准输入", args)
+		}
+	}
+}
+
+func TestRunCLIConvert(t *testing.T) {
+	const eth, tron = "0x9858EfFD232B4033E47d90003D41EC34EcaEda94", "TPrkFhZ8LH8Mruco8vXyA496TaeFBrbmeU"
+	code, out, errOut := runCLI(t, "convert", "--to", "tron", eth)
+	if code != exitOK || strings.TrimSpace(out) != tron {
+		t.Fatalf("convert --to tron: %d %q %s", code, out, errOut)
+	}
+
+	code, out, errOut = runCLI(t, "convert", "--to", "eth", "--format", "json", tron)
+	if code != exitOK {
+		t.Fatalf("退出码 %d: %s", code, errOut)
+	}
+	var results []convertResult
+	if err := json.Unmarshal([]byte(out), &results); err != nil {
+		t.Fatalf("--to 与 --format json 应输出 JSON: %v %q", err, out)
+	}
+	if len(results) != 1 || results[0].Address != tron || results[0].Format != "eth" || results[0].Converted != eth {
+		t.Errorf("convert JSON = %+v", results)
+	}
+}
+
+func TestRunCLIMnemonicStdin(t *testing.T) {
+	// --mnemonic - 从标准输入读取，结果与直接传入助记词相同
+	for _, args := range [][]string{
+		{"keyinfo", "--path", "m/44'/60'/0'/0/1", "--format", "json"},
+		{"sign-message", "--message", "hello", "--format", "json"},
+	} {
+		code, want, errOut := runCLI(t, append(args, "--mnemonic", testMnemonic)...)
+		if code != exitOK {
+			t.Fatalf("%v: 退出码 %d: %s", args, code, errOut)
+		}
+		stdin := withStdin(t, testMnemonic+"\n")
+		code, got, errOut := runCLI(t, append(args, "--mnemonic", "-")...)
+		if code != exitOK || got != want {
+			t.Errorf("%v --mnemonic -: %d %q, 期望 %q: %s", args, code, got, want, errOut)
+		}
+		if stdin.Len() != 0 {
+			t.Errorf("%v: 未读取标准输入", args)
+		}
+	}
+
+	withStdin(t, "")
+	if code, _, _ := runCLI(t, "keyinfo", "--mnemonic", "-"); code != exitError {
+		t.Errorf("标准输入为空时退出码 = %d", code)
+	}
+}
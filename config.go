@@ -2,12 +2,17 @@ package main
 
 import (
 	"fmt"
-	"io/ioutil"
+	"log"
 	"os"
 	"regexp"
 	"runtime"
 
 	"gopkg.in/yaml.v2"
+
+	"wallet_create_address/pkg/chain"
+	"wallet_create_address/pkg/matcher"
+	"wallet_create_address/pkg/output"
+	"wallet_create_address/pkg/wallet"
 )
 
 // Config 主配置结构
@@ -15,15 +20,18 @@ type Config struct {
 	Generator       ConfigGeneratorConfig `yaml:"generator"`
 	WorkerPool      WorkerPoolConfig      `yaml:"worker_pool"`
 	AddressMatching AddressMatchingConfig `yaml:"address_matching"`
+	Rarity          RarityConfig          `yaml:"rarity"`
 	Performance     PerformanceConfig     `yaml:"performance"`
 	Output          OutputConfig          `yaml:"output"`
 }
 
 // ConfigGeneratorConfig 生成器配置
 type ConfigGeneratorConfig struct {
-	DefaultCount      int  `yaml:"default_count"`
-	UseMnemonic       bool `yaml:"use_mnemonic"`
-	BatchDefaultCount int  `yaml:"batch_default_count"`
+	DefaultCount      int    `yaml:"default_count"`
+	UseMnemonic       bool   `yaml:"use_mnemonic"`
+	BatchDefaultCount int    `yaml:"batch_default_count"`
+	ExtendedKeys      bool   `yaml:"extended_keys"`
+	Network           string `yaml:"network"`
 }
 
 // WorkerPoolConfig 协程池配置
@@ -45,13 +53,14 @@ type AddressMatchingConfig struct {
 }
 
 // MatchingRules 匹配规则
-type MatchingRules struct {
-	IgnoreCase   bool     `yaml:"ignore_case"`
-	Prefixes     []string `yaml:"prefixes"`
-	Suffixes     []string `yaml:"suffixes"`
-	SuffixesSame int      `yaml:"suffix_same"`
-	Contains     []string `yaml:"contains"`
-	Regex        string   `yaml:"regex"`
+type MatchingRules = matcher.Rules
+
+// RarityConfig 稀有地址捕获配置
+type RarityConfig struct {
+	Enabled                  bool   `yaml:"enabled"`
+	TargetChain              string `yaml:"target_chain"`
+	matcher.RarityThresholds `yaml:",inline"`
+	OutputFile               string `yaml:"output_file"`
 }
 
 // PerformanceConfig 性能测试配置
@@ -70,43 +79,35 @@ type WorkerRange struct {
 
 // OutputConfig 输出配置
 type OutputConfig struct {
-	Verbose      bool   `yaml:"verbose"`
-	PreviewCount int    `yaml:"preview_count"`
-	SaveToFile   bool   `yaml:"save_to_file"`
-	OutputFile   string `yaml:"output_file"`
+	Verbose      bool     `yaml:"verbose"`
+	PreviewCount int      `yaml:"preview_count"`
+	SaveToFile   bool     `yaml:"save_to_file"`
+	OutputFile   string   `yaml:"output_file"`
+	Format       string   `yaml:"format"`
+	CSVColumns   []string `yaml:"csv_columns"`
+	// Encrypt 为 true 时输出文件（含稀有地址文件）使用口令加密，口令来自 WALLET_PASSPHRASE 或交互输入
+	Encrypt    bool                    `yaml:"encrypt"`
+	Encryption output.EncryptionParams `yaml:"encryption"`
+	// Keystore 为 format=keystore 时的参数，此时 output_file 为目录，口令来源与加密输出相同
+	Keystore output.KeystoreParams `yaml:"keystore"`
 }
 
 // 全局配置实例
 var GlobalConfig *Config
 
-// LoadConfig 加载配置文件
+// LoadConfig 加载配置：默认值 → 配置文件 → WALLET_* 环境变量
 func LoadConfig(configPath string) (*Config, error) {
-	// 如果配置文件不存在，创建默认配置
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		fmt.Printf("⚠️  配置文件 %s 不存在，创建默认配置...\n", configPath)
-		config := getDefaultConfig()
-		if err := SaveConfig(config, configPath); err != nil {
-			return nil, fmt.Errorf("创建默认配置失败: %v", err)
-		}
-		return config, nil
-	}
-
-	data, err := ioutil.ReadFile(configPath)
+	layered, err := LoadLayeredConfig(configPath, os.Environ())
 	if err != nil {
-		return nil, fmt.Errorf("读取配置文件失败: %v", err)
-	}
-
-	var config Config
-	if err := yaml.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("解析配置文件失败: %v", err)
+		return nil, err
 	}
 
 	// 验证配置
-	if err := validateConfig(&config); err != nil {
-		return nil, fmt.Errorf("配置验证失败: %v", err)
+	if err := layered.Validate(); err != nil {
+		return nil, err
 	}
 
-	return &config, nil
+	return layered.Config, nil
 }
 
 // SaveConfig 保存配置文件
@@ -116,13 +117,25 @@ func SaveConfig(config *Config, configPath string) error {
 		return fmt.Errorf("序列化配置失败: %v", err)
 	}
 
-	if err := ioutil.WriteFile(configPath, data, 0644); err != nil {
+	if err := os.WriteFile(configPath, data, 0644); err != nil {
 		return fmt.Errorf("写入配置文件失败: %v", err)
 	}
 
 	return nil
 }
 
+// SaveWorkerCount 把基准测试得出的协程数写入配置文件
+// 只修改 默认值+配置文件 这两层，环境变量和命令行参数的临时覆盖不会被写入文件
+func SaveWorkerCount(configPath string, workerCount int) error {
+	layered, err := LoadLayeredConfig(configPath, nil)
+	if err != nil {
+		return err
+	}
+	layered.Config.WorkerPool.ManualCount = workerCount
+	layered.Config.WorkerPool.AutoDetect = false
+	return SaveConfig(layered.Config, configPath)
+}
+
 // getDefaultConfig 获取默认配置
 func getDefaultConfig() *Config {
 	return &Config{
@@ -130,6 +143,8 @@ func getDefaultConfig() *Config {
 			DefaultCount:      1,
 			UseMnemonic:       false,
 			BatchDefaultCount: 100,
+			ExtendedKeys:      false,
+			Network:           wallet.MainNet,
 		},
 		WorkerPool: WorkerPoolConfig{
 			AutoDetect:    true,
@@ -149,6 +164,17 @@ func getDefaultConfig() *Config {
 			TargetChains: []string{"eth"},
 			MaxAttempts:  10000,
 		},
+		Rarity: RarityConfig{
+			Enabled:     false,
+			TargetChain: "",
+			RarityThresholds: matcher.RarityThresholds{
+				MinRepeatRun:      7,
+				MinSequence:       8,
+				MinPalindromeTail: 10,
+				MinDigitTail:      14,
+			},
+			OutputFile: "rare_wallets.txt",
+		},
 		Performance: PerformanceConfig{
 			AutoBenchmark: false,
 			TestSamples:   1000,
@@ -163,12 +189,22 @@ func getDefaultConfig() *Config {
 			PreviewCount: 5,
 			SaveToFile:   false,
 			OutputFile:   "wallets.json",
+			Format:       output.FormatText,
+			CSVColumns:   []string{},
+			Encrypt:      false,
+			Encryption:   output.DefaultEncryptionParams(),
+			Keystore:     output.DefaultKeystoreParams(),
 		},
 	}
 }
 
 // validateConfig 验证配置
 func validateConfig(config *Config) error {
+	// 验证生成器配置
+	if _, err := wallet.NetworkParams(config.Generator.Network); err != nil {
+		return err
+	}
+
 	// 验证协程池配置
 	if config.WorkerPool.MinWorkers < 1 {
 		return fmt.Errorf("最小协程数不能小于1")
@@ -192,6 +228,45 @@ func validateConfig(config *Config) error {
 		}
 	}
 
+	// 验证稀有地址配置
+	if config.Rarity.Enabled {
+		rarity := config.Rarity
+		if rarity.MinRepeatRun < 0 || rarity.MinSequence < 0 ||
+			rarity.MinPalindromeTail < 0 || rarity.MinDigitTail < 0 {
+			return fmt.Errorf("稀有度阈值不能为负数")
+		}
+		if rarity.MinRepeatRun == 0 && rarity.MinSequence == 0 &&
+			rarity.MinPalindromeTail == 0 && rarity.MinDigitTail == 0 {
+			return fmt.Errorf("稀有地址捕获已启用，但未设置任何阈值")
+		}
+		switch rarity.TargetChain {
+		case "", "eth", "btc", "tron", "bsc", "polygon":
+		default:
+			return fmt.Errorf("稀有地址目标链无效: %s", rarity.TargetChain)
+		}
+		if rarity.OutputFile == "" {
+			return fmt.Errorf("稀有地址输出文件不能为空")
+		}
+	}
+
+	// 验证输出配置
+	if err := output.ValidateFormat(config.Output.Format); err != nil {
+		return err
+	}
+	if err := output.ValidateColumns(config.Output.CSVColumns); err != nil {
+		return err
+	}
+	if config.Output.Encrypt {
+		if err := config.Output.Encryption.Validate(); err != nil {
+			return err
+		}
+	}
+	if config.Output.Format == output.FormatKeystore {
+		if err := config.Output.Keystore.Validate(); err != nil {
+			return err
+		}
+	}
+
 	// 验证性能测试配置
 	if config.Performance.WorkerRange.Min < 1 {
 		return fmt.Errorf("性能测试最小协程数不能小于1")
@@ -226,6 +301,52 @@ func (c *Config) GetOptimalWorkerCount() int {
 	return workerCount
 }
 
+// MatcherConfig 转换为地址匹配器配置，verbose 时输出匹配成功的调试日志
+func (c *Config) MatcherConfig() matcher.Config {
+	mc := matcher.Config{
+		Rules:        c.AddressMatching.Rules,
+		TargetChains: c.AddressMatching.TargetChains,
+		MaxAttempts:  c.AddressMatching.MaxAttempts,
+	}
+	if c.Output.Verbose {
+		mc.Logger = log.Default()
+	}
+	return mc
+}
+
+// WalletOptions 生成选项中与配置相关的部分（扩展密钥与网络）
+func (c *Config) WalletOptions(opts wallet.Options) wallet.Options {
+	opts.ExtendedKeys = c.Generator.ExtendedKeys
+	opts.Network = c.Generator.Network
+	return opts
+}
+
+// OutputOptions 转换为输出写入器选项（不含口令），chainName 和 useMnemonic 仅用于 text 格式
+func (c *Config) OutputOptions(chainName string, useMnemonic bool) output.Options {
+	return output.Options{
+		Format:      c.Output.Format,
+		Chain:       chainName,
+		UseMnemonic: useMnemonic,
+		Columns:     c.Output.CSVColumns,
+		Encryption:  c.Output.Encryption,
+		Keystore:    c.Output.Keystore,
+	}
+}
+
+// RarityChain 获取稀有地址评估的链，未启用时返回空
+func (c *Config) RarityChain() string {
+	if !c.Rarity.Enabled {
+		return ""
+	}
+	if c.Rarity.TargetChain != "" {
+		return c.Rarity.TargetChain
+	}
+	if len(c.AddressMatching.TargetChains) > 0 && c.AddressMatching.TargetChains[0] != chain.All {
+		return c.AddressMatching.TargetChains[0]
+	}
+	return chain.ETH
+}
+
 // MatchesAddress 检查地址是否匹配规则
 func (c *Config) MatchesAddress(address string, chain string) bool {
 	if !c.AddressMatching.Enabled {
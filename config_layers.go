@@ -0,0 +1,272 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// 配置值来源，按优先级从低到高
+const (
+	sourceDefault = "default"
+	sourceFile    = "file"
+	sourceEnv     = "env"
+	sourceFlag    = "flag"
+)
+
+// envPrefix 环境变量前缀，如 WALLET_WORKER_POOL_MANUAL_COUNT 对应 worker_pool.manual_count
+const envPrefix = "WALLET_"
+
+// configPathEnv 指定配置文件路径的环境变量
+const configPathEnv = "WALLET_CONFIG"
+
+// ConfigSource 配置值的来源
+type ConfigSource struct {
+	Layer  string `json:"layer"`
+	Detail string `json:"detail,omitempty"` // 文件路径、环境变量名或命令行参数
+}
+
+// ConfigField 单个配置项
+type ConfigField struct {
+	Key    string       `json:"key"`
+	Value  any          `json:"value"`
+	Env    string       `json:"env"`
+	Source ConfigSource `json:"source"`
+}
+
+// LayeredConfig 分层配置：默认值 → 配置文件 → WALLET_* 环境变量 → 命令行参数
+type LayeredConfig struct {
+	Config  *Config
+	Path    string
+	sources map[string]ConfigSource
+}
+
+// LoadLayeredConfig 按 默认值 → 配置文件 → 环境变量 的顺序加载配置（不做验证）
+// 配置文件不存在时视为没有文件层，不会创建文件（由 config init 显式生成）
+func LoadLayeredConfig(configPath string, environ []string) (*LayeredConfig, error) {
+	lc := &LayeredConfig{
+		Config:  getDefaultConfig(),
+		Path:    configPath,
+		sources: make(map[string]ConfigSource),
+	}
+	for _, field := range lc.fields() {
+		lc.sources[field.key] = ConfigSource{Layer: sourceDefault}
+	}
+
+	if err := lc.applyFile(); err != nil {
+		return nil, err
+	}
+	if err := lc.applyEnv(environ); err != nil {
+		return nil, err
+	}
+	return lc, nil
+}
+
+// applyFile 叠加配置文件，文件不存在时跳过
+func (lc *LayeredConfig) applyFile() error {
+	data, err := os.ReadFile(lc.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("读取配置文件失败: %v", err)
+	}
+
+	if err := yaml.Unmarshal(data, lc.Config); err != nil {
+		return fmt.Errorf("解析配置文件失败: %v", err)
+	}
+
+	// 记录文件中出现的配置项
+	var raw yaml.MapSlice
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("解析配置文件失败: %v", err)
+	}
+	for _, key := range flattenYAMLKeys("", raw) {
+		if _, ok := lc.sources[key]; ok {
+			lc.sources[key] = ConfigSource{Layer: sourceFile, Detail: lc.Path}
+		}
+	}
+	return nil
+}
+
+// applyEnv 叠加 WALLET_* 环境变量
+func (lc *LayeredConfig) applyEnv(environ []string) error {
+	env := make(map[string]string)
+	for _, kv := range environ {
+		if name, value, ok := strings.Cut(kv, "="); ok && strings.HasPrefix(name, envPrefix) {
+			env[name] = value
+		}
+	}
+
+	for _, field := range lc.fields() {
+		name := envName(field.key)
+		value, ok := env[name]
+		if !ok {
+			continue
+		}
+		if err := setFieldValue(field.value, value); err != nil {
+			return fmt.Errorf("环境变量 %s 无效: %v", name, err)
+		}
+		lc.sources[field.key] = ConfigSource{Layer: sourceEnv, Detail: name}
+	}
+	return nil
+}
+
+// Set 以命令行参数覆盖配置项，key 为 yaml 路径（如 worker_pool.manual_count）
+func (lc *LayeredConfig) Set(key, value, flagName string) error {
+	for _, field := range lc.fields() {
+		if field.key != key {
+			continue
+		}
+		if err := setFieldValue(field.value, value); err != nil {
+			return fmt.Errorf("配置项 %s 的值无效: %v", key, err)
+		}
+		lc.sources[key] = ConfigSource{Layer: sourceFlag, Detail: flagName}
+		return nil
+	}
+	return fmt.Errorf("未知配置项: %s", key)
+}
+
+// Validate 验证合并后的配置
+func (lc *LayeredConfig) Validate() error {
+	if err := validateConfig(lc.Config); err != nil {
+		return fmt.Errorf("配置验证失败: %v", err)
+	}
+	return nil
+}
+
+// Fields 列出全部配置项的最终值及来源，按 key 排序
+func (lc *LayeredConfig) Fields() []ConfigField {
+	var result []ConfigField
+	for _, field := range lc.fields() {
+		result = append(result, ConfigField{
+			Key:    field.key,
+			Value:  field.value.Interface(),
+			Env:    envName(field.key),
+			Source: lc.sources[field.key],
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
+
+// configField 配置结构中的叶子字段
+type configField struct {
+	key   string
+	value reflect.Value
+}
+
+// fields 按 yaml 标签遍历配置结构的叶子字段
+func (lc *LayeredConfig) fields() []configField {
+	return collectFields("", reflect.ValueOf(lc.Config).Elem())
+}
+
+func collectFields(prefix string, v reflect.Value) []configField {
+	var result []configField
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("yaml")
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "-" || (!sf.IsExported() && !sf.Anonymous) {
+			continue
+		}
+
+		fv := v.Field(i)
+		// 内联结构体的字段与外层处于同一层级
+		if opts == "inline" {
+			result = append(result, collectFields(prefix, fv)...)
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(sf.Name)
+		}
+
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+		if fv.Kind() == reflect.Struct {
+			result = append(result, collectFields(key, fv)...)
+			continue
+		}
+		result = append(result, configField{key: key, value: fv})
+	}
+	return result
+}
+
+// setFieldValue 将字符串解析为字段类型并赋值，列表使用逗号分隔
+func setFieldValue(v reflect.Value, value string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(n))
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("不支持的列表类型 %s", v.Type())
+		}
+		items := []string{}
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		v.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("不支持的类型 %s", v.Type())
+	}
+	return nil
+}
+
+// flattenYAMLKeys 展开 YAML 映射中出现的全部叶子路径
+func flattenYAMLKeys(prefix string, m yaml.MapSlice) []string {
+	var keys []string
+	for _, item := range m {
+		key := fmt.Sprint(item.Key)
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		if nested, ok := item.Value.(yaml.MapSlice); ok {
+			keys = append(keys, flattenYAMLKeys(key, nested)...)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// envName 配置项对应的环境变量名
+func envName(key string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// resolveConfigPath 确定配置文件路径：命令行参数 → WALLET_CONFIG → config.yaml
+func resolveConfigPath(flagValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	if path := os.Getenv(configPathEnv); path != "" {
+		return path
+	}
+	return "config.yaml"
+}
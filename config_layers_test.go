@@ -0,0 +1,143 @@
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fieldSource 查找配置项的值与来源
+func fieldSource(t *testing.T, lc *LayeredConfig, key string) (any, ConfigSource) {
+	t.Helper()
+	for _, field := range lc.Fields() {
+		if field.Key == key {
+			return field.Value, field.Source
+		}
+	}
+	t.Fatalf("未找到配置项 %s", key)
+	return nil, ConfigSource{}
+}
+
+func TestLoadLayeredConfigMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	lc, err := LoadLayeredConfig(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("加载配置不应创建文件: %v", err)
+	}
+	for _, field := range lc.Fields() {
+		if field.Source.Layer != sourceDefault {
+			t.Errorf("%s 的来源 = %s, 期望 default", field.Key, field.Source.Layer)
+		}
+	}
+	if err := lc.Validate(); err != nil {
+		t.Errorf("默认配置无效: %v", err)
+	}
+}
+
+func TestLoadLayeredConfigPrecedence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	data := "worker_pool:\n  manual_count: 6\n  auto_detect: false\ngenerator:\n  use_mnemonic: true\n"
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	environ := []string{"WALLET_WORKER_POOL_MANUAL_COUNT=7", "WALLET_ADDRESS_MATCHING_TARGET_CHAINS=tron, eth", "OTHER=1"}
+	lc, err := LoadLayeredConfig(path, environ)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value, source := fieldSource(t, lc, "generator.use_mnemonic"); value != true || source.Layer != sourceFile || source.Detail != path {
+		t.Errorf("generator.use_mnemonic = %v %+v", value, source)
+	}
+	if value, source := fieldSource(t, lc, "worker_pool.manual_count"); value != 7 || source.Detail != "WALLET_WORKER_POOL_MANUAL_COUNT" {
+		t.Errorf("worker_pool.manual_count = %v %+v", value, source)
+	}
+	if chains := lc.Config.AddressMatching.TargetChains; len(chains) != 2 || chains[0] != "tron" || chains[1] != "eth" {
+		t.Errorf("target_chains = %v", chains)
+	}
+
+	if err := lc.Set("worker_pool.manual_count", "9", "--workers"); err != nil {
+		t.Fatal(err)
+	}
+	if value, source := fieldSource(t, lc, "worker_pool.manual_count"); value != 9 || source.Layer != sourceFlag {
+		t.Errorf("命令行覆盖后 worker_pool.manual_count = %v %+v", value, source)
+	}
+	if err := lc.Set("no.such.key", "1", "--set"); err == nil {
+		t.Error("未知配置项应返回错误")
+	}
+	if err := lc.Set("worker_pool.manual_count", "many", "--set"); err == nil {
+		t.Error("无效的整数应返回错误")
+	}
+}
+
+func TestSaveWorkerCount(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	data := "generator:\n  use_mnemonic: true\n"
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	// 环境变量的覆盖只对本次运行生效，不应写入文件
+	t.Setenv("WALLET_OUTPUT_VERBOSE", "false")
+	t.Setenv("WALLET_WORKER_POOL_MANUAL_COUNT", "3")
+
+	if err := SaveWorkerCount(path, 12); err != nil {
+		t.Fatal(err)
+	}
+	lc, err := LoadLayeredConfig(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value, source := fieldSource(t, lc, "worker_pool.manual_count"); value != 12 || source.Layer != sourceFile {
+		t.Errorf("worker_pool.manual_count = %v %+v", value, source)
+	}
+	if value, _ := fieldSource(t, lc, "worker_pool.auto_detect"); value != false {
+		t.Errorf("worker_pool.auto_detect = %v", value)
+	}
+	if value, _ := fieldSource(t, lc, "generator.use_mnemonic"); value != true {
+		t.Errorf("文件中的 generator.use_mnemonic 丢失: %v", value)
+	}
+	if value, _ := fieldSource(t, lc, "output.verbose"); value != true {
+		t.Errorf("环境变量覆盖被写入文件: output.verbose = %v", value)
+	}
+}
+
+func TestLoadLayeredConfigInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("worker_pool: [\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadLayeredConfig(path, nil); err == nil {
+		t.Error("无效的 YAML 应返回错误")
+	}
+	if _, err := LoadLayeredConfig(filepath.Join(t.TempDir(), "x.yaml"), []string{"WALLET_OUTPUT_VERBOSE=maybe"}); err == nil {
+		t.Error("无效的环境变量应返回错误")
+	}
+}
+
+func TestConfigInit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if code, _, _ := runCLI(t, "config", "show", "--config", path); code != exitOK {
+		t.Fatalf("config show 退出码 = %d", code)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("config show 不应创建配置文件: %v", err)
+	}
+
+	if code, _, errOut := runCLI(t, "config", "init", "--config", path); code != exitOK {
+		t.Fatalf("config init 退出码 %d: %s", code, errOut)
+	}
+	if _, err := LoadConfig(path); err != nil {
+		t.Fatalf("生成的配置无效: %v", err)
+	}
+	if code, _, _ := runCLI(t, "config", "init", "--config", path); code != exitUsage {
+		t.Errorf("已存在时 config init 退出码 = %d", code)
+	}
+	if code, _, _ := runCLI(t, "config", "init", "--config", path, "--force"); code != exitOK {
+		t.Errorf("config init --force 退出码 = %d", code)
+	}
+}
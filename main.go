@@ -3,12 +3,23 @@ package main
 import (
 	"fmt"
 	"log"
+	"os"
 	"runtime"
 )
 
 func main() {
+	args, code := parseGlobalFlags(os.Args[1:])
+	if code >= 0 {
+		os.Exit(code)
+	}
+
+	// 带子命令时以非交互方式运行
+	if len(args) > 0 {
+		os.Exit(RunCLI(args))
+	}
+
 	// 加载配置
-	config, err := LoadConfig("config.yaml")
+	config, err := LoadConfig(defaultConfigPath)
 	if err != nil {
 		log.Fatalf("❌ 加载配置失败: %v", err)
 	}
@@ -23,7 +34,7 @@ func main() {
 	}
 
 	// 创建应用实例
-	app := NewApp(config)
+	app := NewApp(config, defaultConfigPath)
 
 	// 运行应用
 	app.Run()
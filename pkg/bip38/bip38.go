@@ -0,0 +1,414 @@
+// Package bip38 实现 BIP38 口令加密私钥（6P 开头）
+//
+// 支持两种模式：
+//   - 非 EC 乘法模式：持有私钥的一方用口令直接加密；
+//   - EC 乘法模式：口令持有者生成中间码（passphrase 开头），第三方据此生成加密私钥和确认码，
+//     整个过程中第三方无法得知口令，也无法得到私钥。
+package bip38
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/base58"
+	"github.com/btcsuite/btcd/chaincfg"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/text/unicode/norm"
+)
+
+var (
+	// ErrInvalidFormat 字符串不是合法的 BIP38 数据
+	ErrInvalidFormat = errors.New("BIP38 格式无效")
+	// ErrWrongPassphrase 口令错误（地址校验失败）
+	ErrWrongPassphrase = errors.New("口令错误")
+	// ErrInvalidLotSequence 批号或序号超出范围
+	ErrInvalidLotSequence = errors.New("批号须在 0-1048575 之间，序号须在 0-4095 之间")
+)
+
+// 前缀与标志位
+var (
+	prefixNonEC        = []byte{0x01, 0x42}
+	prefixEC           = []byte{0x01, 0x43}
+	magicIntermediate  = []byte{0x2C, 0xE9, 0xB3, 0xE1, 0xFF, 0x39, 0xE2}
+	magicConfirmation  = []byte{0x64, 0x3B, 0xF6, 0xA8, 0x9A}
+	intermediateLotSeq = byte(0x51)
+	intermediateNoLot  = byte(0x53)
+)
+
+const (
+	flagNonEC      = 0xC0
+	flagCompressed = 0x20
+	flagLotSeq     = 0x04
+)
+
+// Key 解密得到的私钥
+type Key struct {
+	PrivateKey *btcec.PrivateKey
+	Compressed bool
+	Address    string
+}
+
+// WIF 私钥的主网 WIF 编码
+func (k *Key) WIF() (string, error) {
+	wif, err := btcutil.NewWIF(k.PrivateKey, &chaincfg.MainNetParams, k.Compressed)
+	if err != nil {
+		return "", err
+	}
+	return wif.String(), nil
+}
+
+// Encrypt 非 EC 乘法模式加密私钥；compressed 决定对应地址使用压缩还是未压缩公钥
+func Encrypt(privateKey *btcec.PrivateKey, passphrase string, compressed bool) (string, error) {
+	address, err := p2pkhAddress(privateKey.PubKey(), compressed)
+	if err != nil {
+		return "", err
+	}
+	addressHash := checksum([]byte(address))
+
+	derived, err := scrypt.Key(normalize(passphrase), addressHash, 16384, 8, 8, 64)
+	if err != nil {
+		return "", err
+	}
+	key := privateKey.Serialize()
+	half1 := encryptBlock(derived[32:], xor(key[:16], derived[:16]))
+	half2 := encryptBlock(derived[32:], xor(key[16:], derived[16:32]))
+
+	flag := byte(flagNonEC)
+	if compressed {
+		flag |= flagCompressed
+	}
+	payload := concat(prefixNonEC, []byte{flag}, addressHash, half1, half2)
+	return encodeCheck(payload), nil
+}
+
+// Decrypt 解密 6P 开头的加密私钥（两种模式均可），并校验地址
+func Decrypt(encrypted, passphrase string) (*Key, error) {
+	payload, err := decodeCheck(encrypted, 39)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case bytes.Equal(payload[:2], prefixNonEC):
+		return decryptNonEC(payload, passphrase)
+	case bytes.Equal(payload[:2], prefixEC):
+		return decryptEC(payload, passphrase)
+	}
+	return nil, ErrInvalidFormat
+}
+
+func decryptNonEC(payload []byte, passphrase string) (*Key, error) {
+	flag := payload[2]
+	if flag&flagNonEC != flagNonEC {
+		return nil, ErrInvalidFormat
+	}
+	compressed := flag&flagCompressed != 0
+	addressHash := payload[3:7]
+
+	derived, err := scrypt.Key(normalize(passphrase), addressHash, 16384, 8, 8, 64)
+	if err != nil {
+		return nil, err
+	}
+	key := concat(
+		xor(decryptBlock(derived[32:], payload[7:23]), derived[:16]),
+		xor(decryptBlock(derived[32:], payload[23:39]), derived[16:32]),
+	)
+	privateKey, _ := btcec.PrivKeyFromBytes(key)
+	return verifiedKey(privateKey, compressed, addressHash)
+}
+
+func decryptEC(payload []byte, passphrase string) (*Key, error) {
+	flag := payload[2]
+	compressed := flag&flagCompressed != 0
+	lotSequence := flag&flagLotSeq != 0
+	addressHash := payload[3:7]
+	ownerEntropy := payload[7:15]
+
+	passFactor, err := passFactor(passphrase, ownerEntropy, lotSequence)
+	if err != nil {
+		return nil, err
+	}
+	_, passPub := btcec.PrivKeyFromBytes(passFactor)
+	passPoint := passPub.SerializeCompressed()
+
+	derived, err := scrypt.Key(passPoint, concat(addressHash, ownerEntropy), 1024, 1, 1, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	// encryptedpart2 解出 encryptedpart1 的后半部分和 seedb 的后 8 字节
+	part2 := xor(decryptBlock(derived[32:], payload[23:39]), derived[16:32])
+	part1 := concat(payload[15:23], part2[:8])
+	seedB := concat(xor(decryptBlock(derived[32:], part1), derived[:16]), part2[8:])
+	factorB := doubleSHA256(seedB)
+
+	var k, f btcec.ModNScalar
+	k.SetByteSlice(passFactor)
+	f.SetByteSlice(factorB)
+	k.Mul(&f)
+	privateKey := btcec.PrivKeyFromScalar(&k)
+	return verifiedKey(privateKey, compressed, addressHash)
+}
+
+// verifiedKey 校验私钥对应地址的哈希，不一致说明口令错误
+func verifiedKey(privateKey *btcec.PrivateKey, compressed bool, addressHash []byte) (*Key, error) {
+	address, err := p2pkhAddress(privateKey.PubKey(), compressed)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(checksum([]byte(address)), addressHash) {
+		return nil, ErrWrongPassphrase
+	}
+	return &Key{PrivateKey: privateKey, Compressed: compressed, Address: address}, nil
+}
+
+// NewIntermediateCode 由口令生成 EC 乘法模式的中间码（passphrase 开头），交给第三方生成加密私钥
+// useLotSequence 为 true 时把批号和序号写入中间码，便于区分批量印制的纸钱包
+func NewIntermediateCode(passphrase string, lot, sequence uint32, useLotSequence bool) (string, error) {
+	var ownerEntropy []byte
+	if useLotSequence {
+		if lot > 1048575 || sequence > 4095 {
+			return "", ErrInvalidLotSequence
+		}
+		ownerSalt := make([]byte, 4)
+		if _, err := rand.Read(ownerSalt); err != nil {
+			return "", err
+		}
+		lotSeq := lot*4096 + sequence
+		ownerEntropy = concat(ownerSalt, []byte{byte(lotSeq >> 24), byte(lotSeq >> 16), byte(lotSeq >> 8), byte(lotSeq)})
+	} else {
+		ownerEntropy = make([]byte, 8)
+		if _, err := rand.Read(ownerEntropy); err != nil {
+			return "", err
+		}
+	}
+
+	passFactor, err := passFactor(passphrase, ownerEntropy, useLotSequence)
+	if err != nil {
+		return "", err
+	}
+	_, passPub := btcec.PrivKeyFromBytes(passFactor)
+
+	last := intermediateNoLot
+	if useLotSequence {
+		last = intermediateLotSeq
+	}
+	payload := concat(magicIntermediate, []byte{last}, ownerEntropy, passPub.SerializeCompressed())
+	return encodeCheck(payload), nil
+}
+
+// ECResult 第三方由中间码生成的结果
+type ECResult struct {
+	Encrypted    string `json:"encrypted"`    // 6P 开头的加密私钥
+	Confirmation string `json:"confirmation"` // cfrm38 开头的确认码
+	Address      string `json:"address"`      // 对应的比特币地址
+}
+
+// GenerateEncrypted 由中间码生成新的加密私钥、确认码和地址（无需口令）
+func GenerateEncrypted(intermediate string, compressed bool) (*ECResult, error) {
+	payload, err := decodeCheck(intermediate, 49)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(payload[:7], magicIntermediate) ||
+		(payload[7] != intermediateLotSeq && payload[7] != intermediateNoLot) {
+		return nil, ErrInvalidFormat
+	}
+	ownerEntropy := payload[8:16]
+	passPoint, err := btcec.ParsePubKey(payload[16:49])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+	}
+
+	flag := byte(0)
+	if compressed {
+		flag |= flagCompressed
+	}
+	if payload[7] == intermediateLotSeq {
+		flag |= flagLotSeq
+	}
+
+	seedB := make([]byte, 24)
+	if _, err := rand.Read(seedB); err != nil {
+		return nil, err
+	}
+	factorB := doubleSHA256(seedB)
+
+	generated := multiplyPoint(passPoint, factorB)
+	address, err := p2pkhAddress(generated, compressed)
+	if err != nil {
+		return nil, err
+	}
+	addressHash := checksum([]byte(address))
+
+	derived, err := scrypt.Key(passPoint.SerializeCompressed(), concat(addressHash, ownerEntropy), 1024, 1, 1, 64)
+	if err != nil {
+		return nil, err
+	}
+	part1 := encryptBlock(derived[32:], xor(seedB[:16], derived[:16]))
+	part2 := encryptBlock(derived[32:], xor(concat(part1[8:], seedB[16:]), derived[16:32]))
+	encrypted := encodeCheck(concat(prefixEC, []byte{flag}, addressHash, ownerEntropy, part1[:8], part2))
+
+	// 确认码：加密后的 pointb，口令持有者据此可验证地址而无需私钥
+	_, pointBPub := btcec.PrivKeyFromBytes(factorB)
+	pointB := pointBPub.SerializeCompressed()
+	pointBPrefix := pointB[0] ^ (derived[63] & 0x01)
+	pointBX := concat(
+		encryptBlock(derived[32:], xor(pointB[1:17], derived[:16])),
+		encryptBlock(derived[32:], xor(pointB[17:33], derived[16:32])),
+	)
+	confirmation := encodeCheck(concat(magicConfirmation, []byte{flag}, addressHash, ownerEntropy, []byte{pointBPrefix}, pointBX))
+
+	return &ECResult{Encrypted: encrypted, Confirmation: confirmation, Address: address}, nil
+}
+
+// VerifyConfirmation 用口令验证第三方给出的确认码，返回确认的地址
+func VerifyConfirmation(confirmation, passphrase string) (string, error) {
+	payload, err := decodeCheck(confirmation, 51)
+	if err != nil {
+		return "", err
+	}
+	if !bytes.Equal(payload[:5], magicConfirmation) {
+		return "", ErrInvalidFormat
+	}
+	flag := payload[5]
+	addressHash := payload[6:10]
+	ownerEntropy := payload[10:18]
+
+	passFactor, err := passFactor(passphrase, ownerEntropy, flag&flagLotSeq != 0)
+	if err != nil {
+		return "", err
+	}
+	_, passPub := btcec.PrivKeyFromBytes(passFactor)
+
+	derived, err := scrypt.Key(passPub.SerializeCompressed(), concat(addressHash, ownerEntropy), 1024, 1, 1, 64)
+	if err != nil {
+		return "", err
+	}
+	pointB := concat(
+		[]byte{payload[18] ^ (derived[63] & 0x01)},
+		xor(decryptBlock(derived[32:], payload[19:35]), derived[:16]),
+		xor(decryptBlock(derived[32:], payload[35:51]), derived[16:32]),
+	)
+	pointBPub, err := btcec.ParsePubKey(pointB)
+	if err != nil {
+		return "", ErrWrongPassphrase
+	}
+
+	address, err := p2pkhAddress(multiplyPoint(pointBPub, passFactor), flag&flagCompressed != 0)
+	if err != nil {
+		return "", err
+	}
+	if !bytes.Equal(checksum([]byte(address)), addressHash) {
+		return "", ErrWrongPassphrase
+	}
+	return address, nil
+}
+
+// passFactor 由口令和 ownerentropy 计算 passfactor
+func passFactor(passphrase string, ownerEntropy []byte, lotSequence bool) ([]byte, error) {
+	ownerSalt := ownerEntropy
+	if lotSequence {
+		ownerSalt = ownerEntropy[:4]
+	}
+	preFactor, err := scrypt.Key(normalize(passphrase), ownerSalt, 16384, 8, 8, 32)
+	if err != nil {
+		return nil, err
+	}
+	if !lotSequence {
+		return preFactor, nil
+	}
+	return doubleSHA256(concat(preFactor, ownerEntropy)), nil
+}
+
+// multiplyPoint 计算 point * scalar
+func multiplyPoint(point *btcec.PublicKey, scalar []byte) *btcec.PublicKey {
+	var s btcec.ModNScalar
+	s.SetByteSlice(scalar)
+	var p, result btcec.JacobianPoint
+	point.AsJacobian(&p)
+	btcec.ScalarMultNonConst(&s, &p, &result)
+	result.ToAffine()
+	return btcec.NewPublicKey(&result.X, &result.Y)
+}
+
+// p2pkhAddress 主网 P2PKH 地址
+func p2pkhAddress(pub *btcec.PublicKey, compressed bool) (string, error) {
+	serialized := pub.SerializeUncompressed()
+	if compressed {
+		serialized = pub.SerializeCompressed()
+	}
+	address, err := btcutil.NewAddressPubKeyHash(btcutil.Hash160(serialized), &chaincfg.MainNetParams)
+	if err != nil {
+		return "", err
+	}
+	return address.EncodeAddress(), nil
+}
+
+// encodeCheck Base58Check 编码（载荷中已包含前缀，不再追加版本字节）
+func encodeCheck(payload []byte) string {
+	return base58.Encode(concat(payload, checksum(payload)))
+}
+
+// decodeCheck Base58Check 解码并校验长度
+func decodeCheck(s string, size int) ([]byte, error) {
+	data := base58.Decode(s)
+	if len(data) != size+4 {
+		return nil, ErrInvalidFormat
+	}
+	payload := data[:size]
+	if !bytes.Equal(checksum(payload), data[size:]) {
+		return nil, fmt.Errorf("%w: 校验和错误", ErrInvalidFormat)
+	}
+	return payload, nil
+}
+
+func checksum(data []byte) []byte {
+	return doubleSHA256(data)[:4]
+}
+
+func doubleSHA256(data []byte) []byte {
+	first := sha256.Sum256(data)
+	second := sha256.Sum256(first[:])
+	return second[:]
+}
+
+// normalize 口令按 BIP38 要求使用 Unicode NFC 规范化
+func normalize(passphrase string) []byte {
+	return []byte(norm.NFC.String(passphrase))
+}
+
+func encryptBlock(key, src []byte) []byte {
+	block, _ := aes.NewCipher(key)
+	dst := make([]byte, aes.BlockSize)
+	block.Encrypt(dst, src)
+	return dst
+}
+
+func decryptBlock(key, src []byte) []byte {
+	block, _ := aes.NewCipher(key)
+	dst := make([]byte, aes.BlockSize)
+	block.Decrypt(dst, src)
+	return dst
+}
+
+func xor(a, b []byte) []byte {
+	out := make([]byte, len(a))
+	for i := range a {
+		out[i] = a[i] ^ b[i]
+	}
+	return out
+}
+
+func concat(parts ...[]byte) []byte {
+	var out []byte
+	for _, p := range parts {
+		out = append(out, p...)
+	}
+	return out
+}
@@ -0,0 +1,134 @@
+package bip38
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil"
+)
+
+// BIP38 规范中的测试向量
+var nonECVectors = []struct {
+	passphrase string
+	encrypted  string
+	wif        string
+	compressed bool
+}{
+	{"TestingOneTwoThree", "6PRVWUbkzzsbcVac2qwfssoUJAN1Xhrg6bNk8J7Nzm5H7kxEbn2Nh2ZoGg", "5KN7MzqK5wt2TP1fQCYyHBtDrXdJuXbUzm4A9rKAteGu3Qi5CVR", false},
+	{"Satoshi", "6PRNFFkZc2NZ6dJqFfhRoFNMR9Lnyj7dYGrzdgXXVMXcxoKTePPX1dWByq", "5HtasZ6ofTHP6HCwTqTkLDuLQisYPah7aUnSKfC7h4hMUVw2gi5", false},
+	{"TestingOneTwoThree", "6PYNKZ1EAgYgmQfmNVamxyXVWHzK5s6DGhwP4J5o44cvXdoY7sRzhtpUeo", "L44B5gGEpqEDRS9vVPz7QT35jcBG2r3CZwSwQ4fCewXAhAhqGVpP", true},
+	{"Satoshi", "6PYLtMnXvfG3oJde97zRyLYFZCYizPU5T3LwgdYJz1fRhh16bU7u6PPmY7", "KwYgW8gcxj1JWJXhPSu4Fqwzfhp5Yfi42mdYmMa4XqK7NJxXUSK7", true},
+}
+
+var ecVectors = []struct {
+	passphrase   string
+	encrypted    string
+	address      string
+	wif          string
+	confirmation string
+}{
+	{"TestingOneTwoThree", "6PfQu77ygVyJLZjfvMLyhLMQbYnu5uguoJJ4kMCLqWwPEdfpwANVS76gTX",
+		"1PE6TQi6HTVNz5DLwB1LcpMBALubfuN2z2", "5K4caxezwjGCGfnoPTZ8tMcJBLB7Jvyjv4xxeacadhq8nLisLR2", ""},
+	{"Satoshi", "6PfLGnQs6VZnrNpmVKfjotbnQuaJK4KZoPFrAjx1JMJUa1Ft8gnf5WxfKd",
+		"1CqzrtZC6mXSAhoxtFwVjz8LtwLJjDYU3V", "5KJ51SgxWaAYR13zd9ReMhJpwrcX47xTJh2D3fGPG9CM8vkv5sH", ""},
+	{"MOLON LABE", "6PgNBNNzDkKdhkT6uJntUXwwzQV8Rr2tZcbkDcuC9DZRsS6AtHts4Ypo1j",
+		"1Jscj8ALrYu2y9TD8NrpvDBugPedmbj4Yh", "5JLdxTtcTHcfYcmJsNVy1v2PMDx432JPoYcBTVVRHpPaxUrdtf8",
+		"cfrm38V8aXBn7JWA1ESmFMUn6erxeBGZGAxJPY4e36S9QWkzZKtaVqLNMgnifETYw7BPwWC9aPD"},
+	{"ΜΟΛΩΝ ΛΑΒΕ", "6PgGWtx25kUg8QWvwuJAgorN6k9FbE25rv5dMRwu5SKMnfpfVe5mar2ngH",
+		"1Lurmih3KruL4xDB5FmHof38yawNtP9oGf", "5KMKKuUmAkiNbA3DazMQiLfDq47qs8MAEThm4yL8R2PhV1ov33D",
+		"cfrm38V8G4qq2ywYEFfWLD5Cc6msj9UwsG2Mj4Z6QdGJAFQpdatZLavkgRd1i4iBMdRngDqDs51"},
+}
+
+func TestEncryptVectors(t *testing.T) {
+	t.Parallel()
+	for _, v := range nonECVectors {
+		wif, err := btcutil.DecodeWIF(v.wif)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := Encrypt(wif.PrivKey, v.passphrase, v.compressed)
+		if err != nil || got != v.encrypted {
+			t.Errorf("Encrypt(%s) = %s, %v, 期望 %s", v.wif, got, err, v.encrypted)
+		}
+	}
+}
+
+func TestDecryptVectors(t *testing.T) {
+	t.Parallel()
+	for _, v := range nonECVectors {
+		key, err := Decrypt(v.encrypted, v.passphrase)
+		if err != nil {
+			t.Errorf("Decrypt(%s): %v", v.encrypted, err)
+			continue
+		}
+		if wif, _ := key.WIF(); wif != v.wif || key.Compressed != v.compressed {
+			t.Errorf("Decrypt(%s) = %s compressed=%v", v.encrypted, wif, key.Compressed)
+		}
+	}
+	for _, v := range ecVectors {
+		key, err := Decrypt(v.encrypted, v.passphrase)
+		if err != nil {
+			t.Errorf("Decrypt(%s): %v", v.encrypted, err)
+			continue
+		}
+		if wif, _ := key.WIF(); wif != v.wif || key.Address != v.address {
+			t.Errorf("Decrypt(%s) = %s %s", v.encrypted, wif, key.Address)
+		}
+	}
+
+	if _, err := Decrypt(nonECVectors[0].encrypted, "wrong"); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("错误口令: %v", err)
+	}
+	if _, err := Decrypt(ecVectors[0].encrypted, "wrong"); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("EC 模式错误口令: %v", err)
+	}
+	if _, err := Decrypt("6PRVWUbkzzsbcVac2qwfssoUJAN1Xhrg6bNk8J7Nzm5H7kxEbn2Nh2ZoGh", "x"); err == nil {
+		t.Error("校验和错误应返回错误")
+	}
+}
+
+func TestVerifyConfirmationVectors(t *testing.T) {
+	t.Parallel()
+	for _, v := range ecVectors {
+		if v.confirmation == "" {
+			continue
+		}
+		address, err := VerifyConfirmation(v.confirmation, v.passphrase)
+		if err != nil || address != v.address {
+			t.Errorf("VerifyConfirmation(%s) = %s, %v", v.confirmation, address, err)
+		}
+		if _, err := VerifyConfirmation(v.confirmation, "wrong"); !errors.Is(err, ErrWrongPassphrase) {
+			t.Errorf("错误口令: %v", err)
+		}
+	}
+}
+
+func TestECMultiplyRoundTrip(t *testing.T) {
+	t.Parallel()
+	for _, lot := range []bool{false, true} {
+		intermediate, err := NewIntermediateCode("passphrase", 263183, 1, lot)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, compressed := range []bool{false, true} {
+			result, err := GenerateEncrypted(intermediate, compressed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			key, err := Decrypt(result.Encrypted, "passphrase")
+			if err != nil || key.Address != result.Address || key.Compressed != compressed {
+				t.Errorf("lot=%v compressed=%v: Decrypt = %+v, %v", lot, compressed, key, err)
+			}
+			if address, err := VerifyConfirmation(result.Confirmation, "passphrase"); err != nil || address != result.Address {
+				t.Errorf("lot=%v compressed=%v: VerifyConfirmation = %s, %v", lot, compressed, address, err)
+			}
+		}
+	}
+
+	if _, err := NewIntermediateCode("x", 1<<20, 0, true); !errors.Is(err, ErrInvalidLotSequence) {
+		t.Errorf("批号超出范围: %v", err)
+	}
+	if _, err := GenerateEncrypted(ecVectors[0].encrypted, true); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("非中间码: %v", err)
+	}
+}
@@ -0,0 +1,221 @@
+// Package bip85 实现 BIP85：由一个 BIP32 主密钥确定性地派生子助记词、十六进制熵、WIF、xprv 与密码
+//
+// 每个应用对应 m/83696968'/{应用}'/... 下的强化路径，派生出的私钥 k 经
+// HMAC-SHA512(key="bip-entropy-from-k", k) 得到 64 字节熵，再按应用截取或编码。
+// 子密钥之间、子密钥与主密钥之间无法互相推导，只需备份主助记词。
+package bip85
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/tyler-smith/go-bip32"
+	"github.com/tyler-smith/go-bip39/wordlists"
+
+	"wallet_create_address/pkg/wallet"
+)
+
+// 应用编号
+const (
+	purpose       = 83696968
+	appBIP39      = 39
+	appWIF        = 2
+	appXPRV       = 32
+	appHex        = 128169
+	appPwdBase64  = 707764
+	appPwdBase85  = 707785
+	hmacKeyString = "bip-entropy-from-k"
+)
+
+var (
+	// ErrInvalidWordCount 助记词单词数不受支持
+	ErrInvalidWordCount = errors.New("助记词单词数须为 12、15、18、21 或 24")
+	// ErrUnknownLanguage 语言不受支持
+	ErrUnknownLanguage = errors.New("不支持的助记词语言")
+	// ErrInvalidLength 十六进制或密码长度超出范围
+	ErrInvalidLength = errors.New("长度超出范围")
+)
+
+// Language BIP85 的助记词语言及编号
+type Language struct {
+	Code      uint32
+	Name      string
+	Words     []string
+	Separator string
+}
+
+// Languages 支持的语言，名称用于命令行参数（葡萄牙语 9 暂无词表）
+var Languages = []Language{
+	{0, "english", wordlists.English, " "},
+	{1, "japanese", wordlists.Japanese, "　"},
+	{2, "korean", wordlists.Korean, " "},
+	{3, "spanish", wordlists.Spanish, " "},
+	{4, "chinese_simplified", wordlists.ChineseSimplified, " "},
+	{5, "chinese_traditional", wordlists.ChineseTraditional, " "},
+	{6, "french", wordlists.French, " "},
+	{7, "italian", wordlists.Italian, " "},
+	{8, "czech", wordlists.Czech, " "},
+}
+
+// LookupLanguage 按名称查找语言
+func LookupLanguage(name string) (Language, error) {
+	for _, lang := range Languages {
+		if lang.Name == strings.ToLower(name) {
+			return lang, nil
+		}
+	}
+	return Language{}, fmt.Errorf("%w: %s", ErrUnknownLanguage, name)
+}
+
+// Result 一次派生的结果
+type Result struct {
+	Application string `json:"application"`
+	Path        string `json:"path"`
+	Value       string `json:"value"`
+}
+
+// Entropy 按路径派生 64 字节 BIP85 熵，路径必须全部为强化层级
+func Entropy(masterKey *bip32.Key, path string) ([]byte, error) {
+	key, err := wallet.DeriveKey(masterKey, path)
+	if err != nil {
+		return nil, err
+	}
+	mac := hmac.New(sha512.New, []byte(hmacKeyString))
+	mac.Write(key.Key)
+	return mac.Sum(nil), nil
+}
+
+// Mnemonic 派生子 BIP39 助记词：m/83696968'/39'/{语言}'/{单词数}'/{序号}'
+func Mnemonic(masterKey *bip32.Key, lang Language, words int, index uint32) (Result, error) {
+	if words < 12 || words > 24 || words%3 != 0 {
+		return Result{}, ErrInvalidWordCount
+	}
+	path := fmt.Sprintf("m/%d'/%d'/%d'/%d'/%d'", purpose, appBIP39, lang.Code, words, index)
+	entropy, err := Entropy(masterKey, path)
+	if err != nil {
+		return Result{}, err
+	}
+	mnemonic := encodeMnemonic(entropy[:words*4/3], lang)
+	return Result{Application: "bip39", Path: path, Value: mnemonic}, nil
+}
+
+// Hex 派生 numBytes（16-64）字节的十六进制熵：m/83696968'/128169'/{字节数}'/{序号}'
+func Hex(masterKey *bip32.Key, numBytes int, index uint32) (Result, error) {
+	if numBytes < 16 || numBytes > 64 {
+		return Result{}, fmt.Errorf("%w: 字节数须在 16-64 之间", ErrInvalidLength)
+	}
+	path := fmt.Sprintf("m/%d'/%d'/%d'/%d'", purpose, appHex, numBytes, index)
+	entropy, err := Entropy(masterKey, path)
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{Application: "hex", Path: path, Value: fmt.Sprintf("%x", entropy[:numBytes])}, nil
+}
+
+// WIF 派生压缩公钥的主网 WIF 私钥：m/83696968'/2'/{序号}'
+func WIF(masterKey *bip32.Key, index uint32) (Result, error) {
+	path := fmt.Sprintf("m/%d'/%d'/%d'", purpose, appWIF, index)
+	entropy, err := Entropy(masterKey, path)
+	if err != nil {
+		return Result{}, err
+	}
+	privateKey, _ := btcec.PrivKeyFromBytes(entropy[:32])
+	wif, err := btcutil.NewWIF(privateKey, &chaincfg.MainNetParams, true)
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{Application: "wif", Path: path, Value: wif.String()}, nil
+}
+
+// XPRV 派生新的主扩展私钥：m/83696968'/32'/{序号}'，链码取前 32 字节，私钥取后 32 字节
+func XPRV(masterKey *bip32.Key, index uint32) (Result, error) {
+	path := fmt.Sprintf("m/%d'/%d'/%d'", purpose, appXPRV, index)
+	entropy, err := Entropy(masterKey, path)
+	if err != nil {
+		return Result{}, err
+	}
+	key := &bip32.Key{
+		Version:     bip32.PrivateWalletVersion,
+		ChainCode:   entropy[:32],
+		Key:         entropy[32:],
+		Depth:       0,
+		ChildNumber: []byte{0, 0, 0, 0},
+		FingerPrint: []byte{0, 0, 0, 0},
+		IsPrivate:   true,
+	}
+	return Result{Application: "xprv", Path: path, Value: key.B58Serialize()}, nil
+}
+
+// PasswordBase64 派生 Base64 密码（长度 20-86）：m/83696968'/707764'/{长度}'/{序号}'
+func PasswordBase64(masterKey *bip32.Key, length int, index uint32) (Result, error) {
+	if length < 20 || length > 86 {
+		return Result{}, fmt.Errorf("%w: Base64 密码长度须在 20-86 之间", ErrInvalidLength)
+	}
+	path := fmt.Sprintf("m/%d'/%d'/%d'/%d'", purpose, appPwdBase64, length, index)
+	entropy, err := Entropy(masterKey, path)
+	if err != nil {
+		return Result{}, err
+	}
+	password := base64.StdEncoding.EncodeToString(entropy)[:length]
+	return Result{Application: "pwd-base64", Path: path, Value: password}, nil
+}
+
+// PasswordBase85 派生 Base85 密码（长度 10-80）：m/83696968'/707785'/{长度}'/{序号}'
+func PasswordBase85(masterKey *bip32.Key, length int, index uint32) (Result, error) {
+	if length < 10 || length > 80 {
+		return Result{}, fmt.Errorf("%w: Base85 密码长度须在 10-80 之间", ErrInvalidLength)
+	}
+	path := fmt.Sprintf("m/%d'/%d'/%d'/%d'", purpose, appPwdBase85, length, index)
+	entropy, err := Entropy(masterKey, path)
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{Application: "pwd-base85", Path: path, Value: encodeBase85(entropy)[:length]}, nil
+}
+
+// encodeMnemonic 按指定词表把熵编码为 BIP39 助记词（不修改 go-bip39 的全局词表）
+func encodeMnemonic(entropy []byte, lang Language) string {
+	checksumBits := len(entropy) / 4
+	hash := sha256.Sum256(entropy)
+
+	// 熵后追加 SHA256 的前 checksumBits 位，每 11 位对应一个单词
+	n := new(big.Int).SetBytes(entropy)
+	n.Lsh(n, uint(checksumBits))
+	n.Or(n, big.NewInt(int64(hash[0]>>(8-checksumBits))))
+
+	wordCount := (len(entropy)*8 + checksumBits) / 11
+	words := make([]string, wordCount)
+	mask := big.NewInt(2047)
+	for i := wordCount - 1; i >= 0; i-- {
+		words[i] = lang.Words[new(big.Int).And(n, mask).Int64()]
+		n.Rsh(n, 11)
+	}
+	return strings.Join(words, lang.Separator)
+}
+
+// base85Charset RFC 1924 字符集（与 Python base64.b85encode 相同）
+const base85Charset = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz!#$%&()*+-;<=>?@^_`{|}~"
+
+// encodeBase85 每 4 字节编码为 5 个字符，输入长度须为 4 的倍数
+func encodeBase85(data []byte) string {
+	var sb strings.Builder
+	for i := 0; i+4 <= len(data); i += 4 {
+		v := uint32(data[i])<<24 | uint32(data[i+1])<<16 | uint32(data[i+2])<<8 | uint32(data[i+3])
+		var chunk [5]byte
+		for j := 4; j >= 0; j-- {
+			chunk[j] = base85Charset[v%85]
+			v /= 85
+		}
+		sb.Write(chunk[:])
+	}
+	return sb.String()
+}
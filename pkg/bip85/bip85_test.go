@@ -0,0 +1,98 @@
+package bip85
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/tyler-smith/go-bip32"
+)
+
+// testMasterKey BIP85 测试向量使用的主密钥
+func testMasterKey(t *testing.T) *bip32.Key {
+	t.Helper()
+	masterKey, err := bip32.B58Deserialize("xprv9s21ZrQH143K2LBWUUQRFXhucrQqBpKdRRxNVq2zBqsx8HVqFk2uYo8kmbaLLHRdqtQpUm98uKfu3vca1LqdGhUtyoFnCNkfmXRyPXLjbKb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return masterKey
+}
+
+func TestEntropyVectors(t *testing.T) {
+	masterKey := testMasterKey(t)
+	tests := map[string]string{
+		"m/83696968'/0'/0'": "efecfbccffea313214232d29e71563d941229afb4338c21f9517c41aaa0d16f00b83d2a09ef747e7a64e8e2bd5a14869e693da66ce94ac2da570ab7ee48618f7",
+		"m/83696968'/0'/1'": "70c6e3e8ebee8dc4c0dbba66076819bb8c09672527c4277ca8729532ad711872218f826919f6b67218adde99018a6df9095ab2b58d803b5b93ec9802085a690e",
+	}
+	for path, want := range tests {
+		if got, err := Entropy(masterKey, path); err != nil || hex.EncodeToString(got) != want {
+			t.Errorf("Entropy(%s) = %x, %v", path, got, err)
+		}
+	}
+}
+
+func TestApplicationVectors(t *testing.T) {
+	masterKey := testMasterKey(t)
+	english, err := LookupLanguage("English")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mnemonic := func(words int) func() (Result, error) {
+		return func() (Result, error) { return Mnemonic(masterKey, english, words, 0) }
+	}
+
+	// BIP85 规范中的测试向量
+	tests := []struct {
+		name   string
+		derive func() (Result, error)
+		path   string
+		want   string
+	}{
+		{"BIP39 12 词", mnemonic(12), "m/83696968'/39'/0'/12'/0'",
+			"girl mad pet galaxy egg matter matrix prison refuse sense ordinary nose"},
+		{"BIP39 18 词", mnemonic(18), "m/83696968'/39'/0'/18'/0'",
+			"near account window bike charge season chef number sketch tomorrow excuse sniff circle vital hockey outdoor supply token"},
+		{"BIP39 24 词", mnemonic(24), "m/83696968'/39'/0'/24'/0'",
+			"puppy ocean match cereal symbol another shed magic wrap hammer bulb intact gadget divorce twin tonight reason outdoor destroy simple truth cigar social volcano"},
+		{"HEX", func() (Result, error) { return Hex(masterKey, 64, 0) }, "m/83696968'/128169'/64'/0'",
+			"492db4698cf3b73a5a24998aa3e9d7fa96275d85724a91e71aa2d645442f878555d078fd1f1f67e368976f04137b1f7a0d19232136ca50c44614af72b5582a5c"},
+		{"WIF", func() (Result, error) { return WIF(masterKey, 0) }, "m/83696968'/2'/0'",
+			"Kzyv4uF39d4Jrw2W7UryTHwZr1zQVNk4dAFyqE6BuMrMh1Za7uhp"},
+		{"XPRV", func() (Result, error) { return XPRV(masterKey, 0) }, "m/83696968'/32'/0'",
+			"xprv9s21ZrQH143K2srSbCSg4m4kLvPMzcWydgmKEnMmoZUurYuBuYG46c6P71UGXMzmriLzCCBvKQWBUv3vPB3m1SATMhp3uEjXHJ42jFg7myX"},
+		{"PWD BASE64", func() (Result, error) { return PasswordBase64(masterKey, 21, 0) }, "m/83696968'/707764'/21'/0'",
+			"dKLoepugzdVJvdL56ogNV"},
+		{"PWD BASE85", func() (Result, error) { return PasswordBase85(masterKey, 12, 0) }, "m/83696968'/707785'/12'/0'",
+			"_s`{TW89)i4`"},
+	}
+	for _, tt := range tests {
+		got, err := tt.derive()
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got.Path != tt.path || got.Value != tt.want {
+			t.Errorf("%s = %s %q, 期望 %s %q", tt.name, got.Path, got.Value, tt.path, tt.want)
+		}
+	}
+}
+
+func TestInvalidParams(t *testing.T) {
+	masterKey := testMasterKey(t)
+	english, _ := LookupLanguage("english")
+	if _, err := Mnemonic(masterKey, english, 13, 0); !errors.Is(err, ErrInvalidWordCount) {
+		t.Errorf("13 词: %v", err)
+	}
+	if _, err := Hex(masterKey, 15, 0); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("15 字节: %v", err)
+	}
+	if _, err := PasswordBase64(masterKey, 87, 0); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("Base64 87 位: %v", err)
+	}
+	if _, err := PasswordBase85(masterKey, 9, 0); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("Base85 9 位: %v", err)
+	}
+	if _, err := LookupLanguage("portuguese"); !errors.Is(err, ErrUnknownLanguage) {
+		t.Errorf("未知语言: %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+package chain
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/crypto/ripemd160"
+)
+
+// EthereumAddress 生成以太坊地址（EIP-55 校验和格式），BSC 与 Polygon 地址相同
+func EthereumAddress(publicKey *ecdsa.PublicKey) string {
+	return crypto.PubkeyToAddress(*publicKey).Hex()
+}
+
+// 比特币地址类型
+const (
+	BTCP2PKH      = "p2pkh"       // 传统地址 1...
+	BTCP2SHP2WPKH = "p2sh-p2wpkh" // 嵌套隔离见证 3...
+	BTCP2WPKH     = "p2wpkh"      // 原生隔离见证 bc1q...
+	BTCP2TR       = "p2tr"        // Taproot bc1p...（BIP86 单密钥，无脚本路径）
+
+	BTCP2PKHUncompressed = "p2pkh-uncompressed" // 未压缩公钥的传统地址
+)
+
+// ErrUnknownAddressType 不支持的比特币地址类型
+var ErrUnknownAddressType = errors.New("未知的比特币地址类型")
+
+// BitcoinAddress 生成比特币 P2PKH 地址（压缩公钥）
+func BitcoinAddress(publicKey *ecdsa.PublicKey) (string, error) {
+	return BitcoinAddressOfType(publicKey, BTCP2PKH, &chaincfg.MainNetParams)
+}
+
+// BitcoinAddressOfType 按地址类型和网络生成比特币地址（除 BTCP2PKHUncompressed 外均使用压缩公钥）
+func BitcoinAddressOfType(publicKey *ecdsa.PublicKey, addressType string, params *chaincfg.Params) (string, error) {
+	compressed := crypto.CompressPubkey(publicKey)
+	pubKeyHash := Hash160(compressed)
+
+	var address btcutil.Address
+	var err error
+	switch addressType {
+	case BTCP2PKH:
+		address, err = btcutil.NewAddressPubKeyHash(pubKeyHash, params)
+	case BTCP2WPKH:
+		address, err = btcutil.NewAddressWitnessPubKeyHash(pubKeyHash, params)
+	case BTCP2SHP2WPKH:
+		// 赎回脚本: OP_0 <20 字节公钥哈希>
+		redeemScript := append([]byte{0x00, 0x14}, pubKeyHash...)
+		address, err = btcutil.NewAddressScriptHash(redeemScript, params)
+	case BTCP2PKHUncompressed:
+		address, err = btcutil.NewAddressPubKeyHash(Hash160(crypto.FromECDSAPub(publicKey)), params)
+	case BTCP2TR:
+		var internalKey *btcec.PublicKey
+		if internalKey, err = btcec.ParsePubKey(compressed); err != nil {
+			return "", err
+		}
+		outputKey := txscript.ComputeTaprootKeyNoScript(internalKey)
+		address, err = btcutil.NewAddressTaproot(schnorr.SerializePubKey(outputKey), params)
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnknownAddressType, addressType)
+	}
+	if err != nil {
+		return "", err
+	}
+	return address.EncodeAddress(), nil
+}
+
+// TronAddressPrefix 波场地址的版本字节
+const TronAddressPrefix = 0x41
+
+// TronAddress 生成波场地址
+func TronAddress(publicKey *ecdsa.PublicKey) string {
+	pubKeyBytes := crypto.FromECDSAPub(publicKey)
+	hash := crypto.Keccak256(pubKeyBytes[1:])
+	return TronAddressFromHash(hash[12:])
+}
+
+// TronHexAddress 生成十六进制格式的波场地址（41 加 20 字节，TronGrid 等接口使用）
+func TronHexAddress(publicKey *ecdsa.PublicKey) string {
+	hash := crypto.Keccak256(crypto.FromECDSAPub(publicKey)[1:])
+	return TronHexAddressFromHash(hash[12:])
+}
+
+// Hash160 计算 RIPEMD160(SHA256(data))
+func Hash160(data []byte) []byte {
+	sha256Hash := sha256.Sum256(data)
+	ripemd160Hasher := ripemd160.New()
+	ripemd160Hasher.Write(sha256Hash[:])
+	return ripemd160Hasher.Sum(nil)
+}
@@ -0,0 +1,84 @@
+package chain
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// keyFromHex 测试用私钥
+func keyFromHex(t *testing.T, s string) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := crypto.HexToECDSA(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+// keyFromWIF 测试用 WIF 私钥
+func keyFromWIF(t *testing.T, s string) *ecdsa.PrivateKey {
+	t.Helper()
+	wif, err := btcutil.DecodeWIF(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return wif.PrivKey.ToECDSA()
+}
+
+func TestEthereumAndTronAddress(t *testing.T) {
+	key := keyFromHex(t, "0000000000000000000000000000000000000000000000000000000000000001")
+	if got := EthereumAddress(&key.PublicKey); got != "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf" {
+		t.Errorf("EthereumAddress = %s", got)
+	}
+	if got := TronAddress(&key.PublicKey); got != "TMVQGm1qAQYVdetCeGRRkTWYYrLXuHK2HC" {
+		t.Errorf("TronAddress = %s", got)
+	}
+	if got := TronHexAddress(&key.PublicKey); got != "417e5f4552091a69125d5dfcb7b8c2659029395bdf" {
+		t.Errorf("TronHexAddress = %s", got)
+	}
+}
+
+func TestBitcoinAddressOfType(t *testing.T) {
+	one := keyFromHex(t, "0000000000000000000000000000000000000000000000000000000000000001")
+	tests := []struct {
+		name        string
+		key         *ecdsa.PrivateKey
+		addressType string
+		params      *chaincfg.Params
+		want        string
+	}{
+		{"p2pkh", one, BTCP2PKH, &chaincfg.MainNetParams, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH"},
+		{"p2pkh 未压缩", one, BTCP2PKHUncompressed, &chaincfg.MainNetParams, "1EHNa6Q4Jz2uvNExL497mE43ikXhwF6kZm"},
+		{"p2sh-p2wpkh", one, BTCP2SHP2WPKH, &chaincfg.MainNetParams, "3JvL6Ymt8MVWiCNHC7oWU6nLeHNJKLZGLN"},
+		{"p2wpkh", one, BTCP2WPKH, &chaincfg.MainNetParams, "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"},
+		// BIP84/86 测试向量的第一个接收地址
+		{"BIP84", keyFromWIF(t, "KyZpNDKnfs94vbrwhJneDi77V6jF64PWPF8x5cdJb8ifgg2DUc9d"), BTCP2WPKH,
+			&chaincfg.MainNetParams, "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu"},
+		{"BIP86", keyFromHex(t, "41f41d69260df4cf277826a9b65a3717e4eeddbeedf637f212ca096576479361"), BTCP2TR,
+			&chaincfg.MainNetParams, "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BitcoinAddressOfType(&tt.key.PublicKey, tt.addressType, tt.params)
+			if err != nil || got != tt.want {
+				t.Errorf("BitcoinAddressOfType = %s, %v, 期望 %s", got, err, tt.want)
+			}
+		})
+	}
+
+	if _, err := BitcoinAddressOfType(&one.PublicKey, "p2wsh", &chaincfg.MainNetParams); err == nil {
+		t.Error("未知地址类型应返回错误")
+	}
+}
+
+func TestHash160(t *testing.T) {
+	got := hex.EncodeToString(Hash160([]byte("")))
+	if got != "b472a266d0bd89c13706a4132ccfb16f7c3b9fcb" {
+		t.Errorf("Hash160(\"\") = %s", got)
+	}
+}
@@ -0,0 +1,227 @@
+package chain
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// base58Index 字符到数值的反查表，-1 表示不在字母表中
+var base58Index = func() (index [256]int8) {
+	for i := range index {
+		index[i] = -1
+	}
+	for i := 0; i < len(base58Alphabet); i++ {
+		index[base58Alphabet[i]] = int8(i)
+	}
+	return index
+}()
+
+// base58StackSize 编解码时放在栈上的缓冲区大小，足以容纳地址、WIF 和扩展密钥
+const base58StackSize = 128
+
+var (
+	// ErrInvalidBase58 字符串包含 Base58 字母表以外的字符
+	ErrInvalidBase58 = errors.New("无效的 Base58 字符")
+	// ErrBase58CheckLength Base58Check 数据不足以包含 4 字节校验和
+	ErrBase58CheckLength = errors.New("Base58Check 数据太短")
+)
+
+// Base58CharError Base58 字符串第 Position 个字符（从 1 开始）无效
+type Base58CharError struct {
+	Position int
+	Char     byte
+}
+
+func (e *Base58CharError) Error() string {
+	return fmt.Sprintf("%v: 第 %d 个字符 %q", ErrInvalidBase58, e.Position, e.Char)
+}
+
+func (e *Base58CharError) Unwrap() error {
+	return ErrInvalidBase58
+}
+
+// ChecksumError Base58Check 校验和不匹配
+type ChecksumError struct {
+	Expected, Actual [4]byte
+}
+
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("%v: 应为 %x，实际为 %x", ErrBadChecksum, e.Expected, e.Actual)
+}
+
+func (e *ChecksumError) Unwrap() error {
+	return ErrBadChecksum
+}
+
+// base58Checksum 双重 SHA256 的前 4 字节
+func base58Checksum(data []byte) [4]byte {
+	hash1 := sha256.Sum256(data)
+	hash2 := sha256.Sum256(hash1[:])
+	return [4]byte(hash2[:4])
+}
+
+// Base58CheckEncode 计算双重 SHA256 校验和并进行 Base58 编码
+func Base58CheckEncode(data []byte) string {
+	var stack [base58StackSize]byte
+	checksum := base58Checksum(data)
+	full := append(append(stack[:0], data...), checksum[:]...)
+	return Base58Encode(full)
+}
+
+// Base58Encode Base58 编码（比特币字母表）
+func Base58Encode(data []byte) string {
+	var stack [base58StackSize * 2]byte
+	return string(AppendBase58(stack[:0], data))
+}
+
+// base58LimbRadix 编码时每个 uint32 存 5 位 Base58 数字（58^5 < 2^32）
+const base58LimbRadix = 58 * 58 * 58 * 58 * 58
+
+// AppendBase58 把 data 的 Base58 编码追加到 dst 并返回
+// 中间结果放在栈上，dst 容量足够时不分配内存（地址匹配的热路径）
+func AppendBase58(dst, data []byte) []byte {
+	zeros := 0
+	for zeros < len(data) && data[zeros] == 0 {
+		zeros++
+	}
+	data = data[zeros:]
+
+	// 编码后的位数上限：log(256)/log(58) ≈ 1.38，每个 limb 5 位
+	limbCount := (len(data)*138/100+1)/5 + 1
+	var stack [base58StackSize / 2]uint32
+	var limbs []uint32
+	if limbCount <= len(stack) {
+		limbs = stack[:limbCount]
+	} else {
+		limbs = make([]uint32, limbCount)
+	}
+
+	// 每次读入最多 4 字节做 limbs = limbs*2^(8n) + chunk（58^5 进制大端），high 之前的 limb 全为 0
+	high := limbCount - 1
+	for len(data) > 0 {
+		n := len(data) % 4
+		if n == 0 {
+			n = 4
+		}
+		var chunk uint64
+		for _, b := range data[:n] {
+			chunk = chunk<<8 | uint64(b)
+		}
+		data = data[n:]
+
+		carry := chunk
+		j := limbCount - 1
+		for ; j > high || carry != 0; j-- {
+			carry += uint64(limbs[j]) << (8 * n)
+			limbs[j] = uint32(carry % base58LimbRadix)
+			carry /= base58LimbRadix
+		}
+		high = j
+	}
+
+	for range zeros {
+		dst = append(dst, '1')
+	}
+	leading := true
+	for _, limb := range limbs[high+1:] {
+		var digits [5]byte
+		for k := 4; k >= 0; k-- {
+			digits[k] = byte(limb % 58)
+			limb /= 58
+		}
+		for _, d := range digits {
+			if leading && d == 0 {
+				continue
+			}
+			leading = false
+			dst = append(dst, base58Alphabet[d])
+		}
+	}
+	return dst
+}
+
+// Base58CheckDecode Base58 解码并验证双重 SHA256 校验和，返回去掉校验和的数据（含版本字节）
+// 校验和不匹配时返回 *ChecksumError（errors.Is 匹配 ErrBadChecksum）
+func Base58CheckDecode(s string) ([]byte, error) {
+	data, err := Base58Decode(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 5 {
+		return nil, ErrBase58CheckLength
+	}
+	payload := data[:len(data)-4]
+	expected := base58Checksum(payload)
+	if actual := [4]byte(data[len(data)-4:]); actual != expected {
+		return nil, &ChecksumError{Expected: expected, Actual: actual}
+	}
+	return payload, nil
+}
+
+// Base58Decode Base58 解码（比特币字母表），开头的每个 '1' 对应一个零字节
+// 含无效字符时返回 *Base58CharError（errors.Is 匹配 ErrInvalidBase58）
+func Base58Decode(s string) ([]byte, error) {
+	// 解码结果不会比字符串长，一次分配到位
+	return AppendBase58Decode(make([]byte, 0, len(s)), s)
+}
+
+// AppendBase58Decode 把 s 解码后的字节追加到 dst 并返回，dst 容量足够时不分配内存
+func AppendBase58Decode(dst []byte, s string) ([]byte, error) {
+	zeros := 0
+	for zeros < len(s) && s[zeros] == '1' {
+		zeros++
+	}
+
+	// 解码后的字节数上限：log(58)/log(256) ≈ 0.733，按 4 字节一个 limb 存放
+	size := (len(s)-zeros)*733/1000 + 1
+	limbCount := (size + 3) / 4
+	var stack [base58StackSize / 4]uint32
+	var limbs []uint32
+	if limbCount <= len(stack) {
+		limbs = stack[:limbCount]
+	} else {
+		limbs = make([]uint32, limbCount)
+	}
+
+	// 每次读入最多 5 个字符做 limbs = limbs*58^n + chunk（2^32 进制大端），high 之前的 limb 全为 0
+	high := limbCount - 1
+	for i := zeros; i < len(s); {
+		var chunk, multiplier uint64 = 0, 1
+		for n := 0; n < 5 && i < len(s); n, i = n+1, i+1 {
+			d := base58Index[s[i]]
+			if d < 0 {
+				return nil, &Base58CharError{Position: i + 1, Char: s[i]}
+			}
+			chunk = chunk*58 + uint64(d)
+			multiplier *= 58
+		}
+
+		carry := chunk
+		j := limbCount - 1
+		for ; j > high || carry != 0; j-- {
+			carry += uint64(limbs[j]) * multiplier
+			limbs[j] = uint32(carry)
+			carry >>= 32
+		}
+		high = j
+	}
+
+	for range zeros {
+		dst = append(dst, 0)
+	}
+	leading := true
+	for _, limb := range limbs[high+1:] {
+		for shift := 24; shift >= 0; shift -= 8 {
+			b := byte(limb >> shift)
+			if leading && b == 0 {
+				continue
+			}
+			leading = false
+			dst = append(dst, b)
+		}
+	}
+	return dst, nil
+}
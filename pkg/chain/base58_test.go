@@ -0,0 +1,128 @@
+package chain
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/base58"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestBase58Errors(t *testing.T) {
+	var charErr *Base58CharError
+	if _, err := Base58Decode("1BgG0Z9"); !errors.As(err, &charErr) || charErr.Position != 5 || charErr.Char != '0' ||
+		!errors.Is(err, ErrInvalidBase58) {
+		t.Errorf("无效字符: %v", err)
+	}
+
+	var checksumErr *ChecksumError
+	if _, err := Base58CheckDecode("TMVQGm1qAQYVdetCeGRRkTWYYrLXuHK2HD"); !errors.As(err, &checksumErr) || !errors.Is(err, ErrBadChecksum) {
+		t.Errorf("校验和错误: %v", err)
+	}
+	if _, err := Base58CheckDecode("1111"); !errors.Is(err, ErrBase58CheckLength) {
+		t.Errorf("数据太短: %v", err)
+	}
+
+	if got, err := Base58Decode("1112"); err != nil || !bytes.Equal(got, []byte{0, 0, 0, 1}) {
+		t.Errorf("前导零: %x, %v", got, err)
+	}
+}
+
+// FuzzBase58 编码结果与 btcutil/base58 一致，且解码后还原
+func FuzzBase58(f *testing.F) {
+	for _, seed := range [][]byte{nil, {0}, {0, 0, 1}, {0xff, 0xff}, bytes.Repeat([]byte{0xab}, 100)} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		encoded := Base58Encode(data)
+		if want := base58.Encode(data); encoded != want {
+			t.Fatalf("Base58Encode(%x) = %s, btcutil = %s", data, encoded, want)
+		}
+		decoded, err := Base58Decode(encoded)
+		if err != nil || !bytes.Equal(decoded, data) {
+			t.Fatalf("Base58Decode(%s) = %x, %v, 期望 %x", encoded, decoded, err, data)
+		}
+	})
+}
+
+// FuzzBase58Decode 任意字符串的解码结果与 btcutil/base58 一致（btcutil 对无效输入返回空）
+func FuzzBase58Decode(f *testing.F) {
+	for _, seed := range []string{"", "1", "11z", "TMVQGm1qAQYVdetCeGRRkTWYYrLXuHK2HC", "0OIl", "zzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzz"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		decoded, err := Base58Decode(s)
+		want := base58.Decode(s)
+		if err != nil {
+			if len(want) != 0 {
+				t.Fatalf("Base58Decode(%q): %v, btcutil = %x", s, err, want)
+			}
+			return
+		}
+		if !bytes.Equal(decoded, want) {
+			t.Fatalf("Base58Decode(%q) = %x, btcutil = %x", s, decoded, want)
+		}
+	})
+}
+
+// FuzzBase58Check Base58Check 编码与 btcutil/base58.CheckEncode 一致，且解码后还原版本字节和载荷
+func FuzzBase58Check(f *testing.F) {
+	f.Add(byte(TronAddressPrefix), bytes.Repeat([]byte{0x7e}, 20))
+	f.Add(byte(0), []byte{})
+	f.Fuzz(func(t *testing.T, version byte, payload []byte) {
+		encoded := Base58CheckEncode(append([]byte{version}, payload...))
+		if want := base58.CheckEncode(payload, version); encoded != want {
+			t.Fatalf("Base58CheckEncode = %s, btcutil = %s", encoded, want)
+		}
+		decoded, err := Base58CheckDecode(encoded)
+		if err != nil || decoded[0] != version || !bytes.Equal(decoded[1:], payload) {
+			t.Fatalf("Base58CheckDecode(%s) = %x, %v", encoded, decoded, err)
+		}
+	})
+}
+
+// benchPayload Tron 地址的版本字节加 20 字节哈希
+var benchPayload = append([]byte{TronAddressPrefix}, bytes.Repeat([]byte{0x9c}, 20)...)
+
+func BenchmarkBase58CheckEncode(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		Base58CheckEncode(benchPayload)
+	}
+}
+
+func BenchmarkBase58CheckEncodeBtcutil(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		base58.CheckEncode(benchPayload[1:], benchPayload[0])
+	}
+}
+
+func BenchmarkBase58CheckDecode(b *testing.B) {
+	encoded := Base58CheckEncode(benchPayload)
+	b.ReportAllocs()
+	for range b.N {
+		Base58CheckDecode(encoded)
+	}
+}
+
+func BenchmarkBase58CheckDecodeBtcutil(b *testing.B) {
+	encoded := Base58CheckEncode(benchPayload)
+	b.ReportAllocs()
+	for range b.N {
+		base58.CheckDecode(encoded)
+	}
+}
+
+// BenchmarkTronAddress 地址匹配热路径：公钥到 T 开头地址
+func BenchmarkTronAddress(b *testing.B) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	for range b.N {
+		TronAddress(&key.PublicKey)
+	}
+}
@@ -0,0 +1,30 @@
+// Package chain 提供各区块链的地址编码
+package chain
+
+// 支持的链类型
+const (
+	ETH     = "eth"
+	BTC     = "btc"
+	BSC     = "bsc"
+	Polygon = "polygon"
+	Tron    = "tron"
+	All     = "all"
+)
+
+// Names 支持的全部链类型（不含 all）
+var Names = []string{ETH, BTC, Tron, BSC, Polygon}
+
+// IsSupported 检查链类型是否受支持
+func IsSupported(name string) bool {
+	for _, n := range Names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// IsEVM 检查链类型是否为 EVM 链（eth/bsc/polygon 地址格式相同）
+func IsEVM(name string) bool {
+	return name == ETH || name == BSC || name == Polygon
+}
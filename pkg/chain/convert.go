@@ -0,0 +1,73 @@
+package chain
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// 共享 20 字节 Keccak 哈希、可以互相转换的地址格式
+const (
+	FormatEthereum = "eth"      // 0x 开头，EIP-55 校验和（BSC/Polygon 相同）
+	FormatTron     = "tron"     // T 开头，Base58Check
+	FormatTronHex  = "tron-hex" // 41 开头的十六进制
+)
+
+// ConvertFormats 全部可转换的地址格式
+var ConvertFormats = []string{FormatEthereum, FormatTron, FormatTronHex}
+
+var (
+	// ErrNotConvertible 地址不是 EVM 或波场地址
+	ErrNotConvertible = errors.New("只有 EVM 与波场地址可以互相转换")
+	// ErrUnknownConvertFormat 不支持的目标格式
+	ErrUnknownConvertFormat = errors.New("未知的地址格式")
+)
+
+// AddressHash 校验 EVM 或波场地址并返回其 20 字节哈希
+func AddressHash(address string) ([]byte, error) {
+	info, err := ValidateAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	if info.Chain != ETH && info.Chain != Tron {
+		return nil, fmt.Errorf("%w: %s 地址", ErrNotConvertible, info.Chain)
+	}
+	return hex.DecodeString(info.Payload)
+}
+
+// ConvertAddress 把 EVM 或波场地址转换为 format 指定的格式
+func ConvertAddress(address, format string) (string, error) {
+	hash, err := AddressHash(address)
+	if err != nil {
+		return "", err
+	}
+	switch format {
+	case FormatEthereum:
+		return EthereumAddressFromHash(hash), nil
+	case FormatTron:
+		return TronAddressFromHash(hash), nil
+	case FormatTronHex:
+		return TronHexAddressFromHash(hash), nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnknownConvertFormat, format)
+}
+
+// EthereumAddressFromHash 由 20 字节哈希生成 EIP-55 以太坊地址
+func EthereumAddressFromHash(hash []byte) string {
+	return common.BytesToAddress(hash).Hex()
+}
+
+// TronAddressFromHash 由 20 字节哈希生成 T 开头的波场地址
+func TronAddressFromHash(hash []byte) string {
+	var data [21]byte
+	data[0] = TronAddressPrefix
+	copy(data[1:], hash)
+	return Base58CheckEncode(data[:])
+}
+
+// TronHexAddressFromHash 由 20 字节哈希生成 41 开头的波场十六进制地址
+func TronHexAddressFromHash(hash []byte) string {
+	return fmt.Sprintf("%02x%s", TronAddressPrefix, hex.EncodeToString(hash))
+}
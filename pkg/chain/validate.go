@@ -0,0 +1,215 @@
+package chain
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil/bech32"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	// ErrUnknownFormat 无法识别地址所属的链
+	ErrUnknownFormat = errors.New("无法识别的地址格式")
+	// ErrBadChecksum 地址校验和错误
+	ErrBadChecksum = errors.New("地址校验和错误")
+)
+
+// 地址编码
+const (
+	EncodingEIP55       = "eip55"       // 大小写混合，含 EIP-55 校验和
+	EncodingLowercase   = "lowercase"   // 全小写或全大写的十六进制，无校验和
+	EncodingBase58Check = "base58check" // Base58 加 4 字节双重 SHA256 校验和
+	EncodingHex         = "hex"         // 波场 41 开头的十六进制，无校验和
+	EncodingBech32      = "bech32"      // BIP173，隔离见证 v0
+	EncodingBech32m     = "bech32m"     // BIP350，隔离见证 v1 及以上
+)
+
+// AddressInfo 地址校验结果
+type AddressInfo struct {
+	Address  string `json:"address"`
+	Chain    string `json:"chain"`
+	Type     string `json:"type"`
+	Encoding string `json:"encoding"`
+	Network  string `json:"network,omitempty"` // 比特币地址的网络: mainnet|testnet
+	Version  int    `json:"version"`           // Base58Check 版本字节或隔离见证版本，EVM 地址为 0
+	Payload  string `json:"payload"`           // 解码出的公钥哈希、脚本哈希或见证程序（十六进制）
+}
+
+// ValidateAddress 识别地址所属的链并校验格式与校验和
+// EVM 地址（eth/bsc/polygon 相同）统一识别为 eth；波场地址可以是 T 开头的 Base58Check 或 41 开头的十六进制
+func ValidateAddress(address string) (*AddressInfo, error) {
+	address = strings.TrimSpace(address)
+
+	switch {
+	case strings.HasPrefix(address, "0x") || strings.HasPrefix(address, "0X"):
+		return validateEthereum(address)
+	case strings.HasPrefix(address, "T"):
+		return validateTron(address)
+	case len(address) == 42 && strings.HasPrefix(address, "41"):
+		return validateTronHex(address)
+	default:
+		return validateBitcoin(address)
+	}
+}
+
+// validateEthereum 校验以太坊地址及 EIP-55 校验和
+func validateEthereum(address string) (*AddressInfo, error) {
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("%w: 以太坊地址必须为 0x 加 40 位十六进制", ErrUnknownFormat)
+	}
+
+	hexPart := address[2:]
+	encoding := EncodingLowercase
+	if strings.ToLower(hexPart) != hexPart && strings.ToUpper(hexPart) != hexPart {
+		if common.HexToAddress(address).Hex() != address {
+			return nil, ErrBadChecksum
+		}
+		encoding = EncodingEIP55
+	}
+
+	return &AddressInfo{
+		Address:  address,
+		Chain:    ETH,
+		Type:     "evm",
+		Encoding: encoding,
+		Payload:  strings.ToLower(hexPart),
+	}, nil
+}
+
+// validateTron 校验波场 Base58Check 地址
+func validateTron(address string) (*AddressInfo, error) {
+	data, err := Base58CheckDecode(address)
+	if err != nil {
+		if errors.Is(err, ErrBadChecksum) {
+			return nil, ErrBadChecksum
+		}
+		return nil, fmt.Errorf("%w: %v", ErrUnknownFormat, err)
+	}
+	if len(data) != 21 || data[0] != TronAddressPrefix {
+		return nil, fmt.Errorf("%w: 波场地址前缀或长度错误", ErrUnknownFormat)
+	}
+
+	return &AddressInfo{
+		Address:  address,
+		Chain:    Tron,
+		Type:     "base58check",
+		Encoding: EncodingBase58Check,
+		Version:  TronAddressPrefix,
+		Payload:  hex.EncodeToString(data[1:]),
+	}, nil
+}
+
+// validateTronHex 校验 41 开头的波场十六进制地址（没有校验和）
+func validateTronHex(address string) (*AddressInfo, error) {
+	data, err := hex.DecodeString(address)
+	if err != nil {
+		return nil, fmt.Errorf("%w: 波场十六进制地址必须为 41 加 40 位十六进制", ErrUnknownFormat)
+	}
+	return &AddressInfo{
+		Address:  address,
+		Chain:    Tron,
+		Type:     "hex",
+		Encoding: EncodingHex,
+		Version:  TronAddressPrefix,
+		Payload:  hex.EncodeToString(data[1:]),
+	}, nil
+}
+
+// bitcoinBase58Versions 比特币 Base58Check 地址的版本字节
+var bitcoinBase58Versions = map[byte]struct{ network, addressType string }{
+	0x00: {"mainnet", "p2pkh"},
+	0x05: {"mainnet", "p2sh"},
+	0x6f: {"testnet", "p2pkh"},
+	0xc4: {"testnet", "p2sh"},
+}
+
+// bitcoinHRPs 隔离见证地址的人类可读前缀
+var bitcoinHRPs = map[string]string{
+	"bc": "mainnet",
+	"tb": "testnet",
+}
+
+// validateBitcoin 校验比特币主网或测试网地址：Base58Check（1/3/m/n/2）或 bech32/bech32m（bc1/tb1）
+func validateBitcoin(address string) (*AddressInfo, error) {
+	lower := strings.ToLower(address)
+	if strings.HasPrefix(lower, "bc1") || strings.HasPrefix(lower, "tb1") {
+		return validateSegwit(address)
+	}
+
+	data, err := Base58CheckDecode(address)
+	if err != nil {
+		if errors.Is(err, ErrBadChecksum) {
+			return nil, ErrBadChecksum
+		}
+		return nil, fmt.Errorf("%w: %v", ErrUnknownFormat, err)
+	}
+	version, ok := bitcoinBase58Versions[data[0]]
+	if !ok || len(data) != 21 {
+		return nil, fmt.Errorf("%w: 未知的版本字节 0x%02x 或长度错误", ErrUnknownFormat, data[0])
+	}
+
+	return &AddressInfo{
+		Address:  address,
+		Chain:    BTC,
+		Type:     version.addressType,
+		Encoding: EncodingBase58Check,
+		Network:  version.network,
+		Version:  int(data[0]),
+		Payload:  hex.EncodeToString(data[1:]),
+	}, nil
+}
+
+// validateSegwit 校验隔离见证地址：v0 必须使用 bech32，v1 及以上必须使用 bech32m（BIP350）
+func validateSegwit(address string) (*AddressInfo, error) {
+	hrp, data, bechVersion, err := bech32.DecodeGeneric(address)
+	if err != nil {
+		var checksumErr bech32.ErrInvalidChecksum
+		if errors.As(err, &checksumErr) {
+			return nil, ErrBadChecksum
+		}
+		return nil, fmt.Errorf("%w: %v", ErrUnknownFormat, err)
+	}
+	network, ok := bitcoinHRPs[hrp]
+	if !ok || len(data) < 1 || data[0] > 16 {
+		return nil, fmt.Errorf("%w: 无效的隔离见证前缀或版本", ErrUnknownFormat)
+	}
+
+	witnessVersion := int(data[0])
+	program, err := bech32.ConvertBits(data[1:], 5, 8, false)
+	if err != nil || len(program) < 2 || len(program) > 40 {
+		return nil, fmt.Errorf("%w: 见证程序长度错误", ErrUnknownFormat)
+	}
+
+	encoding := EncodingBech32
+	if witnessVersion > 0 {
+		encoding = EncodingBech32m
+	}
+	if (encoding == EncodingBech32) != (bechVersion == bech32.Version0) {
+		return nil, fmt.Errorf("%w: 隔离见证 v%d 地址必须使用 %s 编码", ErrBadChecksum, witnessVersion, encoding)
+	}
+
+	info := &AddressInfo{
+		Address:  address,
+		Chain:    BTC,
+		Encoding: encoding,
+		Network:  network,
+		Version:  witnessVersion,
+		Payload:  hex.EncodeToString(program),
+	}
+	switch {
+	case witnessVersion == 0 && len(program) == 20:
+		info.Type = "p2wpkh"
+	case witnessVersion == 0 && len(program) == 32:
+		info.Type = "p2wsh"
+	case witnessVersion == 0:
+		return nil, fmt.Errorf("%w: 隔离见证 v0 程序必须为 20 或 32 字节", ErrUnknownFormat)
+	case witnessVersion == 1 && len(program) == 32:
+		info.Type = "p2tr"
+	default:
+		info.Type = fmt.Sprintf("witness_v%d", witnessVersion)
+	}
+	return info, nil
+}
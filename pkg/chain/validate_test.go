@@ -0,0 +1,88 @@
+package chain
+
+import (
+	"errors"
+	"testing"
+)
+
+// 私钥 1 的公钥哈希（Keccak 与 HASH160）
+const (
+	keyOneKeccak  = "7e5f4552091a69125d5dfcb7b8c2659029395bdf"
+	keyOneHash160 = "751e76e8199196d454941c45d1b3a323f1433bd6"
+)
+
+func TestValidateAddress(t *testing.T) {
+	tests := []struct {
+		address  string
+		chain    string
+		typ      string
+		encoding string
+		network  string
+		payload  string
+	}{
+		{"0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf", ETH, "evm", EncodingEIP55, "", keyOneKeccak},
+		{"0x7e5f4552091a69125d5dfcb7b8c2659029395bdf", ETH, "evm", EncodingLowercase, "", keyOneKeccak},
+		{"TMVQGm1qAQYVdetCeGRRkTWYYrLXuHK2HC", Tron, "base58check", EncodingBase58Check, "", keyOneKeccak},
+		{"417e5f4552091a69125d5dfcb7b8c2659029395bdf", Tron, "hex", EncodingHex, "", keyOneKeccak},
+		{"1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", BTC, "p2pkh", EncodingBase58Check, "mainnet", keyOneHash160},
+		{"3JvL6Ymt8MVWiCNHC7oWU6nLeHNJKLZGLN", BTC, "p2sh", EncodingBase58Check, "mainnet", "bcfeb728b584253d5f3f70bcb780e9ef218a68f4"},
+		// BIP173/BIP350 测试向量
+		{"BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4", BTC, "p2wpkh", EncodingBech32, "mainnet", keyOneHash160},
+		{"tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3q0sl5k7", BTC, "p2wsh", EncodingBech32, "testnet",
+			"1863143c14c5166804bd19203356da136c985678cd4d27a1b8c6329604903262"},
+		{"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0", BTC, "p2tr", EncodingBech32m, "mainnet",
+			"79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"},
+	}
+	for _, tt := range tests {
+		info, err := ValidateAddress(" " + tt.address + "\n")
+		if err != nil {
+			t.Errorf("ValidateAddress(%s): %v", tt.address, err)
+			continue
+		}
+		if info.Address != tt.address || info.Chain != tt.chain || info.Type != tt.typ || info.Encoding != tt.encoding ||
+			info.Network != tt.network || info.Payload != tt.payload {
+			t.Errorf("ValidateAddress(%s) = %+v", tt.address, info)
+		}
+	}
+}
+
+func TestValidateAddressErrors(t *testing.T) {
+	tests := map[string]error{
+		"0x7E5F4552091A69125d5DfCb7b8C2659029395BDf": ErrBadChecksum, // 大小写不符合 EIP-55
+		"TMVQGm1qAQYVdetCeGRRkTWYYrLXuHK2HD":         ErrBadChecksum,
+		"1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMJ":         ErrBadChecksum,
+		// BIP350：v0 使用 bech32m、v1 使用 bech32 均无效
+		"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kemeawh":                     ErrBadChecksum,
+		"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqh2y7hd": ErrBadChecksum,
+		"0x7e5f4552091a69125d5dfcb7b8c2659029395b":                       ErrUnknownFormat,
+		"tc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vq5zuyut": ErrUnknownFormat,
+		"hello": ErrUnknownFormat,
+	}
+	for address, want := range tests {
+		if _, err := ValidateAddress(address); !errors.Is(err, want) {
+			t.Errorf("ValidateAddress(%s): %v, 期望 %v", address, err, want)
+		}
+	}
+}
+
+func TestConvertAddress(t *testing.T) {
+	want := map[string]string{
+		FormatEthereum: "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf",
+		FormatTron:     "TMVQGm1qAQYVdetCeGRRkTWYYrLXuHK2HC",
+		FormatTronHex:  "417e5f4552091a69125d5dfcb7b8c2659029395bdf",
+	}
+	for _, from := range want {
+		for format, to := range want {
+			if got, err := ConvertAddress(from, format); err != nil || got != to {
+				t.Errorf("ConvertAddress(%s, %s) = %s, %v", from, format, got, err)
+			}
+		}
+	}
+
+	if _, err := ConvertAddress("1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", FormatTron); !errors.Is(err, ErrNotConvertible) {
+		t.Errorf("比特币地址: %v", err)
+	}
+	if _, err := ConvertAddress(want[FormatTron], "btc"); !errors.Is(err, ErrUnknownConvertFormat) {
+		t.Errorf("未知格式: %v", err)
+	}
+}
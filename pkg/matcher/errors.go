@@ -0,0 +1,24 @@
+package matcher
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrPatternTooLong 匹配模式超过地址长度
+var ErrPatternTooLong = errors.New("模式太长")
+
+// RuleError 匹配规则无效
+type RuleError struct {
+	Rule  string
+	Value string
+	Err   error
+}
+
+func (e *RuleError) Error() string {
+	return fmt.Sprintf("无效的匹配规则 %s=%q: %v", e.Rule, e.Value, e.Err)
+}
+
+func (e *RuleError) Unwrap() error {
+	return e.Err
+}
@@ -1,18 +1,43 @@
-package main
+// Package matcher 提供靓号地址的规则匹配、稀有度评分与并发匹配服务
+package matcher
 
 import (
-	"fmt"
-	"log"
 	"regexp"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
+
+	"wallet_create_address/pkg/chain"
+	"wallet_create_address/pkg/wallet"
 )
 
+// Logger 日志接口，*log.Logger 即满足该接口
+type Logger interface {
+	Printf(format string, v ...any)
+}
+
+// Rules 匹配规则
+type Rules struct {
+	IgnoreCase   bool     `yaml:"ignore_case"`
+	Prefixes     []string `yaml:"prefixes"`
+	Suffixes     []string `yaml:"suffixes"`
+	SuffixesSame int      `yaml:"suffix_same"`
+	Contains     []string `yaml:"contains"`
+	Regex        string   `yaml:"regex"`
+}
+
+// Config 地址匹配器配置
+type Config struct {
+	Rules        Rules
+	TargetChains []string // 只有第一个才会匹配
+	MaxAttempts  int      // 0表示无限制
+	Logger       Logger   // 可选：输出匹配成功的调试信息
+}
+
 // AddressMatcher 地址匹配器
 type AddressMatcher struct {
-	config    *Config
+	config    Config
 	regex     *regexp.Regexp
 	attempts  int64
 	matched   int64
@@ -20,18 +45,26 @@ type AddressMatcher struct {
 	mutex     sync.RWMutex
 }
 
+// Stats 匹配统计信息
+type Stats struct {
+	Attempts int64
+	Matched  int64
+	Rate     float64 // 匹配率（百分比）
+	Duration time.Duration
+}
+
 // NewAddressMatcher 创建地址匹配器
-func NewAddressMatcher(config *Config) (*AddressMatcher, error) {
+func NewAddressMatcher(config Config) (*AddressMatcher, error) {
 	matcher := &AddressMatcher{
 		config:    config,
 		startTime: time.Now(),
 	}
 
 	// 预编译正则表达式
-	if config.AddressMatching.Rules.Regex != "" {
-		regex, err := regexp.Compile(config.AddressMatching.Rules.Regex)
+	if config.Rules.Regex != "" {
+		regex, err := regexp.Compile(config.Rules.Regex)
 		if err != nil {
-			return nil, fmt.Errorf("编译正则表达式失败: %v", err)
+			return nil, &RuleError{Rule: "regex", Value: config.Rules.Regex, Err: err}
 		}
 		matcher.regex = regex
 	}
@@ -39,17 +72,16 @@ func NewAddressMatcher(config *Config) (*AddressMatcher, error) {
 	return matcher, nil
 }
 
-// IsEnabled 检查是否启用地址匹配
-func (am *AddressMatcher) IsEnabled() bool {
-	return am.config.AddressMatching.Enabled
+// TargetChain 获取参与匹配的目标链
+func (am *AddressMatcher) TargetChain() string {
+	if len(am.config.TargetChains) == 0 {
+		return chain.All
+	}
+	return am.config.TargetChains[0]
 }
 
 // MatchWallet 检查钱包是否匹配规则
-func (am *AddressMatcher) MatchWallet(wallet MultiChainWallet) bool {
-	if !am.IsEnabled() {
-		return true
-	}
-
+func (am *AddressMatcher) MatchWallet(w wallet.MultiChainWallet) bool {
 	atomic.AddInt64(&am.attempts, 1)
 
 	// 检查各个链的地址
@@ -57,24 +89,24 @@ func (am *AddressMatcher) MatchWallet(wallet MultiChainWallet) bool {
 		name    string
 		address string
 	}{
-		{"eth", wallet.EthAddress},
-		{"btc", wallet.BtcAddress},
-		{"tron", wallet.TronAddress},
-		{"bsc", wallet.BscAddress},
-		{"polygon", wallet.PolygonAddress},
+		{chain.ETH, w.EthAddress},
+		{chain.BTC, w.BtcAddress},
+		{chain.Tron, w.TronAddress},
+		{chain.BSC, w.BscAddress},
+		{chain.Polygon, w.PolygonAddress},
 	}
-	switch am.config.AddressMatching.TargetChains[0] {
-	case "eth":
+	switch am.TargetChain() {
+	case chain.ETH:
 		chains = chains[:1]
-	case "btc":
+	case chain.BTC:
 		chains = chains[1:2]
-	case "tron":
+	case chain.Tron:
 		chains = chains[2:3]
-	case "bsc":
+	case chain.BSC:
 		chains = chains[3:4]
-	case "polygon":
+	case chain.Polygon:
 		chains = chains[4:5]
-	case "all":
+	case chain.All:
 		// 保持所有链
 	default:
 		return false // 未指定有效链
@@ -95,20 +127,20 @@ func (am *AddressMatcher) matchesChainAddress(address, chainName string) bool {
 		return false
 	}
 
-	rules := am.config.AddressMatching.Rules
+	rules := am.config.Rules
 
 	// 标准化地址（去除0x前缀进行匹配）
-	normalizedAddr := normalizeAddress(address)
+	normalizedAddr := NormalizeAddress(address)
 
 	// 检查后缀匹配 - 只要有一个匹配就返回true
 	if am.checkSuffixes(normalizedAddr, rules.Suffixes) {
-		log.Println(2, "后缀匹配成功:", normalizedAddr)
+		am.logf("后缀匹配成功: %s", normalizedAddr)
 		return true
 	}
 
 	// 检查后缀相同匹配 - 如果满足条件就返回true
 	if am.checkSuffixesSame(normalizedAddr, rules.SuffixesSame) {
-		log.Println(3, "后缀相同连号配成功:", normalizedAddr)
+		am.logf("后缀相同连号配成功: %s", normalizedAddr)
 		return true
 	}
 
@@ -121,15 +153,22 @@ func (am *AddressMatcher) matchesChainAddress(address, chainName string) bool {
 	return false
 }
 
+// logf 输出调试日志
+func (am *AddressMatcher) logf(format string, v ...any) {
+	if am.config.Logger != nil {
+		am.config.Logger.Printf(format, v...)
+	}
+}
+
 // isTargetChain 检查是否是目标链
 func (am *AddressMatcher) isTargetChain(chainName string) bool {
-	targetChains := am.config.AddressMatching.TargetChains
+	targetChains := am.config.TargetChains
 	if len(targetChains) == 0 {
 		return true
 	}
 
 	for _, target := range targetChains {
-		if target == "all" || target == chainName {
+		if target == chain.All || target == chainName {
 			return true
 		}
 	}
@@ -163,7 +202,7 @@ func (am *AddressMatcher) checkSuffixes(address string, suffixes []string) bool
 		if suffix == "" {
 			continue
 		}
-		if am.config.AddressMatching.Rules.IgnoreCase {
+		if am.config.Rules.IgnoreCase {
 			if strings.HasSuffix(strings.ToLower(address), strings.ToLower(suffix)) {
 				return true
 			}
@@ -195,7 +234,7 @@ func (am *AddressMatcher) checkSuffixesSame(address string, SuffixesSame int) bo
 		c := address[i]
 		if c == lastChar {
 			count++
-		} else if am.config.AddressMatching.Rules.IgnoreCase &&
+		} else if am.config.Rules.IgnoreCase &&
 			((c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')) &&
 			(c == lastChar+32 || c == lastChar-32) {
 			// 忽略大小写比较
@@ -235,40 +274,23 @@ func (am *AddressMatcher) checkRegex(address string) bool {
 }
 
 // GetStats 获取匹配统计信息
-func (am *AddressMatcher) GetStats() (attempts, matched int64, rate float64, duration time.Duration) {
-	attempts = atomic.LoadInt64(&am.attempts)
-	matched = atomic.LoadInt64(&am.matched)
-	duration = time.Since(am.startTime)
-
-	if attempts > 0 {
-		rate = float64(matched) / float64(attempts) * 100
+func (am *AddressMatcher) GetStats() Stats {
+	stats := Stats{
+		Attempts: atomic.LoadInt64(&am.attempts),
+		Matched:  atomic.LoadInt64(&am.matched),
+		Duration: time.Since(am.startTime),
 	}
 
-	return
-}
-
-// PrintStats 打印匹配统计信息
-func (am *AddressMatcher) PrintStats() {
-	attempts, matched, rate, duration := am.GetStats()
-
-	fmt.Printf("\n📊 地址匹配统计:\n")
-	fmt.Printf("尝试次数: %d\n", attempts)
-	fmt.Printf("匹配成功: %d\n", matched)
-	fmt.Printf("匹配率: %.2f%%\n", rate)
-	fmt.Printf("运行时间: %v\n", duration)
-
-	if attempts > 0 {
-		fmt.Printf("平均速度: %.2f 次/秒\n", float64(attempts)/duration.Seconds())
+	if stats.Attempts > 0 {
+		stats.Rate = float64(stats.Matched) / float64(stats.Attempts) * 100
 	}
+
+	return stats
 }
 
 // ShouldStop 检查是否应该停止生成
 func (am *AddressMatcher) ShouldStop() bool {
-	if !am.IsEnabled() {
-		return false
-	}
-
-	maxAttempts := am.config.AddressMatching.MaxAttempts
+	maxAttempts := am.config.MaxAttempts
 	if maxAttempts <= 0 {
 		return false // 无限制
 	}
@@ -283,34 +305,34 @@ func (am *AddressMatcher) Reset() {
 	am.startTime = time.Now()
 }
 
-// normalizeAddress 标准化地址（去除0x前缀，转小写）
-func normalizeAddress(address string) string {
+// NormalizeAddress 标准化地址（去除0x前缀）
+func NormalizeAddress(address string) string {
 	if len(address) >= 2 && strings.ToLower(address[:2]) == "0x" {
 		return address[2:]
 	}
 	return address
 }
 
-// ValidateMatchingRules 验证匹配规则
-func ValidateMatchingRules(rules MatchingRules) error {
+// ValidateRules 验证匹配规则
+func ValidateRules(rules Rules) error {
 	// 验证正则表达式
 	if rules.Regex != "" {
 		if _, err := regexp.Compile(rules.Regex); err != nil {
-			return fmt.Errorf("无效的正则表达式: %v", err)
+			return &RuleError{Rule: "regex", Value: rules.Regex, Err: err}
 		}
 	}
 
 	// 验证前缀格式
 	for _, prefix := range rules.Prefixes {
 		if len(prefix) > 40 { // 以太坊地址最长40字符（不含0x）
-			return fmt.Errorf("前缀太长: %s", prefix)
+			return &RuleError{Rule: "prefix", Value: prefix, Err: ErrPatternTooLong}
 		}
 	}
 
 	// 验证后缀格式
 	for _, suffix := range rules.Suffixes {
 		if len(suffix) > 40 {
-			return fmt.Errorf("后缀太长: %s", suffix)
+			return &RuleError{Rule: "suffix", Value: suffix, Err: ErrPatternTooLong}
 		}
 	}
 
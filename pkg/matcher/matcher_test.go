@@ -0,0 +1,99 @@
+package matcher
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"wallet_create_address/pkg/chain"
+	"wallet_create_address/pkg/wallet"
+)
+
+func TestMatchWallet(t *testing.T) {
+	w := wallet.MultiChainWallet{
+		EthAddress:  "0x52908400098527886E0F7030069857D2E4169EE7",
+		TronAddress: "TJRyWwFs9wTFGZg3JbrVriFbNfCug5tDeC",
+	}
+	tests := []struct {
+		name  string
+		chain string
+		rules Rules
+		want  bool
+	}{
+		{"后缀", chain.ETH, Rules{Suffixes: []string{"9EE7"}, SuffixesSame: 8}, true},
+		{"后缀区分大小写", chain.ETH, Rules{Suffixes: []string{"9ee7"}, SuffixesSame: 8}, false},
+		{"后缀忽略大小写", chain.ETH, Rules{Suffixes: []string{"9ee7"}, SuffixesSame: 8, IgnoreCase: true}, true},
+		{"多个后缀任一", chain.ETH, Rules{Suffixes: []string{"0000", "EE7"}, SuffixesSame: 8}, true},
+		{"其他链的地址", chain.Tron, Rules{Suffixes: []string{"9EE7"}, SuffixesSame: 8}, false},
+		{"波场后缀", chain.Tron, Rules{Suffixes: []string{"DeC"}, SuffixesSame: 8}, true},
+		{"无规则", chain.ETH, Rules{}, true},
+		{"未知链", "doge", Rules{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			am, err := NewAddressMatcher(Config{Rules: tt.rules, TargetChains: []string{tt.chain}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := am.MatchWallet(w); got != tt.want {
+				t.Errorf("MatchWallet = %v, 期望 %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckSuffixesSame(t *testing.T) {
+	am, _ := NewAddressMatcher(Config{})
+	if !am.checkSuffixesSame("abc8888", 4) || am.checkSuffixesSame("abc8888", 5) {
+		t.Error("区分大小写的连号判断错误")
+	}
+	am, _ = NewAddressMatcher(Config{Rules: Rules{IgnoreCase: true}})
+	if !am.checkSuffixesSame("xaAaA", 4) {
+		t.Error("忽略大小写时 aAaA 应视为 4 个相同字符")
+	}
+}
+
+func TestValidateRules(t *testing.T) {
+	var ruleErr *RuleError
+	if err := ValidateRules(Rules{Regex: "("}); !errors.As(err, &ruleErr) || ruleErr.Rule != "regex" {
+		t.Errorf("无效正则: %v", err)
+	}
+	long := strings.Repeat("a", 41)
+	if err := ValidateRules(Rules{Suffixes: []string{long}}); !errors.Is(err, ErrPatternTooLong) {
+		t.Errorf("过长后缀: %v", err)
+	}
+	if err := ValidateRules(Rules{Prefixes: []string{"abc"}, Suffixes: []string{"888"}, Regex: "^0x"}); err != nil {
+		t.Errorf("有效规则: %v", err)
+	}
+	if _, err := NewAddressMatcher(Config{Rules: Rules{Regex: "["}}); err == nil {
+		t.Error("NewAddressMatcher 应拒绝无效正则")
+	}
+}
+
+func TestMatchingServiceMaxMatch(t *testing.T) {
+	var callbacks int
+	ms, err := NewMatchingService(ServiceConfig{
+		Matcher: Config{
+			Rules:        Rules{Suffixes: []string{"a"}, SuffixesSame: 8, IgnoreCase: true},
+			TargetChains: []string{chain.ETH},
+		},
+		WorkerCount: 2,
+		MaxMatch:    3,
+	}, Callbacks{OnMatch: func(wallet.MultiChainWallet, int) { callbacks++ }})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := ms.Run(context.Background())
+	if len(result.Wallets) != 3 || callbacks != 3 {
+		t.Fatalf("匹配 %d 个钱包, 回调 %d 次", len(result.Wallets), callbacks)
+	}
+	for _, w := range result.Wallets {
+		if !strings.HasSuffix(strings.ToLower(w.EthAddress), "a") {
+			t.Errorf("%s 不满足规则", w.EthAddress)
+		}
+	}
+	if result.Stats.Matched < 3 || result.Stats.Attempts < result.Stats.Matched {
+		t.Errorf("统计信息 = %+v", result.Stats)
+	}
+}
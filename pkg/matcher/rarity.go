@@ -0,0 +1,201 @@
+package matcher
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"wallet_create_address/pkg/chain"
+	"wallet_create_address/pkg/wallet"
+)
+
+// RarityThresholds 稀有度阈值，0表示不检查该特征
+// IgnoreCase 只影响 Base58 地址；EVM 地址的大小写是 EIP-55 校验和而非地址本身，总是按小写评分
+type RarityThresholds struct {
+	IgnoreCase        bool `yaml:"ignore_case"`
+	MinRepeatRun      int  `yaml:"min_repeat_run"`
+	MinSequence       int  `yaml:"min_sequence"`
+	MinPalindromeTail int  `yaml:"min_palindrome_tail"`
+	MinDigitTail      int  `yaml:"min_digit_tail"`
+}
+
+// RareFind 稀有地址发现结果
+type RareFind struct {
+	Wallet  wallet.MultiChainWallet
+	Chain   string
+	Address string
+	Score   int
+	Reasons []string
+}
+
+// RarityScorer 稀有地址评分器
+// 与 AddressMatcher 并行工作，不依赖配置的匹配规则，
+// 而是根据地址本身的“客观稀有度”捕获靓号
+type RarityScorer struct {
+	chain      string
+	thresholds RarityThresholds
+	found      int64
+}
+
+// NewRarityScorer 创建评估指定链地址的稀有地址评分器
+func NewRarityScorer(chainName string, thresholds RarityThresholds) *RarityScorer {
+	return &RarityScorer{
+		chain:      chainName,
+		thresholds: thresholds,
+	}
+}
+
+// Chain 获取评估的链类型
+func (rs *RarityScorer) Chain() string {
+	return rs.chain
+}
+
+// Evaluate 评估钱包地址的稀有度，满足任一阈值时返回发现结果
+func (rs *RarityScorer) Evaluate(w wallet.MultiChainWallet) (*RareFind, bool) {
+	address, err := w.Address(rs.chain)
+	if err != nil || address == "" {
+		return nil, false
+	}
+
+	score, reasons := rs.Score(address)
+	if len(reasons) == 0 {
+		return nil, false
+	}
+
+	atomic.AddInt64(&rs.found, 1)
+	return &RareFind{
+		Wallet:  w,
+		Chain:   rs.chain,
+		Address: address,
+		Score:   score,
+		Reasons: reasons,
+	}, true
+}
+
+// Score 计算地址稀有度得分，返回得分和命中的特征
+func (rs *RarityScorer) Score(address string) (int, []string) {
+	cfg := rs.thresholds
+	addr := NormalizeAddress(address)
+	if cfg.IgnoreCase || chain.IsEVM(rs.chain) {
+		addr = strings.ToLower(addr)
+	}
+
+	score := 0
+	var reasons []string
+
+	if cfg.MinRepeatRun > 0 {
+		if run := longestRepeatRun(addr); run >= cfg.MinRepeatRun {
+			score += run
+			reasons = append(reasons, fmt.Sprintf("连续相同字符x%d", run))
+		}
+	}
+
+	if cfg.MinSequence > 0 {
+		if seq := longestSequence(addr); seq >= cfg.MinSequence {
+			score += seq
+			reasons = append(reasons, fmt.Sprintf("顺序/倒序序列x%d", seq))
+		}
+	}
+
+	if cfg.MinPalindromeTail > 0 {
+		if tail := palindromeTailLength(addr); tail >= cfg.MinPalindromeTail {
+			score += tail
+			reasons = append(reasons, fmt.Sprintf("回文尾号x%d", tail))
+		}
+	}
+
+	if cfg.MinDigitTail > 0 {
+		if tail := digitTailLength(addr); tail >= cfg.MinDigitTail {
+			score += tail
+			reasons = append(reasons, fmt.Sprintf("纯数字尾号x%d", tail))
+		}
+	}
+
+	return score, reasons
+}
+
+// FoundCount 获取已发现的稀有地址数量
+func (rs *RarityScorer) FoundCount() int64 {
+	return atomic.LoadInt64(&rs.found)
+}
+
+// longestRepeatRun 地址中任意位置最长的连续相同字符长度
+func longestRepeatRun(s string) int {
+	best, run := 0, 0
+	for i := 0; i < len(s); i++ {
+		if i > 0 && s[i] == s[i-1] {
+			run++
+		} else {
+			run = 1
+		}
+		if run > best {
+			best = run
+		}
+	}
+	return best
+}
+
+// longestSequence 地址中最长的递增或递减字符序列长度（如 12345678、fedcba）
+func longestSequence(s string) int {
+	best, up, down := 0, 0, 0
+	for i := 0; i < len(s); i++ {
+		if i > 0 && isSameClass(s[i], s[i-1]) && s[i] == s[i-1]+1 {
+			up++
+		} else {
+			up = 1
+		}
+		if i > 0 && isSameClass(s[i], s[i-1]) && s[i] == s[i-1]-1 {
+			down++
+		} else {
+			down = 1
+		}
+		if up > best {
+			best = up
+		}
+		if down > best {
+			best = down
+		}
+	}
+	return best
+}
+
+// isSameClass 两个字符是否同属数字、小写字母或大写字母
+func isSameClass(a, b byte) bool {
+	switch {
+	case a >= '0' && a <= '9':
+		return b >= '0' && b <= '9'
+	case a >= 'a' && a <= 'z':
+		return b >= 'a' && b <= 'z'
+	case a >= 'A' && a <= 'Z':
+		return b >= 'A' && b <= 'Z'
+	}
+	return false
+}
+
+// palindromeTailLength 地址末尾最长回文串长度
+func palindromeTailLength(s string) int {
+	for length := len(s); length > 1; length-- {
+		if isPalindrome(s[len(s)-length:]) {
+			return length
+		}
+	}
+	return 0
+}
+
+func isPalindrome(s string) bool {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		if s[i] != s[j] {
+			return false
+		}
+	}
+	return true
+}
+
+// digitTailLength 地址末尾连续数字的长度
+func digitTailLength(s string) int {
+	count := 0
+	for i := len(s) - 1; i >= 0 && s[i] >= '0' && s[i] <= '9'; i-- {
+		count++
+	}
+	return count
+}
@@ -0,0 +1,80 @@
+package matcher
+
+import (
+	"testing"
+
+	"wallet_create_address/pkg/chain"
+	"wallet_create_address/pkg/wallet"
+)
+
+func TestRarityScore(t *testing.T) {
+	tests := []struct {
+		name       string
+		thresholds RarityThresholds
+		address    string
+		score      int
+		reasons    int
+	}{
+		{"重复字符", RarityThresholds{MinRepeatRun: 5}, "0x1a2b88888c3d", 5, 1},
+		{"重复不足", RarityThresholds{MinRepeatRun: 6}, "0x1a2b88888c3d", 0, 0},
+		{"递增序列", RarityThresholds{MinSequence: 6}, "0x9f123456af", 6, 1},
+		{"递减字母", RarityThresholds{MinSequence: 4}, "0x90fedcb1", 5, 1},
+		{"跨类不算序列", RarityThresholds{MinSequence: 3}, "0x9:;a", 0, 0},
+		{"回文尾号", RarityThresholds{MinPalindromeTail: 5}, "0xffff12321", 5, 1},
+		{"数字尾号", RarityThresholds{MinDigitTail: 4}, "TXyz98765", 5, 1},
+		{"多个特征累加", RarityThresholds{MinRepeatRun: 4, MinDigitTail: 4}, "0xab0000", 8, 2},
+		{"0x 前缀不参与", RarityThresholds{MinSequence: 3}, "0x0x", 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, reasons := NewRarityScorer(chain.ETH, tt.thresholds).Score(tt.address)
+			if score != tt.score || len(reasons) != tt.reasons {
+				t.Errorf("Score(%q) = %d %v, 期望 %d 分 %d 个特征", tt.address, score, reasons, tt.score, tt.reasons)
+			}
+		})
+	}
+}
+
+func TestRarityScoreCase(t *testing.T) {
+	tests := []struct {
+		name       string
+		chain      string
+		thresholds RarityThresholds
+		address    string
+		score      int
+	}{
+		{"波场忽略大小写", chain.Tron, RarityThresholds{MinRepeatRun: 4, IgnoreCase: true}, "TXyzaAaA", 4},
+		{"波场区分大小写", chain.Tron, RarityThresholds{MinRepeatRun: 4}, "TXyzaAaA", 0},
+		// EIP-55 校验和地址（测试助记词 m/44'/60'/0'/0/16012）中的 fffFFF 按 6 个连续字符计分
+		{"以太坊校验和地址", chain.ETH, RarityThresholds{MinRepeatRun: 6}, "0x2b573dd16913822BB95cFffFFFBd6d91df3C2987", 6},
+		{"以太坊无视忽略大小写配置", chain.ETH, RarityThresholds{MinRepeatRun: 6, IgnoreCase: false}, "0x12aAaAaA", 6},
+		{"以太坊混合大小写序列", chain.ETH, RarityThresholds{MinSequence: 6}, "0x90AbCdEf", 6},
+		{"BSC 同样按小写", chain.BSC, RarityThresholds{MinRepeatRun: 6}, "0x12aAaAaA", 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, _ := NewRarityScorer(tt.chain, tt.thresholds).Score(tt.address)
+			if score != tt.score {
+				t.Errorf("Score(%q) = %d, 期望 %d", tt.address, score, tt.score)
+			}
+		})
+	}
+}
+
+func TestRarityEvaluate(t *testing.T) {
+	scorer := NewRarityScorer(chain.Tron, RarityThresholds{MinDigitTail: 3})
+	rare := wallet.MultiChainWallet{EthAddress: "0x000", TronAddress: "TAbc1234"}
+	find, ok := scorer.Evaluate(rare)
+	if !ok || find.Chain != chain.Tron || find.Address != rare.TronAddress || find.Score != 4 {
+		t.Fatalf("Evaluate = %+v %v", find, ok)
+	}
+	if _, ok := scorer.Evaluate(wallet.MultiChainWallet{TronAddress: "TAbc12x"}); ok {
+		t.Error("不满足阈值的地址不应被捕获")
+	}
+	if _, ok := scorer.Evaluate(wallet.MultiChainWallet{}); ok {
+		t.Error("空地址不应被捕获")
+	}
+	if got := scorer.FoundCount(); got != 1 {
+		t.Errorf("FoundCount = %d, 期望 1", got)
+	}
+}
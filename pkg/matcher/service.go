@@ -0,0 +1,179 @@
+package matcher
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"wallet_create_address/pkg/wallet"
+)
+
+// ServiceConfig 地址匹配服务配置
+type ServiceConfig struct {
+	Matcher     Config
+	WorkerCount int
+	MaxMatch    int // 最大匹配次数（0表示无限制）
+
+	// 稀有地址捕获：RarityChain 为空时不启用
+	RarityChain string
+	Rarity      RarityThresholds
+
+	// 统计回调间隔（<=0 时不回调）
+	StatsInterval time.Duration
+}
+
+// Callbacks 匹配过程中的回调，均在同一个协程中依次调用
+type Callbacks struct {
+	OnMatch    func(w wallet.MultiChainWallet, count int)
+	OnRareFind func(find *RareFind)
+	OnStats    func(stats Stats)
+}
+
+// MatchingResult 匹配结果
+type MatchingResult struct {
+	Wallets   []wallet.MultiChainWallet
+	RareFinds []*RareFind
+	Duration  time.Duration
+	Stats     Stats
+}
+
+// MatchingService 地址匹配服务
+type MatchingService struct {
+	config    ServiceConfig
+	callbacks Callbacks
+	generator *wallet.WalletGenerator
+	matcher   *AddressMatcher
+	scorer    *RarityScorer
+}
+
+// NewMatchingService 创建地址匹配服务
+func NewMatchingService(config ServiceConfig, callbacks Callbacks) (*MatchingService, error) {
+	matcher, err := NewAddressMatcher(config.Matcher)
+	if err != nil {
+		return nil, err
+	}
+
+	ms := &MatchingService{
+		config:    config,
+		callbacks: callbacks,
+		generator: wallet.NewWalletGenerator(),
+		matcher:   matcher,
+	}
+	if config.RarityChain != "" {
+		ms.scorer = NewRarityScorer(config.RarityChain, config.Rarity)
+	}
+
+	return ms, nil
+}
+
+// Matcher 获取地址匹配器
+func (ms *MatchingService) Matcher() *AddressMatcher {
+	return ms.matcher
+}
+
+// Run 运行地址匹配，直到达到最大尝试次数、最大匹配次数或 ctx 被取消
+func (ms *MatchingService) Run(ctx context.Context) *MatchingResult {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	workerCount := ms.config.WorkerCount
+	if workerCount < 1 {
+		workerCount = 1
+	}
+
+	start := time.Now()
+
+	// 使用协程池进行匹配
+	walletChan := make(chan wallet.MultiChainWallet, 100)
+	rareChan := make(chan *RareFind, 100)
+	var wg sync.WaitGroup
+
+	// 启动工作协程
+	for i := 0; i < workerCount; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil && !ms.matcher.ShouldStop() {
+				w, err := ms.generator.GenerateRandomWallet()
+				if err != nil {
+					continue
+				}
+
+				if ms.matcher.MatchWallet(w) {
+					select {
+					case walletChan <- w:
+					case <-ctx.Done():
+						return
+					}
+				} else if ms.scorer != nil {
+					// 未命中规则，但地址本身足够稀有
+					if find, ok := ms.scorer.Evaluate(w); ok {
+						select {
+						case rareChan <- find:
+						case <-ctx.Done():
+							return
+						}
+					}
+				}
+			}
+		}()
+	}
+
+	// 所有协程结束后关闭结果通道
+	go func() {
+		wg.Wait()
+		close(walletChan)
+		close(rareChan)
+	}()
+
+	// 定期回调统计信息
+	var tick <-chan time.Time
+	if ms.config.StatsInterval > 0 && ms.callbacks.OnStats != nil {
+		ticker := time.NewTicker(ms.config.StatsInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	// 收集匹配的钱包和稀有地址
+	result := &MatchingResult{}
+	maxMatch := ms.config.MaxMatch
+	matchCh, rareCh := walletChan, rareChan
+	for matchCh != nil || rareCh != nil {
+		select {
+		case w, ok := <-matchCh:
+			if !ok {
+				matchCh = nil
+				continue
+			}
+			// 达到最大匹配次数后，丢弃停止前已生成的匹配
+			if maxMatch > 0 && len(result.Wallets) >= maxMatch {
+				continue
+			}
+			result.Wallets = append(result.Wallets, w)
+			if ms.callbacks.OnMatch != nil {
+				ms.callbacks.OnMatch(w, len(result.Wallets))
+			}
+			// 如果找到足够的匹配，停止搜索
+			if maxMatch > 0 && len(result.Wallets) >= maxMatch {
+				cancel()
+			}
+
+		case find, ok := <-rareCh:
+			if !ok {
+				rareCh = nil
+				continue
+			}
+			result.RareFinds = append(result.RareFinds, find)
+			if ms.callbacks.OnRareFind != nil {
+				ms.callbacks.OnRareFind(find)
+			}
+
+		case <-tick:
+			ms.callbacks.OnStats(ms.matcher.GetStats())
+		}
+	}
+
+	result.Duration = time.Since(start)
+	result.Stats = ms.matcher.GetStats()
+	return result
+}
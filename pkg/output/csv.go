@@ -0,0 +1,105 @@
+package output
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"wallet_create_address/pkg/wallet"
+)
+
+// ErrUnknownColumn 不支持的 CSV 列
+var ErrUnknownColumn = errors.New("不支持的 CSV 列")
+
+// columns CSV 列名（与 MultiChainWallet 的 json 标签一致）及取值函数
+var columns = map[string]func(wallet.MultiChainWallet) string{
+	"index":           func(w wallet.MultiChainWallet) string { return strconv.Itoa(w.Index) },
+	"mnemonic":        func(w wallet.MultiChainWallet) string { return w.Mnemonic },
+	"private_key":     func(w wallet.MultiChainWallet) string { return w.PrivateKey },
+	"public_key":      func(w wallet.MultiChainWallet) string { return w.PublicKey },
+	"derive_path":     func(w wallet.MultiChainWallet) string { return w.DerivePath },
+	"eth_address":     func(w wallet.MultiChainWallet) string { return w.EthAddress },
+	"btc_address":     func(w wallet.MultiChainWallet) string { return w.BtcAddress },
+	"bsc_address":     func(w wallet.MultiChainWallet) string { return w.BscAddress },
+	"polygon_address": func(w wallet.MultiChainWallet) string { return w.PolygonAddress },
+	"tron_address":    func(w wallet.MultiChainWallet) string { return w.TronAddress },
+	// 以下列仅在启用扩展密钥时有值
+	"wif":          func(w wallet.MultiChainWallet) string { return w.WIF },
+	"account_path": func(w wallet.MultiChainWallet) string { return w.AccountPath },
+	"account_xprv": func(w wallet.MultiChainWallet) string { return w.AccountXprv },
+	"account_xpub": func(w wallet.MultiChainWallet) string { return w.AccountXpub },
+	"bip49_ypub":   func(w wallet.MultiChainWallet) string { return w.BIP49Ypub },
+	"bip84_zpub":   func(w wallet.MultiChainWallet) string { return w.BIP84Zpub },
+}
+
+// DefaultColumns 默认的 CSV 列
+var DefaultColumns = []string{
+	"index", "mnemonic", "private_key", "public_key", "derive_path",
+	"eth_address", "btc_address", "bsc_address", "polygon_address", "tron_address",
+}
+
+// ValidateColumns 检查 CSV 列名是否受支持
+func ValidateColumns(names []string) error {
+	for _, name := range names {
+		if _, ok := columns[name]; !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownColumn, name)
+		}
+	}
+	return nil
+}
+
+// CSVWriter CSV 写入器
+type CSVWriter struct {
+	w       *csv.Writer
+	closer  io.Closer
+	columns []string
+}
+
+// NewCSVWriter 创建 CSV 写入器，columns 为空时使用 DefaultColumns；header 为 true 时先写表头
+func NewCSVWriter(w io.Writer, names []string, header bool) (*CSVWriter, error) {
+	if len(names) == 0 {
+		names = DefaultColumns
+	}
+	if err := ValidateColumns(names); err != nil {
+		return nil, err
+	}
+
+	cw := &CSVWriter{w: csv.NewWriter(w), columns: names}
+	if header {
+		if err := cw.w.Write(names); err != nil {
+			return nil, fmt.Errorf("写入文件失败: %w", err)
+		}
+	}
+	return cw, nil
+}
+
+// Write 写入一个钱包
+func (cw *CSVWriter) Write(w wallet.MultiChainWallet) error {
+	record := make([]string, len(cw.columns))
+	for i, name := range cw.columns {
+		record[i] = columns[name](w)
+	}
+	if err := cw.w.Write(record); err != nil {
+		return fmt.Errorf("写入文件失败: %w", err)
+	}
+	// 每行立即落盘，中断时不丢失已生成的钱包
+	cw.w.Flush()
+	if err := cw.w.Error(); err != nil {
+		return fmt.Errorf("写入文件失败: %w", err)
+	}
+	return nil
+}
+
+// Close 关闭写入器（仅关闭由 OpenFile 打开的文件）
+func (cw *CSVWriter) Close() error {
+	cw.w.Flush()
+	err := cw.w.Error()
+	if cw.closer != nil {
+		if closeErr := cw.closer.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
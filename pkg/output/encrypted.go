@@ -0,0 +1,462 @@
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+)
+
+// 加密文件格式：
+//
+//	文件头: magic(7) | 版本(1) | KDF(1) | 参数 3×uint32 | salt(16) | nonce(24) | 校验标签(16)
+//	记录帧: 长度 uint32 | nonce(24) | 密文（XChaCha20-Poly1305）
+//	帧附加数据: 文件头前部 | 帧序号 uint64 | 结束标志(1)
+//
+// 校验标签是对空明文的加密结果，用于在追加写入和解密前验证口令。
+// 每次写入独立成帧，帧序号从 0 连续递增，Close 时写入空明文的结束帧，
+// 因此删除、重排、重复帧或在帧边界截断文件都会在解密时被发现。
+// 追加写入时验证并去掉原有的结束帧，从下一个序号继续；
+// 未正常关闭的文件缺少结束帧，追加时丢弃末尾不完整的帧后继续写入。
+const (
+	encMagic     = "WGENCv\x00"
+	encVersion   = 2
+	encSaltSize  = 16
+	encParamSize = 1 + 3*4
+	// encHeaderSize 文件头总长度
+	encHeaderSize = len(encMagic) + 1 + encParamSize + encSaltSize + chacha20poly1305.NonceSizeX + chacha20poly1305.Overhead
+	// encMaxFrame 单帧最大长度，防止损坏的长度字段导致超大内存分配
+	encMaxFrame = 16 << 20
+	// encFramePrefix 帧长度与 nonce
+	encFramePrefix = 4 + chacha20poly1305.NonceSizeX
+	// encMaxKDFMemory 密钥派生允许使用的最大内存，文件头中的参数在认证前就会被使用
+	encMaxKDFMemory = 1 << 30
+)
+
+// KDF 算法
+const (
+	KDFScrypt   = "scrypt"
+	KDFArgon2id = "argon2id"
+)
+
+var kdfIDs = map[string]byte{KDFScrypt: 1, KDFArgon2id: 2}
+
+var (
+	// ErrWrongPassphrase 口令错误
+	ErrWrongPassphrase = errors.New("口令错误或文件头已损坏")
+	// ErrNotEncrypted 文件不是加密输出格式
+	ErrNotEncrypted = errors.New("不是加密输出文件")
+	// ErrCorrupted 加密数据损坏或被篡改
+	ErrCorrupted = errors.New("加密数据已损坏")
+	// ErrTruncated 缺少结束帧：文件被截断，或写入时未正常关闭
+	ErrTruncated = fmt.Errorf("%w: 缺少结束帧，文件被截断或写入时未正常关闭", ErrCorrupted)
+)
+
+// EncryptionParams 口令派生密钥的参数
+type EncryptionParams struct {
+	KDF             string `yaml:"kdf"`               // scrypt | argon2id
+	ScryptLogN      int    `yaml:"scrypt_log_n"`      // scrypt N = 2^log_n
+	ScryptR         int    `yaml:"scrypt_r"`          // scrypt r
+	ScryptP         int    `yaml:"scrypt_p"`          // scrypt p
+	Argon2Time      int    `yaml:"argon2_time"`       // argon2id 迭代次数
+	Argon2MemoryKiB int    `yaml:"argon2_memory_kib"` // argon2id 内存（KiB）
+	Argon2Threads   int    `yaml:"argon2_threads"`    // argon2id 并行度
+}
+
+// DefaultEncryptionParams 默认加密参数
+func DefaultEncryptionParams() EncryptionParams {
+	return EncryptionParams{
+		KDF:             KDFArgon2id,
+		ScryptLogN:      17,
+		ScryptR:         8,
+		ScryptP:         1,
+		Argon2Time:      3,
+		Argon2MemoryKiB: 64 * 1024,
+		Argon2Threads:   4,
+	}
+}
+
+// Validate 检查加密参数，内存上限为 1GiB，scrypt 的 p 与 argon2id 的迭代次数不超过 16
+func (p EncryptionParams) Validate() error {
+	switch p.KDF {
+	case KDFScrypt:
+		// scrypt 使用 128·r·N 字节内存
+		if p.ScryptLogN < 10 || p.ScryptLogN > 20 || p.ScryptR < 1 || p.ScryptP < 1 || p.ScryptP > 16 ||
+			p.ScryptR > encMaxKDFMemory/(128<<p.ScryptLogN) {
+			return fmt.Errorf("scrypt 参数无效: log_n=%d r=%d p=%d", p.ScryptLogN, p.ScryptR, p.ScryptP)
+		}
+	case KDFArgon2id:
+		if p.Argon2Time < 1 || p.Argon2Time > 16 || p.Argon2MemoryKiB < 8*1024 || p.Argon2MemoryKiB > encMaxKDFMemory/1024 ||
+			p.Argon2Threads < 1 || p.Argon2Threads > 255 {
+			return fmt.Errorf("argon2id 参数无效: time=%d memory=%dKiB threads=%d",
+				p.Argon2Time, p.Argon2MemoryKiB, p.Argon2Threads)
+		}
+	default:
+		return fmt.Errorf("不支持的 KDF: %s", p.KDF)
+	}
+	return nil
+}
+
+// values 按算法取出写入文件头的三个参数
+func (p EncryptionParams) values() [3]uint32 {
+	if p.KDF == KDFScrypt {
+		return [3]uint32{uint32(p.ScryptLogN), uint32(p.ScryptR), uint32(p.ScryptP)}
+	}
+	return [3]uint32{uint32(p.Argon2Time), uint32(p.Argon2MemoryKiB), uint32(p.Argon2Threads)}
+}
+
+// deriveKey 从口令派生 32 字节密钥
+func (p EncryptionParams) deriveKey(passphrase string, salt []byte) ([]byte, error) {
+	if p.KDF == KDFScrypt {
+		return scrypt.Key([]byte(passphrase), salt, 1<<p.ScryptLogN, p.ScryptR, p.ScryptP, chacha20poly1305.KeySize)
+	}
+	return argon2.IDKey([]byte(passphrase), salt, uint32(p.Argon2Time), uint32(p.Argon2MemoryKiB),
+		uint8(p.Argon2Threads), chacha20poly1305.KeySize), nil
+}
+
+// encHeader 解析后的文件头
+type encHeader struct {
+	raw    []byte
+	params EncryptionParams
+	salt   []byte
+}
+
+// aad 文件头中参与认证的部分（不含校验 nonce 和标签）
+func (h *encHeader) aad() []byte {
+	return h.raw[:len(encMagic)+1+encParamSize+encSaltSize]
+}
+
+// frameAAD 第 seq 帧的附加数据，final 表示结束帧
+func (h *encHeader) frameAAD(seq uint64, final bool) []byte {
+	aad := binary.BigEndian.AppendUint64(bytes.Clone(h.aad()), seq)
+	if final {
+		return append(aad, 1)
+	}
+	return append(aad, 0)
+}
+
+// newHeader 生成新文件头并派生密钥
+func newHeader(passphrase string, params EncryptionParams) (*encHeader, []byte, error) {
+	if err := params.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(encMagic)
+	buf.WriteByte(encVersion)
+	buf.WriteByte(kdfIDs[params.KDF])
+	for _, v := range params.values() {
+		binary.Write(&buf, binary.BigEndian, v)
+	}
+	salt := make([]byte, encSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, nil, fmt.Errorf("生成随机盐失败: %w", err)
+	}
+	buf.Write(salt)
+
+	key, err := params.deriveKey(passphrase, salt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("派生密钥失败: %w", err)
+	}
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	h := &encHeader{params: params, salt: salt}
+	nonce := make([]byte, chacha20poly1305.NonceSizeX)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, nil, fmt.Errorf("生成随机数失败: %w", err)
+	}
+	aad := buf.Bytes()
+	buf.Write(nonce)
+	buf.Write(aead.Seal(nil, nonce, nil, aad))
+	h.raw = buf.Bytes()
+	return h, key, nil
+}
+
+// readHeader 读取文件头，派生密钥并验证口令
+func readHeader(r io.Reader, passphrase string) (*encHeader, []byte, error) {
+	raw := make([]byte, encHeaderSize)
+	if _, err := io.ReadFull(r, raw); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, nil, ErrNotEncrypted
+		}
+		return nil, nil, fmt.Errorf("读取文件头失败: %w", err)
+	}
+	if string(raw[:len(encMagic)]) != encMagic {
+		return nil, nil, ErrNotEncrypted
+	}
+	pos := len(encMagic)
+	if raw[pos] != encVersion {
+		return nil, nil, fmt.Errorf("不支持的加密文件版本: %d", raw[pos])
+	}
+	pos++
+
+	var params EncryptionParams
+	for name, id := range kdfIDs {
+		if raw[pos] == id {
+			params.KDF = name
+		}
+	}
+	pos++
+	var v [3]int
+	for i := range v {
+		v[i] = int(binary.BigEndian.Uint32(raw[pos:]))
+		pos += 4
+	}
+	if params.KDF == KDFScrypt {
+		params.ScryptLogN, params.ScryptR, params.ScryptP = v[0], v[1], v[2]
+	} else {
+		params.Argon2Time, params.Argon2MemoryKiB, params.Argon2Threads = v[0], v[1], v[2]
+	}
+	if err := params.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrCorrupted, err)
+	}
+
+	h := &encHeader{raw: raw, params: params, salt: raw[pos : pos+encSaltSize]}
+	pos += encSaltSize
+
+	key, err := params.deriveKey(passphrase, h.salt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("派生密钥失败: %w", err)
+	}
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	nonce := raw[pos : pos+chacha20poly1305.NonceSizeX]
+	if _, err := aead.Open(nil, nonce, raw[pos+chacha20poly1305.NonceSizeX:], h.aad()); err != nil {
+		return nil, nil, ErrWrongPassphrase
+	}
+	return h, key, nil
+}
+
+// EncryptedWriter 把每次写入加密为独立的帧，Close 时写入结束帧
+type EncryptedWriter struct {
+	w      io.Writer
+	closer io.Closer
+	header *encHeader
+	aead   cipher.AEAD
+	seq    uint64
+	closed bool
+}
+
+func newEncryptedWriter(w io.Writer, header *encHeader, key []byte, seq uint64) (*EncryptedWriter, error) {
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+	return &EncryptedWriter{w: w, header: header, aead: aead, seq: seq}, nil
+}
+
+// NewEncryptedWriter 写入新的文件头并创建加密写入器
+func NewEncryptedWriter(w io.Writer, passphrase string, params EncryptionParams) (*EncryptedWriter, error) {
+	header, key, err := newHeader(passphrase, params)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(header.raw); err != nil {
+		return nil, fmt.Errorf("写入文件失败: %w", err)
+	}
+	return newEncryptedWriter(w, header, key, 0)
+}
+
+// resumeEncryptedWriter 在已有加密文件末尾继续写入
+// 文件须以追加模式打开且读取位置在开头；原有的结束帧会被截掉，末尾不完整的帧被丢弃
+func resumeEncryptedWriter(file *os.File, passphrase string) (*EncryptedWriter, error) {
+	header, key, err := readHeader(file, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	ew, err := newEncryptedWriter(file, header, key, 0)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("读取文件信息失败: %w", err)
+	}
+
+	// 按长度字段遍历帧，只计数不解密
+	offset, last := int64(encHeaderSize), int64(-1)
+	var prefix [encFramePrefix]byte
+	for offset < info.Size() {
+		if _, err := file.ReadAt(prefix[:], offset); err != nil {
+			break // 不完整的帧头
+		}
+		size := int64(binary.BigEndian.Uint32(prefix[:4]))
+		if size < chacha20poly1305.Overhead || size > encMaxFrame {
+			return nil, fmt.Errorf("%w: 第 %d 帧长度 %d 无效", ErrCorrupted, ew.seq, size)
+		}
+		if offset+encFramePrefix+size > info.Size() {
+			break // 不完整的帧
+		}
+		last = offset
+		offset += encFramePrefix + size
+		ew.seq++
+	}
+
+	// 最后一帧是结束帧时从它的位置继续写入
+	if last >= 0 {
+		frame := make([]byte, offset-last)
+		if _, err := file.ReadAt(frame, last); err != nil {
+			return nil, fmt.Errorf("读取文件失败: %w", err)
+		}
+		nonce, ciphertext := frame[4:encFramePrefix], frame[encFramePrefix:]
+		if _, err := ew.aead.Open(nil, nonce, ciphertext, header.frameAAD(ew.seq-1, true)); err == nil {
+			offset = last
+			ew.seq--
+		} else if _, err := ew.aead.Open(nil, nonce, ciphertext, header.frameAAD(ew.seq-1, false)); err != nil {
+			return nil, fmt.Errorf("%w: 第 %d 帧认证失败", ErrCorrupted, ew.seq-1)
+		}
+	}
+	if offset < info.Size() {
+		if err := file.Truncate(offset); err != nil {
+			return nil, fmt.Errorf("截断文件失败: %w", err)
+		}
+	}
+	return ew, nil
+}
+
+// Write 加密 p 并写入一个帧
+func (ew *EncryptedWriter) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if ew.closed {
+		return 0, errors.New("加密写入器已关闭")
+	}
+	if len(p) > encMaxFrame-chacha20poly1305.Overhead {
+		return 0, fmt.Errorf("单次写入过大: %d 字节", len(p))
+	}
+	if err := ew.writeFrame(p, false); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// writeFrame 加密并写入一帧，序号随之递增
+func (ew *EncryptedWriter) writeFrame(p []byte, final bool) error {
+	frame := make([]byte, encFramePrefix, encFramePrefix+len(p)+chacha20poly1305.Overhead)
+	nonce := frame[4:]
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("生成随机数失败: %w", err)
+	}
+	frame = ew.aead.Seal(frame, nonce, p, ew.header.frameAAD(ew.seq, final))
+	binary.BigEndian.PutUint32(frame, uint32(len(frame)-encFramePrefix))
+
+	// 整帧一次写入，追加模式下不会与其他帧交错
+	if _, err := ew.w.Write(frame); err != nil {
+		return err
+	}
+	ew.seq++
+	return nil
+}
+
+// Close 写入结束帧并关闭底层文件（仅关闭由 OpenFile 打开的文件）
+func (ew *EncryptedWriter) Close() error {
+	if ew.closed {
+		return nil
+	}
+	ew.closed = true
+	err := ew.writeFrame(nil, true)
+	if ew.closer != nil {
+		if closeErr := ew.closer.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// DecryptReader 逐帧解密的读取器，读到结束帧后返回 io.EOF，缺少结束帧时返回 ErrTruncated
+type DecryptReader struct {
+	r      *bufio.Reader
+	header *encHeader
+	aead   cipher.AEAD
+	seq    uint64
+	done   bool
+	buf    []byte
+}
+
+// NewDecryptReader 读取文件头、验证口令，返回解密后的明文流
+func NewDecryptReader(r io.Reader, passphrase string) (*DecryptReader, error) {
+	br := bufio.NewReader(r)
+	header, key, err := readHeader(br, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+	return &DecryptReader{r: br, header: header, aead: aead}, nil
+}
+
+// Read 读取明文
+func (dr *DecryptReader) Read(p []byte) (int, error) {
+	for len(dr.buf) == 0 {
+		if err := dr.next(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, dr.buf)
+	dr.buf = dr.buf[n:]
+	return n, nil
+}
+
+// next 解密下一帧
+func (dr *DecryptReader) next() error {
+	if dr.done {
+		return io.EOF
+	}
+	var prefix [encFramePrefix]byte
+	if _, err := io.ReadFull(dr.r, prefix[:]); err != nil {
+		if errors.Is(err, io.EOF) {
+			return ErrTruncated
+		}
+		return fmt.Errorf("%w: 帧不完整", ErrCorrupted)
+	}
+	size := binary.BigEndian.Uint32(prefix[:4])
+	if size < chacha20poly1305.Overhead || size > encMaxFrame {
+		return fmt.Errorf("%w: 帧长度 %d 无效", ErrCorrupted, size)
+	}
+
+	ciphertext := make([]byte, size)
+	if _, err := io.ReadFull(dr.r, ciphertext); err != nil {
+		return fmt.Errorf("%w: 帧不完整", ErrCorrupted)
+	}
+	nonce := prefix[4:]
+	plaintext, err := dr.aead.Open(ciphertext[:0], nonce, ciphertext, dr.header.frameAAD(dr.seq, false))
+	if err != nil && size == chacha20poly1305.Overhead {
+		// 只有空明文的帧可能是结束帧
+		if _, err = dr.aead.Open(nil, nonce, ciphertext, dr.header.frameAAD(dr.seq, true)); err == nil {
+			if _, err := dr.r.Peek(1); !errors.Is(err, io.EOF) {
+				return fmt.Errorf("%w: 结束帧之后还有数据", ErrCorrupted)
+			}
+			dr.done = true
+			return io.EOF
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("%w: 第 %d 帧认证失败", ErrCorrupted, dr.seq)
+	}
+	dr.seq++
+	dr.buf = plaintext
+	return nil
+}
+
+// IsEncrypted 检查数据开头是否为加密输出文件头（不消耗数据）
+func IsEncrypted(r *bufio.Reader) bool {
+	magic, err := r.Peek(len(encMagic))
+	return err == nil && string(magic) == encMagic
+}
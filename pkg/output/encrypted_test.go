@@ -0,0 +1,216 @@
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// testParams 测试用的低成本参数
+var testParams = EncryptionParams{KDF: KDFScrypt, ScryptLogN: 10, ScryptR: 8, ScryptP: 1}
+
+const testPassphrase = "correct horse battery staple"
+
+// encryptFrames 把每个字符串写为一帧，close 为 false 时模拟未正常关闭
+func encryptFrames(t *testing.T, close bool, frames ...string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	ew, err := NewEncryptedWriter(&buf, testPassphrase, testParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range frames {
+		if _, err := ew.Write([]byte(f)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if close {
+		if err := ew.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return buf.Bytes()
+}
+
+// decrypt 解密全部数据，返回已解密的明文和遇到的错误
+func decrypt(data []byte, passphrase string) (string, error) {
+	r, err := NewDecryptReader(bytes.NewReader(data), passphrase)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := io.ReadAll(r)
+	return string(plaintext), err
+}
+
+// bufioReader 供 IsEncrypted 使用的缓冲读取器
+func bufioReader(data []byte) *bufio.Reader {
+	return bufio.NewReader(bytes.NewReader(data))
+}
+
+// splitFrames 拆分出文件头和各帧
+func splitFrames(t *testing.T, data []byte) ([]byte, [][]byte) {
+	t.Helper()
+	header, rest := data[:encHeaderSize], data[encHeaderSize:]
+	var frames [][]byte
+	for len(rest) > 0 {
+		size := encFramePrefix + int(binary.BigEndian.Uint32(rest))
+		frames = append(frames, rest[:size])
+		rest = rest[size:]
+	}
+	return header, frames
+}
+
+func TestEncryptedRoundTrip(t *testing.T) {
+	for _, params := range []EncryptionParams{testParams, {KDF: KDFArgon2id, Argon2Time: 1, Argon2MemoryKiB: 8 * 1024, Argon2Threads: 1}} {
+		var buf bytes.Buffer
+		ew, err := NewEncryptedWriter(&buf, testPassphrase, params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ew.Write([]byte("line 1\n"))
+		ew.Write([]byte("line 2\n"))
+		if err := ew.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if !IsEncrypted(bufioReader(buf.Bytes())) {
+			t.Error("IsEncrypted = false")
+		}
+		if got, err := decrypt(buf.Bytes(), testPassphrase); err != nil || got != "line 1\nline 2\n" {
+			t.Errorf("%s: 解密 = %q, %v", params.KDF, got, err)
+		}
+		if _, err := decrypt(buf.Bytes(), "wrong"); !errors.Is(err, ErrWrongPassphrase) {
+			t.Errorf("%s: 错误口令: %v", params.KDF, err)
+		}
+	}
+	if got, err := decrypt(encryptFrames(t, true), testPassphrase); err != nil || got != "" {
+		t.Errorf("空文件解密 = %q, %v", got, err)
+	}
+}
+
+func TestEncryptedTampering(t *testing.T) {
+	data := encryptFrames(t, true, "a", "b", "c")
+	header, frames := splitFrames(t, data)
+	if len(frames) != 4 {
+		t.Fatalf("帧数 = %d, 期望 3 个数据帧加结束帧", len(frames))
+	}
+	join := func(frames ...[]byte) []byte {
+		return slices.Concat(append([][]byte{header}, frames...)...)
+	}
+
+	tests := map[string][]byte{
+		"删除帧":     join(frames[0], frames[2], frames[3]),
+		"重排帧":     join(frames[1], frames[0], frames[2], frames[3]),
+		"重复帧":     join(frames[0], frames[1], frames[1], frames[2], frames[3]),
+		"截掉结束帧":   join(frames[0], frames[1], frames[2]),
+		"在帧边界截断":  join(frames[0]),
+		"结束帧后有数据": join(frames[0], frames[1], frames[2], frames[3], frames[0]),
+		"结束帧提前":   join(frames[0], frames[3]),
+		"帧内截断":    data[:len(data)-5],
+	}
+	for name, tampered := range tests {
+		if _, err := decrypt(tampered, testPassphrase); !errors.Is(err, ErrCorrupted) {
+			t.Errorf("%s: 错误 = %v, 期望 ErrCorrupted", name, err)
+		}
+	}
+
+	// 来自另一个文件的帧（不同的盐与密钥）
+	_, other := splitFrames(t, encryptFrames(t, true, "a", "b", "c"))
+	if _, err := decrypt(join(frames[0], other[1], frames[2], frames[3]), testPassphrase); !errors.Is(err, ErrCorrupted) {
+		t.Errorf("替换帧: %v", err)
+	}
+	if _, err := decrypt(encryptFrames(t, false, "a"), testPassphrase); !errors.Is(err, ErrTruncated) {
+		t.Errorf("未关闭的文件: %v", err)
+	}
+}
+
+func TestEncryptedHeaderLimits(t *testing.T) {
+	data := encryptFrames(t, true, "a")
+	paramsAt := len(encMagic) + 2
+
+	// 伪造的超大 KDF 参数应在派生密钥之前被拒绝
+	huge := map[string]func([]byte){
+		"scrypt log_n": func(b []byte) { binary.BigEndian.PutUint32(b[paramsAt:], 30) },
+		"scrypt r":     func(b []byte) { binary.BigEndian.PutUint32(b[paramsAt+4:], 1<<20) },
+		"argon2 内存": func(b []byte) {
+			b[paramsAt-1] = kdfIDs[KDFArgon2id]
+			binary.BigEndian.PutUint32(b[paramsAt:], 1)
+			binary.BigEndian.PutUint32(b[paramsAt+4:], 1<<30)
+			binary.BigEndian.PutUint32(b[paramsAt+8:], 1)
+		},
+	}
+	for name, mutate := range huge {
+		tampered := bytes.Clone(data)
+		mutate(tampered)
+		if _, err := decrypt(tampered, testPassphrase); !errors.Is(err, ErrCorrupted) {
+			t.Errorf("%s: 错误 = %v, 期望 ErrCorrupted", name, err)
+		}
+	}
+
+	for _, p := range []EncryptionParams{
+		{KDF: KDFScrypt, ScryptLogN: 21, ScryptR: 8, ScryptP: 1},
+		{KDF: KDFScrypt, ScryptLogN: 20, ScryptR: 9, ScryptP: 1},
+		{KDF: KDFArgon2id, Argon2Time: 1, Argon2MemoryKiB: 1<<20 + 1, Argon2Threads: 1},
+		{KDF: KDFArgon2id, Argon2Time: 17, Argon2MemoryKiB: 8 * 1024, Argon2Threads: 1},
+	} {
+		if err := p.Validate(); err == nil {
+			t.Errorf("%+v 应超出上限", p)
+		}
+	}
+	if err := DefaultEncryptionParams().Validate(); err != nil {
+		t.Errorf("默认参数: %v", err)
+	}
+}
+
+func TestEncryptedAppend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wallets.txt")
+	opts := Options{Format: FormatJSONL, Passphrase: testPassphrase, Encryption: testParams}
+	for range 3 {
+		w, err := OpenFile(path, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		writeAll(t, w)
+	}
+	data, _ := os.ReadFile(path)
+	got, err := decrypt(data, testPassphrase)
+	if err != nil || bytes.Count([]byte(got), []byte("\n")) != 6 {
+		t.Fatalf("追加 3 次后解密 = %q, %v", got, err)
+	}
+	if _, frames := splitFrames(t, data); len(frames) != 7 {
+		t.Errorf("帧数 = %d, 期望 6 个数据帧加 1 个结束帧", len(frames))
+	}
+
+	if _, err := OpenFile(path, Options{Format: FormatJSONL, Passphrase: "wrong"}); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("错误口令追加: %v", err)
+	}
+	if _, err := OpenFile(path, Options{Format: FormatJSONL}); err == nil {
+		t.Error("不应把明文追加到加密文件")
+	}
+}
+
+func TestEncryptedAppendAfterCrash(t *testing.T) {
+	// 未正常关闭且末尾有不完整帧的文件：追加时丢弃不完整的帧并接续序号
+	path := filepath.Join(t.TempDir(), "wallets.txt")
+	data := encryptFrames(t, false, "a\n", "b\n")
+	_, frames := splitFrames(t, encryptFrames(t, false, "c\n"))
+	if err := os.WriteFile(path, append(data, frames[0][:10]...), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := OpenTextFile(path, Options{Chain: "eth", Passphrase: testPassphrase})
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeAll(t, w)
+	data, _ = os.ReadFile(path)
+	got, err := decrypt(data, testPassphrase)
+	if err != nil || got != "a\nb\n钱包地址: 0xA>>>私钥: aa\n钱包地址: 0xB>>>私钥: \n" {
+		t.Errorf("解密 = %q, %v", got, err)
+	}
+}
@@ -0,0 +1,88 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"wallet_create_address/pkg/wallet"
+)
+
+// JSONLWriter JSON Lines 写入器，每行一个钱包对象，可追加
+type JSONLWriter struct {
+	encoder *json.Encoder
+	closer  io.Closer
+}
+
+// NewJSONLWriter 创建 JSON Lines 写入器
+func NewJSONLWriter(w io.Writer) *JSONLWriter {
+	return &JSONLWriter{encoder: json.NewEncoder(w)}
+}
+
+// Write 写入一个钱包
+func (jw *JSONLWriter) Write(w wallet.MultiChainWallet) error {
+	if err := jw.encoder.Encode(w); err != nil {
+		return fmt.Errorf("写入文件失败: %w", err)
+	}
+	return nil
+}
+
+// Close 关闭写入器（仅关闭由 OpenFile 打开的文件）
+func (jw *JSONLWriter) Close() error {
+	if jw.closer == nil {
+		return nil
+	}
+	return jw.closer.Close()
+}
+
+// JSONWriter JSON 数组写入器，边生成边写入，Close 时补全数组结尾
+type JSONWriter struct {
+	w      io.Writer
+	closer io.Closer
+	count  int
+}
+
+// NewJSONWriter 创建 JSON 数组写入器
+func NewJSONWriter(w io.Writer) *JSONWriter {
+	return &JSONWriter{w: w}
+}
+
+// Write 写入一个钱包
+func (jw *JSONWriter) Write(w wallet.MultiChainWallet) error {
+	data, err := json.MarshalIndent(w, "  ", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化钱包失败: %w", err)
+	}
+
+	sep := ",\n  "
+	if jw.count == 0 {
+		sep = "[\n  "
+	}
+	if _, err := io.WriteString(jw.w, sep); err != nil {
+		return fmt.Errorf("写入文件失败: %w", err)
+	}
+	if _, err := jw.w.Write(data); err != nil {
+		return fmt.Errorf("写入文件失败: %w", err)
+	}
+	jw.count++
+	return nil
+}
+
+// Close 写入数组结尾并关闭写入器（仅关闭由 OpenFile 打开的文件）
+func (jw *JSONWriter) Close() error {
+	end := "\n]\n"
+	if jw.count == 0 {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(jw.w, end)
+	if err != nil {
+		err = fmt.Errorf("写入文件失败: %w", err)
+	}
+
+	if jw.closer != nil {
+		if closeErr := jw.closer.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
@@ -0,0 +1,215 @@
+package output
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/crypto/pbkdf2"
+
+	"wallet_create_address/pkg/wallet"
+)
+
+// keystore 的 KDF 算法
+const (
+	KeystoreScrypt = "scrypt"
+	KeystorePBKDF2 = "pbkdf2"
+)
+
+// ErrPassphraseRequired 写入 keystore 或加密文件时未提供口令
+var ErrPassphraseRequired = errors.New("需要提供口令")
+
+// KeystoreParams Web3 Secret Storage (V3) 的密钥派生参数
+type KeystoreParams struct {
+	KDF              string `yaml:"kdf"`               // scrypt | pbkdf2
+	ScryptN          int    `yaml:"scrypt_n"`          // scrypt N（2 的幂）
+	ScryptP          int    `yaml:"scrypt_p"`          // scrypt p
+	PBKDF2Iterations int    `yaml:"pbkdf2_iterations"` // pbkdf2 迭代次数
+}
+
+// DefaultKeystoreParams 默认参数，与 geth 的标准参数一致
+func DefaultKeystoreParams() KeystoreParams {
+	return KeystoreParams{
+		KDF:              KeystoreScrypt,
+		ScryptN:          keystore.StandardScryptN,
+		ScryptP:          keystore.StandardScryptP,
+		PBKDF2Iterations: 262144,
+	}
+}
+
+// Validate 检查 keystore 参数
+func (p KeystoreParams) Validate() error {
+	switch p.KDF {
+	case KeystoreScrypt:
+		if p.ScryptN < 2 || p.ScryptN&(p.ScryptN-1) != 0 || p.ScryptP < 1 {
+			return fmt.Errorf("keystore scrypt 参数无效: n=%d（须为2的幂） p=%d", p.ScryptN, p.ScryptP)
+		}
+	case KeystorePBKDF2:
+		if p.PBKDF2Iterations < 1 {
+			return fmt.Errorf("keystore pbkdf2 迭代次数无效: %d", p.PBKDF2Iterations)
+		}
+	default:
+		return fmt.Errorf("不支持的 keystore KDF: %s", p.KDF)
+	}
+	return nil
+}
+
+// keystoreJSON V3 keystore 文件结构
+type keystoreJSON struct {
+	Address string              `json:"address"`
+	Crypto  keystore.CryptoJSON `json:"crypto"`
+	ID      string              `json:"id"`
+	Version int                 `json:"version"`
+}
+
+// EncryptKeystore 把十六进制私钥加密为 V3 keystore JSON，返回对应的以太坊地址
+func EncryptKeystore(privateKeyHex, passphrase string, params KeystoreParams) (common.Address, []byte, error) {
+	if passphrase == "" {
+		return common.Address{}, nil, ErrPassphraseRequired
+	}
+	if err := params.Validate(); err != nil {
+		return common.Address{}, nil, err
+	}
+	privateKey, err := crypto.HexToECDSA(privateKeyHex)
+	if err != nil {
+		return common.Address{}, nil, fmt.Errorf("私钥无效: %w", err)
+	}
+	address := crypto.PubkeyToAddress(privateKey.PublicKey)
+	keyBytes := crypto.FromECDSA(privateKey)
+
+	var cryptoJSON keystore.CryptoJSON
+	if params.KDF == KeystoreScrypt {
+		cryptoJSON, err = keystore.EncryptDataV3(keyBytes, []byte(passphrase), params.ScryptN, params.ScryptP)
+	} else {
+		cryptoJSON, err = encryptPBKDF2(keyBytes, []byte(passphrase), params.PBKDF2Iterations)
+	}
+	if err != nil {
+		return common.Address{}, nil, fmt.Errorf("加密私钥失败: %w", err)
+	}
+
+	id, err := newUUID()
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	data, err := json.Marshal(keystoreJSON{
+		Address: hex.EncodeToString(address[:]),
+		Crypto:  cryptoJSON,
+		ID:      id,
+		Version: 3,
+	})
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	return address, data, nil
+}
+
+// encryptPBKDF2 按 Web3 Secret Storage 规范使用 pbkdf2-sha256 加密（geth 只提供 scrypt 的加密实现）
+func encryptPBKDF2(data, auth []byte, iterations int) (keystore.CryptoJSON, error) {
+	salt := make([]byte, 32)
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(salt); err != nil {
+		return keystore.CryptoJSON{}, err
+	}
+	if _, err := rand.Read(iv); err != nil {
+		return keystore.CryptoJSON{}, err
+	}
+
+	derivedKey := pbkdf2.Key(auth, salt, iterations, 32, sha256.New)
+	block, err := aes.NewCipher(derivedKey[:16])
+	if err != nil {
+		return keystore.CryptoJSON{}, err
+	}
+	cipherText := make([]byte, len(data))
+	cipher.NewCTR(block, iv).XORKeyStream(cipherText, data)
+	mac := crypto.Keccak256(derivedKey[16:32], cipherText)
+
+	cj := keystore.CryptoJSON{
+		Cipher:     "aes-128-ctr",
+		CipherText: hex.EncodeToString(cipherText),
+		KDF:        "pbkdf2",
+		KDFParams: map[string]interface{}{
+			"c":     iterations,
+			"dklen": 32,
+			"prf":   "hmac-sha256",
+			"salt":  hex.EncodeToString(salt),
+		},
+		MAC: hex.EncodeToString(mac),
+	}
+	cj.CipherParams.IV = hex.EncodeToString(iv)
+	return cj, nil
+}
+
+// newUUID 生成随机 UUID (v4)
+func newUUID() (string, error) {
+	var u [16]byte
+	if _, err := rand.Read(u[:]); err != nil {
+		return "", fmt.Errorf("生成随机数失败: %w", err)
+	}
+	u[6] = u[6]&0x0f | 0x40
+	u[8] = u[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:]), nil
+}
+
+// KeystoreFileName 按 geth 的规则生成文件名：UTC--<时间>--<地址>
+func KeystoreFileName(t time.Time, address common.Address) string {
+	t = t.UTC()
+	ts := fmt.Sprintf("%04d-%02d-%02dT%02d-%02d-%02d.%09dZ",
+		t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond())
+	return "UTC--" + ts + "--" + hex.EncodeToString(address[:])
+}
+
+// KeystoreWriter 把每个钱包的私钥写为目录中的一个 V3 keystore 文件
+type KeystoreWriter struct {
+	dir        string
+	passphrase string
+	params     KeystoreParams
+}
+
+// NewKeystoreWriter 创建 keystore 写入器，目录不存在时创建
+func NewKeystoreWriter(dir, passphrase string, params KeystoreParams) (*KeystoreWriter, error) {
+	if passphrase == "" {
+		return nil, ErrPassphraseRequired
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("创建目录失败: %w", err)
+	}
+	return &KeystoreWriter{dir: dir, passphrase: passphrase, params: params}, nil
+}
+
+// Write 写入一个钱包的 keystore 文件
+func (kw *KeystoreWriter) Write(w wallet.MultiChainWallet) error {
+	address, data, err := EncryptKeystore(w.PrivateKey, kw.passphrase, kw.params)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(kw.dir, KeystoreFileName(time.Now(), address))
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("创建 keystore 文件失败: %w", err)
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return fmt.Errorf("写入文件失败: %w", err)
+	}
+	return file.Close()
+}
+
+// Close 无需释放资源
+func (kw *KeystoreWriter) Close() error {
+	return nil
+}
@@ -0,0 +1,93 @@
+package output
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"wallet_create_address/pkg/wallet"
+)
+
+const testPrivateKey = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+
+func TestEncryptKeystore(t *testing.T) {
+	for _, params := range []KeystoreParams{
+		{KDF: KeystoreScrypt, ScryptN: 1024, ScryptP: 1},
+		{KDF: KeystorePBKDF2, PBKDF2Iterations: 1000},
+	} {
+		address, data, err := EncryptKeystore(testPrivateKey, "pass", params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if address.Hex() != "0x2c7536E3605D9C16a7a3D7b1898e529396a65c23" {
+			t.Errorf("%s: 地址 = %s", params.KDF, address.Hex())
+		}
+
+		// 用 geth 解密验证文件可被其他钱包导入
+		key, err := keystore.DecryptKey(data, "pass")
+		if err != nil {
+			t.Fatalf("%s: 解密失败: %v", params.KDF, err)
+		}
+		if key.Address != address || common.Bytes2Hex(crypto.FromECDSA(key.PrivateKey)) != testPrivateKey {
+			t.Errorf("%s: 解密出的私钥不一致", params.KDF)
+		}
+		if _, err := keystore.DecryptKey(data, "wrong"); err == nil {
+			t.Errorf("%s: 错误口令应解密失败", params.KDF)
+		}
+
+		var raw map[string]any
+		json.Unmarshal(data, &raw)
+		if raw["version"] != 3.0 || raw["address"] != "2c7536e3605d9c16a7a3d7b1898e529396a65c23" {
+			t.Errorf("%s: keystore = %s", params.KDF, data)
+		}
+	}
+
+	if _, _, err := EncryptKeystore(testPrivateKey, "", DefaultKeystoreParams()); !errors.Is(err, ErrPassphraseRequired) {
+		t.Errorf("空口令: %v", err)
+	}
+	if _, _, err := EncryptKeystore(testPrivateKey, "pass", KeystoreParams{KDF: KeystoreScrypt, ScryptN: 1000, ScryptP: 1}); err == nil {
+		t.Error("N 不是 2 的幂应返回错误")
+	}
+}
+
+func TestKeystoreFileName(t *testing.T) {
+	at := time.Date(2024, 3, 5, 7, 8, 9, 120, time.FixedZone("CST", 8*3600))
+	address := common.HexToAddress("0x2c7536E3605D9C16a7a3D7b1898e529396a65c23")
+	want := "UTC--2024-03-04T23-08-09.000000120Z--2c7536e3605d9c16a7a3d7b1898e529396a65c23"
+	if got := KeystoreFileName(at, address); got != want {
+		t.Errorf("KeystoreFileName = %s", got)
+	}
+}
+
+func TestKeystoreWriter(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "keystore")
+	w, err := OpenFile(dir, Options{Format: FormatKeystore, Passphrase: "pass",
+		Keystore: KeystoreParams{KDF: KeystoreScrypt, ScryptN: 1024, ScryptP: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write(wallet.MultiChainWallet{PrivateKey: testPrivateKey}); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Fatalf("目录中有 %d 个文件", len(entries))
+	}
+	info, _ := entries[0].Info()
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("keystore 文件权限 = %v", info.Mode().Perm())
+	}
+
+	if _, err := OpenFile(dir, Options{Format: FormatKeystore}); !errors.Is(err, ErrPassphraseRequired) {
+		t.Errorf("未提供口令: %v", err)
+	}
+}
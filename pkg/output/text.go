@@ -0,0 +1,85 @@
+// Package output 提供钱包结果的输出写入
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"wallet_create_address/pkg/chain"
+	"wallet_create_address/pkg/matcher"
+	"wallet_create_address/pkg/wallet"
+)
+
+// TextWriter 文本格式写入器，每个钱包一行：
+// "钱包地址: <地址>>>>助记词: <助记词>" 或 "钱包地址: <地址>>>>私钥: <私钥>"，
+// 观察钱包没有私钥，只写 "钱包地址: <地址>"
+type TextWriter struct {
+	w           io.Writer
+	closer      io.Closer
+	chain       string
+	useMnemonic bool
+}
+
+// NewTextWriter 创建文本写入器
+// chain 为写入的地址所属链（eth/btc/tron/bsc/polygon/all），useMnemonic 为 true 时写入助记词，否则写入私钥
+func NewTextWriter(w io.Writer, chain string, useMnemonic bool) *TextWriter {
+	return &TextWriter{
+		w:           w,
+		chain:       chain,
+		useMnemonic: useMnemonic,
+	}
+}
+
+// Write 写入一个钱包
+func (tw *TextWriter) Write(w wallet.MultiChainWallet) error {
+	// bsc/polygon 与 eth 地址相同，各链地址都从钱包字段读取
+	var address string
+	if tw.chain == chain.All {
+		address = w.EthAddress + " " + w.BtcAddress + " " + w.TronAddress
+	} else {
+		var err error
+		if address, err = w.Address(tw.chain); err != nil {
+			return fmt.Errorf("未知链类型: %s", tw.chain)
+		}
+	}
+
+	var err error
+	if w.Mnemonic == "" && w.PrivateKey == "" {
+		// 观察钱包只写入地址
+		_, err = fmt.Fprintf(tw.w, "钱包地址: %s\n", address)
+	} else if tw.useMnemonic {
+		// 如果是助记词模式，写入地址和助记词
+		_, err = fmt.Fprintf(tw.w, "钱包地址: %s>>>助记词: %s\n", address, w.Mnemonic)
+	} else {
+		// 如果是随机钱包模式，只写入地址和私钥
+		_, err = fmt.Fprintf(tw.w, "钱包地址: %s>>>私钥: %s\n", address, w.PrivateKey)
+	}
+	if err != nil {
+		return fmt.Errorf("写入文件失败: %w", err)
+	}
+	return nil
+}
+
+// WriteRareFind 写入一个稀有地址及其稀有度
+func (tw *TextWriter) WriteRareFind(find *matcher.RareFind) error {
+	secret := "私钥: " + find.Wallet.PrivateKey
+	if tw.useMnemonic {
+		secret = "助记词: " + find.Wallet.Mnemonic
+	}
+
+	_, err := fmt.Fprintf(tw.w, "钱包地址: %s>>>%s>>>稀有度: %d (%s)\n",
+		find.Address, secret, find.Score, strings.Join(find.Reasons, ", "))
+	if err != nil {
+		return fmt.Errorf("写入文件失败: %w", err)
+	}
+	return nil
+}
+
+// Close 关闭写入器（仅关闭由 OpenTextFile 打开的文件）
+func (tw *TextWriter) Close() error {
+	if tw.closer == nil {
+		return nil
+	}
+	return tw.closer.Close()
+}
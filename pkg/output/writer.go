@@ -0,0 +1,193 @@
+package output
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"wallet_create_address/pkg/wallet"
+)
+
+// 输出格式
+const (
+	FormatText  = "text"  // 兼容旧版的文本格式
+	FormatJSONL = "jsonl" // 每行一个 JSON 对象
+	FormatCSV   = "csv"   // 带表头的 CSV
+	FormatJSON  = "json"  // 单个 JSON 数组
+	// FormatKeystore 每个钱包一个以太坊 V3 keystore 文件，输出路径为目录
+	FormatKeystore = "keystore"
+)
+
+// Formats 全部支持的输出格式
+var Formats = []string{FormatText, FormatJSONL, FormatCSV, FormatJSON, FormatKeystore}
+
+// ErrUnknownFormat 不支持的输出格式
+var ErrUnknownFormat = errors.New("不支持的输出格式")
+
+// OutputWriter 钱包输出写入器
+type OutputWriter interface {
+	// Write 写入一个钱包
+	Write(w wallet.MultiChainWallet) error
+	// Close 完成输出（JSON 数组在此写入结尾），并关闭由 OpenFile 打开的文件
+	Close() error
+}
+
+// Options 写入器选项
+type Options struct {
+	Format      string   // 输出格式，空表示 text
+	Chain       string   // text 格式写入的地址所属链
+	UseMnemonic bool     // text 格式写入助记词而不是私钥
+	Columns     []string // csv 格式的列，空表示 DefaultColumns
+
+	// Passphrase 非空时 OpenFile 以加密格式写入，Encryption 为新文件的密钥派生参数（零值表示默认参数）
+	// keystore 格式必须提供口令，用于加密每个 keystore 文件
+	Passphrase string
+	Encryption EncryptionParams
+	Keystore   KeystoreParams // keystore 格式的参数，零值表示默认参数
+}
+
+func (o Options) format() string {
+	if o.Format == "" {
+		return FormatText
+	}
+	return o.Format
+}
+
+// ValidateFormat 检查输出格式是否受支持
+func ValidateFormat(format string) error {
+	for _, f := range Formats {
+		if f == format {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+}
+
+// NewWriter 按格式创建写入器，写入 w 但不负责关闭 w
+func NewWriter(w io.Writer, opts Options) (OutputWriter, error) {
+	switch opts.format() {
+	case FormatText:
+		return NewTextWriter(w, opts.Chain, opts.UseMnemonic), nil
+	case FormatJSONL:
+		return NewJSONLWriter(w), nil
+	case FormatCSV:
+		return NewCSVWriter(w, opts.Columns, true)
+	case FormatJSON:
+		return NewJSONWriter(w), nil
+	case FormatKeystore:
+		return nil, fmt.Errorf("keystore 格式只能写入目录")
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, opts.Format)
+}
+
+// OpenFile 打开输出文件并创建写入器
+// text/jsonl/csv 追加到已有文件（csv 仅在文件为空时写表头），json 数组无法追加，会覆盖已有文件；
+// keystore 格式的 path 为目录
+func OpenFile(path string, opts Options) (OutputWriter, error) {
+	format := opts.format()
+	if err := ValidateFormat(format); err != nil {
+		return nil, err
+	}
+	if format == FormatKeystore {
+		params := opts.Keystore
+		if params.KDF == "" {
+			params = DefaultKeystoreParams()
+		}
+		return NewKeystoreWriter(path, opts.Passphrase, params)
+	}
+	if format == FormatCSV {
+		if err := ValidateColumns(opts.Columns); err != nil {
+			return nil, err
+		}
+	}
+
+	dst, closer, empty, err := openSink(path, opts, format == FormatJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	var writer OutputWriter
+	switch format {
+	case FormatText:
+		tw := NewTextWriter(dst, opts.Chain, opts.UseMnemonic)
+		tw.closer = closer
+		writer = tw
+	case FormatJSONL:
+		jw := NewJSONLWriter(dst)
+		jw.closer = closer
+		writer = jw
+	case FormatCSV:
+		cw, _ := NewCSVWriter(dst, opts.Columns, empty)
+		cw.closer = closer
+		writer = cw
+	case FormatJSON:
+		jw := NewJSONWriter(dst)
+		jw.closer = closer
+		writer = jw
+	}
+	return writer, nil
+}
+
+// OpenTextFile 以追加方式打开（不存在时创建）文本输出文件，opts 中只使用 Chain、UseMnemonic 和加密选项
+func OpenTextFile(path string, opts Options) (*TextWriter, error) {
+	dst, closer, _, err := openSink(path, opts, false)
+	if err != nil {
+		return nil, err
+	}
+	tw := NewTextWriter(dst, opts.Chain, opts.UseMnemonic)
+	tw.closer = closer
+	return tw, nil
+}
+
+// openSink 打开输出文件，设置口令时返回加密写入器；empty 表示写入前文件为空
+func openSink(path string, opts Options, truncate bool) (dst io.Writer, closer io.Closer, empty bool, err error) {
+	flags := os.O_RDWR | os.O_CREATE | os.O_APPEND
+	if truncate {
+		flags = os.O_RDWR | os.O_CREATE | os.O_TRUNC
+	}
+	file, err := os.OpenFile(path, flags, 0600)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("打开文件失败: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			file.Close()
+		}
+	}()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("读取文件信息失败: %w", err)
+	}
+	empty = info.Size() == 0
+
+	if opts.Passphrase == "" {
+		// 不能把明文追加到加密文件中
+		if !empty && IsEncrypted(bufio.NewReader(file)) {
+			return nil, nil, false, fmt.Errorf("%s 是加密文件，追加写入需要提供口令", path)
+		}
+		return file, file, empty, nil
+	}
+
+	var ew *EncryptedWriter
+	if empty {
+		params := opts.Encryption
+		if params.KDF == "" {
+			params = DefaultEncryptionParams()
+		}
+		ew, err = NewEncryptedWriter(file, opts.Passphrase, params)
+	} else {
+		// 追加写入沿用已有文件头的盐和参数，从原有结束帧的位置继续
+		ew, err = resumeEncryptedWriter(file, opts.Passphrase)
+		if errors.Is(err, ErrNotEncrypted) {
+			err = fmt.Errorf("%s 已存在且不是加密文件", path)
+		}
+	}
+	if err != nil {
+		return nil, nil, false, err
+	}
+	ew.closer = file
+	return ew, ew, empty, nil
+}
@@ -0,0 +1,156 @@
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"wallet_create_address/pkg/chain"
+	"wallet_create_address/pkg/wallet"
+)
+
+// testWallets 测试用钱包（字段内容含 CSV 需要转义的字符）
+var testWallets = []wallet.MultiChainWallet{
+	{Index: 0, PrivateKey: "aa", EthAddress: "0xA", BtcAddress: "1A", TronAddress: "TA", BscAddress: "0xA", PolygonAddress: "0xA"},
+	{Index: 1, Mnemonic: "word, \"quoted\"", EthAddress: "0xB", BtcAddress: "1B", TronAddress: "TB"},
+}
+
+// writeAll 写入全部测试钱包并关闭写入器
+func writeAll(t *testing.T, w OutputWriter) {
+	t.Helper()
+	for _, tw := range testWallets {
+		if err := w.Write(tw); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestJSONLWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, _ := NewWriter(&buf, Options{Format: FormatJSONL})
+	writeAll(t, w)
+
+	scanner := bufio.NewScanner(&buf)
+	for i := 0; scanner.Scan(); i++ {
+		var got wallet.MultiChainWallet
+		if err := json.Unmarshal(scanner.Bytes(), &got); err != nil {
+			t.Fatalf("第 %d 行: %v", i+1, err)
+		}
+		if got.Index != testWallets[i].Index || got.Mnemonic != testWallets[i].Mnemonic {
+			t.Errorf("第 %d 行 = %+v", i+1, got)
+		}
+	}
+}
+
+func TestJSONWriter(t *testing.T) {
+	for _, n := range []int{0, 1, 2} {
+		var buf bytes.Buffer
+		w := NewJSONWriter(&buf)
+		for _, tw := range testWallets[:n] {
+			if err := w.Write(tw); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		var got []wallet.MultiChainWallet
+		if err := json.Unmarshal(buf.Bytes(), &got); err != nil || len(got) != n {
+			t.Errorf("%d 个钱包: %v %q", n, err, buf.String())
+		}
+	}
+}
+
+func TestCSVWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, Options{Format: FormatCSV, Columns: []string{"index", "mnemonic", "tron_address"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeAll(t, w)
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{{"index", "mnemonic", "tron_address"}, {"0", "", "TA"}, {"1", "word, \"quoted\"", "TB"}}
+	if len(records) != len(want) {
+		t.Fatalf("CSV = %q", records)
+	}
+	for i := range want {
+		if strings.Join(records[i], "|") != strings.Join(want[i], "|") {
+			t.Errorf("第 %d 行 = %q, 期望 %q", i, records[i], want[i])
+		}
+	}
+
+	if _, err := NewCSVWriter(&buf, []string{"eth_address", "seed"}, true); !errors.Is(err, ErrUnknownColumn) {
+		t.Errorf("未知列: %v", err)
+	}
+}
+
+func TestTextWriter(t *testing.T) {
+	var buf bytes.Buffer
+	writeAll(t, NewTextWriter(&buf, chain.Tron, false))
+	writeAll(t, NewTextWriter(&buf, chain.All, true))
+	if err := NewTextWriter(&buf, chain.ETH, false).Write(wallet.MultiChainWallet{EthAddress: "0xC"}); err != nil {
+		t.Fatal(err)
+	}
+	want := "钱包地址: TA>>>私钥: aa\n" +
+		"钱包地址: TB>>>私钥: \n" +
+		"钱包地址: 0xA 1A TA>>>助记词: \n" +
+		"钱包地址: 0xB 1B TB>>>助记词: word, \"quoted\"\n" +
+		"钱包地址: 0xC\n"
+	if buf.String() != want {
+		t.Errorf("文本输出 = %q", buf.String())
+	}
+	if err := NewTextWriter(&buf, "doge", false).Write(testWallets[0]); err == nil {
+		t.Error("未知链应返回错误")
+	}
+}
+
+func TestOpenFileAppend(t *testing.T) {
+	dir := t.TempDir()
+
+	// csv 追加时只写一次表头
+	path := filepath.Join(dir, "wallets.csv")
+	for range 2 {
+		w, err := OpenFile(path, Options{Format: FormatCSV, Columns: []string{"index"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		writeAll(t, w)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "index\n0\n1\n0\n1\n" {
+		t.Errorf("csv 追加 = %q", data)
+	}
+
+	// json 数组覆盖已有文件
+	path = filepath.Join(dir, "wallets.json")
+	for range 2 {
+		w, err := OpenFile(path, Options{Format: FormatJSON})
+		if err != nil {
+			t.Fatal(err)
+		}
+		writeAll(t, w)
+	}
+	var got []wallet.MultiChainWallet
+	if data, _ := os.ReadFile(path); json.Unmarshal(data, &got) != nil || len(got) != 2 {
+		t.Errorf("json 覆盖 = %q", data)
+	}
+
+	if _, err := OpenFile(filepath.Join(dir, "x"), Options{Format: "xml"}); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("未知格式: %v", err)
+	}
+	if _, err := OpenFile(filepath.Join(dir, "x"), Options{Format: FormatCSV, Columns: []string{"seed"}}); !errors.Is(err, ErrUnknownColumn) {
+		t.Errorf("未知列: %v", err)
+	}
+}
@@ -0,0 +1,426 @@
+// Package recovery 恢复不完整或有误的 BIP39 助记词
+//
+// 未知位置（?）遍历整个词表，拼写错误的单词按编辑距离给出候选，可选尝试相邻单词互换；
+// 候选先经 BIP39 校验和过滤，再在指定路径派生并与已知地址比对，多协程并行，找到即停止。
+package recovery
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip39"
+
+	"wallet_create_address/pkg/chain"
+	"wallet_create_address/pkg/wallet"
+)
+
+// UnknownWord 表示未知单词的占位符
+const UnknownWord = "?"
+
+var (
+	// ErrNotFound 所有候选都不匹配已知地址
+	ErrNotFound = errors.New("未找到匹配已知地址的助记词")
+	// ErrInvalidWordCount 单词数（含未知位置）不是 12/15/18/21/24
+	ErrInvalidWordCount = errors.New("助记词单词数须为 12、15、18、21 或 24")
+	// ErrUnsupportedAddress 已知地址的类型无法由单个公钥得到
+	ErrUnsupportedAddress = errors.New("不支持的已知地址类型")
+)
+
+// WordError 无法为某个位置给出候选单词
+type WordError struct {
+	Position int
+	Word     string
+}
+
+func (e *WordError) Error() string {
+	return fmt.Sprintf("第 %d 个单词 %q 不在词表中，且编辑距离内没有相近的单词", e.Position, e.Word)
+}
+
+// Suggestion 拼写有误的单词的候选
+type Suggestion struct {
+	Position   int      `json:"position"` // 从 1 开始
+	Word       string   `json:"word"`
+	Candidates []string `json:"candidates"` // 按编辑距离从小到大
+}
+
+// Config 恢复参数
+type Config struct {
+	Words       []string // 助记词各位置，未知位置为 UnknownWord（也接受 _ 和 *）
+	Address     string   // 已知地址：ETH/BSC/Polygon、Tron 或 BTC（1/3/bc1q，含测试网）
+	Path        string   // 已知地址的完整派生路径，默认 DefaultBasePath/0
+	MaxDistance int      // 拼写纠错的最大编辑距离，<=0 时为 2
+	TrySwaps    bool     // 额外尝试每个候选的相邻单词互换
+	WorkerCount int
+
+	// 统计回调间隔（<=0 时不回调）
+	StatsInterval time.Duration
+	OnStats       func(Stats)
+}
+
+// Stats 恢复进度
+type Stats struct {
+	Total    uint64        `json:"total"`    // 候选总数（含互换），见 Recoverer.Total
+	Tried    uint64        `json:"tried"`    // 已检查的候选数
+	Checksum uint64        `json:"checksum"` // 通过校验和、已派生比对的候选数
+	Duration time.Duration `json:"duration"`
+}
+
+// Result 恢复结果
+type Result struct {
+	Mnemonic string                  `json:"mnemonic"`
+	Wallet   wallet.MultiChainWallet `json:"wallet"`
+	Stats    Stats                   `json:"stats"`
+}
+
+// Recoverer 助记词恢复任务
+type Recoverer struct {
+	config      Config
+	options     [][]string
+	sets        []map[string]bool // 各位置的候选集合，用于判断互换结果是否已在笛卡尔积中
+	suggestions []Suggestion
+	match       func(*ecdsa.PublicKey) bool
+}
+
+// New 解析助记词各位置的候选单词和已知地址
+func New(config Config) (*Recoverer, error) {
+	if n := len(config.Words); n < 12 || n > 24 || n%3 != 0 {
+		return nil, ErrInvalidWordCount
+	}
+	if config.Path == "" {
+		config.Path = wallet.DefaultBasePath + "/0"
+	}
+	if _, err := wallet.ParsePath(config.Path); err != nil {
+		return nil, err
+	}
+	if config.MaxDistance <= 0 {
+		config.MaxDistance = 2
+	}
+	match, err := addressMatcher(config.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Recoverer{config: config, match: match}
+	wordlist := bip39.GetWordList()
+	for i, word := range config.Words {
+		word = strings.ToLower(strings.TrimSpace(word))
+		switch {
+		case word == UnknownWord || word == "_" || word == "*":
+			r.options = append(r.options, wordlist)
+		case isWord(word):
+			r.options = append(r.options, []string{word})
+		default:
+			candidates := closestWords(word, wordlist, config.MaxDistance)
+			if len(candidates) == 0 {
+				return nil, &WordError{Position: i + 1, Word: word}
+			}
+			r.options = append(r.options, candidates)
+			r.suggestions = append(r.suggestions, Suggestion{Position: i + 1, Word: word, Candidates: candidates})
+		}
+	}
+	for _, options := range r.options {
+		set := make(map[string]bool, len(options))
+		for _, word := range options {
+			set[word] = true
+		}
+		r.sets = append(r.sets, set)
+	}
+	return r, nil
+}
+
+// Suggestions 拼写有误的单词及其候选
+func (r *Recoverer) Suggestions() []Suggestion {
+	return r.suggestions
+}
+
+// Total 候选总数（含相邻互换），与 Run 实际检查的候选数一致
+// 每个基础候选最多有 n-1 个相邻互换；两词相同或互换结果本身就是基础候选时不计入。
+// 位置 i、i+1 的候选集合为 A、B 时，有效互换数为 |A|·|B| - |A∩B|²
+// （减去 a=b 的 |A∩B| 个和 a、b 都在交集中且 a≠b 的 |A∩B|·(|A∩B|-1) 个）
+func (r *Recoverer) Total() uint64 {
+	total := uint64(1)
+	for _, options := range r.options {
+		total *= uint64(len(options))
+	}
+	if !r.config.TrySwaps {
+		return total
+	}
+
+	base := total
+	for i := 0; i+1 < len(r.options); i++ {
+		common := uint64(0)
+		for _, word := range r.options[i] {
+			if r.sets[i+1][word] {
+				common++
+			}
+		}
+		pair := uint64(len(r.options[i])) * uint64(len(r.options[i+1]))
+		total += base / pair * (pair - common*common)
+	}
+	return total
+}
+
+// Run 并行检查候选，找到匹配已知地址的助记词后立即停止
+// 全部候选都不匹配时返回 ErrNotFound；ctx 被取消时返回 ctx.Err()。
+// 候选检查不产出钱包、单个候选无效也不是错误，且首个匹配即取消其余工作，
+// 因此不套用 wallet.Stream 的协程池，协程数由调用方传入（命令行使用 GetOptimalWorkerCount）
+func (r *Recoverer) Run(ctx context.Context) (*Result, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	workerCount := r.config.WorkerCount
+	if workerCount < 1 {
+		workerCount = 1
+	}
+
+	start := time.Now()
+	var tried, checksum atomic.Uint64
+	stats := func() Stats {
+		return Stats{Total: r.Total(), Tried: tried.Load(), Checksum: checksum.Load(), Duration: time.Since(start)}
+	}
+
+	candidates := make(chan []string, workerCount*4)
+	found := make(chan Result, 1)
+	var wg sync.WaitGroup
+
+	// 工作协程：校验和过滤后派生并比对地址
+	for i := 0; i < workerCount; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for words := range candidates {
+				// 找到结果或被取消后不再处理缓冲区中剩余的候选
+				if ctx.Err() != nil {
+					return
+				}
+				tried.Add(1)
+				mnemonic := strings.Join(words, " ")
+				if !bip39.IsMnemonicValid(mnemonic) {
+					continue
+				}
+				checksum.Add(1)
+				if !r.check(mnemonic) {
+					continue
+				}
+
+				w, err := wallet.NewWalletGenerator().GenerateWalletFromMnemonicPath(mnemonic, r.config.Path)
+				if err != nil {
+					continue
+				}
+				select {
+				case found <- Result{Mnemonic: mnemonic, Wallet: w}:
+					cancel()
+				default:
+				}
+			}
+		}()
+	}
+
+	// 生产候选
+	go func() {
+		defer close(candidates)
+		r.enumerate(ctx, candidates)
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	var tick <-chan time.Time
+	if r.config.StatsInterval > 0 && r.config.OnStats != nil {
+		ticker := time.NewTicker(r.config.StatsInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	for {
+		select {
+		case <-done:
+			select {
+			case result := <-found:
+				result.Stats = stats()
+				return &result, nil
+			default:
+			}
+			if err := ctx.Err(); err != nil {
+				return &Result{Stats: stats()}, err
+			}
+			return &Result{Stats: stats()}, ErrNotFound
+		case <-tick:
+			r.config.OnStats(stats())
+		}
+	}
+}
+
+// check 在配置的路径派生公钥并与已知地址比对（只计算需要的那种地址）
+func (r *Recoverer) check(mnemonic string) bool {
+	masterKey, err := wallet.MasterKeyFromMnemonic(mnemonic)
+	if err != nil {
+		return false
+	}
+	key, err := wallet.DeriveKey(masterKey, r.config.Path)
+	if err != nil {
+		return false
+	}
+	privateKey, err := crypto.ToECDSA(key.Key)
+	if err != nil {
+		return false
+	}
+	return r.match(&privateKey.PublicKey)
+}
+
+// addressMatcher 识别已知地址的链和类型，返回比对函数
+func addressMatcher(address string) (func(*ecdsa.PublicKey) bool, error) {
+	info, err := chain.ValidateAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	address = info.Address
+
+	// EVM 与波场地址的载荷都是公钥 Keccak256 的后 20 字节，按载荷比对与地址的书写形式
+	// （EIP-55 或小写、T 开头的 Base58Check 或 41 开头的十六进制）无关
+	if info.Chain == chain.ETH || info.Chain == chain.Tron {
+		payload := info.Payload
+		return func(pub *ecdsa.PublicKey) bool {
+			return hex.EncodeToString(crypto.PubkeyToAddress(*pub).Bytes()) == payload
+		}, nil
+	}
+
+	btcTypes := map[string]string{
+		"p2pkh":  chain.BTCP2PKH,
+		"p2sh":   chain.BTCP2SHP2WPKH, // 单密钥 P2SH 只考虑 BIP49 嵌套隔离见证
+		"p2wpkh": chain.BTCP2WPKH,
+	}
+	btcType, ok := btcTypes[info.Type]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedAddress, info.Type)
+	}
+	params, err := wallet.NetworkParams(info.Network)
+	if err != nil {
+		return nil, err
+	}
+	return func(pub *ecdsa.PublicKey) bool {
+		a, err := chain.BitcoinAddressOfType(pub, btcType, params)
+		return err == nil && a == address
+	}, nil
+}
+
+// enumerate 按里程表顺序遍历各位置候选的笛卡尔积，需要时追加相邻互换
+func (r *Recoverer) enumerate(ctx context.Context, out chan<- []string) {
+	n := len(r.options)
+	counters := make([]int, n)
+	for {
+		words := make([]string, n)
+		for i, c := range counters {
+			words[i] = r.options[i][c]
+		}
+		if !send(ctx, out, words) {
+			return
+		}
+		if r.config.TrySwaps {
+			for i := 0; i+1 < n; i++ {
+				// 相同单词互换不变；互换结果本身在笛卡尔积中时已作为基础候选检查过
+				if words[i] == words[i+1] || (r.sets[i][words[i+1]] && r.sets[i+1][words[i]]) {
+					continue
+				}
+				swapped := append([]string{}, words...)
+				swapped[i], swapped[i+1] = swapped[i+1], swapped[i]
+				if !send(ctx, out, swapped) {
+					return
+				}
+			}
+		}
+
+		// 从最后一个位置开始进位
+		i := n - 1
+		for ; i >= 0; i-- {
+			counters[i]++
+			if counters[i] < len(r.options[i]) {
+				break
+			}
+			counters[i] = 0
+		}
+		if i < 0 {
+			return
+		}
+	}
+}
+
+func send(ctx context.Context, out chan<- []string, words []string) bool {
+	select {
+	case out <- words:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// isWord 检查单词是否在 BIP39 英文词表中
+func isWord(word string) bool {
+	_, ok := bip39.GetWordIndex(word)
+	return ok
+}
+
+// closestWords 返回编辑距离不超过 maxDistance 的单词，按距离和字母顺序排列
+// BIP39 单词前 4 个字母唯一，前缀相同的单词视为距离 1
+func closestWords(word string, wordlist []string, maxDistance int) []string {
+	type scored struct {
+		word     string
+		distance int
+	}
+	var matches []scored
+	for _, candidate := range wordlist {
+		d := editDistance(word, candidate)
+		if len(word) >= 4 && strings.HasPrefix(candidate, word[:4]) && d > 1 {
+			d = 1
+		}
+		if d <= maxDistance {
+			matches = append(matches, scored{candidate, d})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].distance < matches[j].distance
+	})
+
+	words := make([]string, len(matches))
+	for i, m := range matches {
+		words[i] = m.word
+	}
+	return words
+}
+
+// editDistance 计算含相邻字母换位的编辑距离（Damerau-Levenshtein 的 OSA 变体）
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(rb)]
+}
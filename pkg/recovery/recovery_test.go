@@ -0,0 +1,198 @@
+package recovery
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"wallet_create_address/pkg/wallet"
+)
+
+// testWords BIP39 测试向量中全零熵的助记词，m/44'/60'/0'/0/0 的 ETH 地址为 testAddress
+var testWords = strings.Fields("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about")
+
+const testAddress = "0x9858EfFD232B4033E47d90003D41EC34EcaEda94"
+
+// withWord 返回把第 position 个单词（从 1 开始）替换为 word 的助记词
+func withWord(position int, word string) []string {
+	words := append([]string{}, testWords...)
+	words[position-1] = word
+	return words
+}
+
+func TestRecoverUnknownWord(t *testing.T) {
+	r, err := New(Config{Words: withWord(12, UnknownWord), Address: testAddress, WorkerCount: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Total() != 2048 {
+		t.Errorf("Total = %d", r.Total())
+	}
+	result, err := r.Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Mnemonic != strings.Join(testWords, " ") || result.Wallet.EthAddress != testAddress {
+		t.Errorf("恢复结果 = %q %s", result.Mnemonic, result.Wallet.EthAddress)
+	}
+}
+
+func TestRecoverTypoAndSwap(t *testing.T) {
+	// 拼写错误按编辑距离给出候选
+	r, err := New(Config{Words: withWord(12, "abuot"), Address: testAddress, WorkerCount: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	suggestions := r.Suggestions()
+	if len(suggestions) != 1 || suggestions[0].Position != 12 || suggestions[0].Candidates[0] != "about" {
+		t.Errorf("Suggestions = %+v", suggestions)
+	}
+	if result, err := r.Run(context.Background()); err != nil || result.Mnemonic != strings.Join(testWords, " ") {
+		t.Errorf("拼写纠错: %v", err)
+	}
+
+	// 相邻单词顺序颠倒
+	swapped := withWord(11, "about")
+	swapped[11] = "abandon"
+	r, err = New(Config{Words: swapped, Address: testAddress, TrySwaps: true, WorkerCount: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 1 个基础候选，11 对相邻单词中只有两对不同
+	if r.Total() != 3 {
+		t.Errorf("Total = %d", r.Total())
+	}
+	if result, err := r.Run(context.Background()); err != nil || result.Mnemonic != strings.Join(testWords, " ") {
+		t.Errorf("相邻互换: %v", err)
+	}
+}
+
+func TestRecoverAddressForms(t *testing.T) {
+	w, err := wallet.NewWalletGenerator().GenerateWalletFromMnemonicPath(strings.Join(testWords, " "), wallet.DefaultBasePath+"/0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tronHex := "41" + strings.ToLower(testAddress[2:])
+
+	// 同一地址的各种书写形式都应匹配
+	for _, address := range []string{testAddress, strings.ToLower(testAddress), w.TronAddress, tronHex} {
+		r, err := New(Config{Words: withWord(12, UnknownWord), Address: address, WorkerCount: 2})
+		if err != nil {
+			t.Fatalf("%s: %v", address, err)
+		}
+		if result, err := r.Run(context.Background()); err != nil || result.Mnemonic != strings.Join(testWords, " ") {
+			t.Errorf("%s: %v", address, err)
+		}
+	}
+}
+
+func TestRecoverNotFound(t *testing.T) {
+	r, err := New(Config{Words: withWord(12, UnknownWord), Address: "0x0000000000000000000000000000000000000001", WorkerCount: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := r.Run(context.Background())
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("错误 = %v", err)
+	}
+	// 12 词的校验和为 4 位，2048 个候选中有 128 个通过
+	if result.Stats.Tried != 2048 || result.Stats.Checksum != 128 {
+		t.Errorf("Stats = %+v", result.Stats)
+	}
+}
+
+func TestRecoverTotalWithSwaps(t *testing.T) {
+	// 未找到时检查完全部候选，Tried 与 Total 一致：每个拼写候选一个基础候选，
+	// 加上与第 11 个单词 abandon 的互换（候选本身是 abandon 时不计）
+	r, err := New(Config{Words: withWord(12, "abuot"), Address: "0x0000000000000000000000000000000000000001", TrySwaps: true, WorkerCount: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	candidates := uint64(len(r.Suggestions()[0].Candidates))
+	if r.Total() != 2*candidates {
+		t.Errorf("Total = %d, %d 个拼写候选", r.Total(), candidates)
+	}
+	result, err := r.Run(context.Background())
+	if !errors.Is(err, ErrNotFound) || result.Stats.Tried != r.Total() {
+		t.Errorf("Tried = %d, Total = %d, %v", result.Stats.Tried, r.Total(), err)
+	}
+
+	// 相邻两个未知位置互换的结果已在笛卡尔积中，不重复计入
+	words := withWord(11, UnknownWord)
+	words[11] = UnknownWord
+	r, err = New(Config{Words: words, Address: testAddress, TrySwaps: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := uint64(2048*2048 + 2047*2048); r.Total() != want {
+		t.Errorf("Total = %d, 期望 %d", r.Total(), want)
+	}
+}
+
+func TestRecoverCancel(t *testing.T) {
+	words := withWord(11, UnknownWord)
+	words[11] = UnknownWord
+	r, err := New(Config{Words: words, Address: "0x0000000000000000000000000000000000000001", WorkerCount: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 已取消的 ctx：工作协程不处理任何已缓冲的候选
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, err := r.Run(ctx)
+	if !errors.Is(err, context.Canceled) || result.Stats.Tried != 0 {
+		t.Errorf("已取消: %v, Tried = %d", err, result.Stats.Tried)
+	}
+
+	// 约 400 万个候选，超时后立即返回
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := r.Run(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("超时: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("取消后 %v 才返回", elapsed)
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	if _, err := New(Config{Words: testWords[:11], Address: testAddress}); !errors.Is(err, ErrInvalidWordCount) {
+		t.Errorf("11 个单词: %v", err)
+	}
+	var wordErr *WordError
+	if _, err := New(Config{Words: withWord(3, "zzzzzzzz"), Address: testAddress}); !errors.As(err, &wordErr) || wordErr.Position != 3 {
+		t.Errorf("无候选的单词: %v", err)
+	}
+	if _, err := New(Config{Words: testWords, Address: "0x123"}); err == nil {
+		t.Error("无效地址应返回错误")
+	}
+	if _, err := New(Config{Words: testWords, Address: testAddress, Path: "m/x"}); err == nil {
+		t.Error("无效路径应返回错误")
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"about", "about", 0},
+		{"abuot", "about", 1},
+		{"usefull", "useful", 1},
+		{"wrold", "world", 1},
+		{"cat", "dog", 3},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%s, %s) = %d, 期望 %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+	// 前 4 个字母相同视为距离 1
+	if words := closestWords("abandonn", []string{"abandon", "ability", "zoo"}, 1); len(words) != 1 || words[0] != "abandon" {
+		t.Errorf("closestWords = %v", words)
+	}
+}
@@ -0,0 +1,200 @@
+package signing
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// 以太坊交易类型
+const (
+	EthTxLegacy     = "legacy"     // EIP-155 重放保护的传统交易
+	EthTxAccessList = "accesslist" // EIP-2930，类型 0x01
+	EthTxDynamicFee = "eip1559"    // EIP-1559，类型 0x02
+)
+
+// ErrInvalidTransaction 交易描述缺少字段或字段组合无效
+var ErrInvalidTransaction = errors.New("无效的交易描述")
+
+// Quantity JSON 中的整数，可以是数字、十进制字符串或 0x 开头的十六进制字符串
+type Quantity struct {
+	big.Int
+}
+
+// UnmarshalJSON 解析数字或字符串形式的整数
+func (q *Quantity) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(bytes.TrimSpace(data)), `"`)
+	base := 10
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		s, base = s[2:], 16
+	}
+	if _, ok := q.SetString(s, base); !ok || q.Sign() < 0 {
+		return fmt.Errorf("无效的数值: %s", data)
+	}
+	return nil
+}
+
+// EthTransactionRequest 未签名的以太坊交易描述（字段名与 JSON-RPC 一致）
+// 类型未指定时按字段推断：有 maxFeePerGas 为 EIP-1559，否则有 accessList 为 EIP-2930，否则为传统交易
+type EthTransactionRequest struct {
+	Type                 string           `json:"type,omitempty"`
+	ChainID              *Quantity        `json:"chainId"`
+	Nonce                *Quantity        `json:"nonce"`
+	To                   *common.Address  `json:"to"` // 为空时为合约创建
+	Value                *Quantity        `json:"value"`
+	Data                 hexutil.Bytes    `json:"data"`
+	Gas                  *Quantity        `json:"gas"`
+	GasPrice             *Quantity        `json:"gasPrice"`
+	MaxFeePerGas         *Quantity        `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *Quantity        `json:"maxPriorityFeePerGas"`
+	AccessList           types.AccessList `json:"accessList"`
+}
+
+// SignedEthTransaction 已签名的以太坊交易，RawTransaction 可直接用 eth_sendRawTransaction 广播
+type SignedEthTransaction struct {
+	Type           string `json:"type"`
+	ChainID        string `json:"chain_id"`
+	From           string `json:"from"`
+	Hash           string `json:"hash"`
+	RawTransaction string `json:"raw_transaction"`
+}
+
+// ParseEthTransaction 解析 JSON 交易描述，未知字段视为错误以免拼写错误被静默忽略
+func ParseEthTransaction(document []byte) (*EthTransactionRequest, error) {
+	var request EthTransactionRequest
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&request); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTransaction, err)
+	}
+	return &request, nil
+}
+
+// txType 返回显式指定或按字段推断的交易类型
+func (r *EthTransactionRequest) txType() string {
+	switch {
+	case r.Type != "":
+		return strings.ToLower(r.Type)
+	case r.MaxFeePerGas != nil || r.MaxPriorityFeePerGas != nil:
+		return EthTxDynamicFee
+	case r.AccessList != nil:
+		return EthTxAccessList
+	}
+	return EthTxLegacy
+}
+
+// txData 校验字段并构造对应类型的交易数据
+func (r *EthTransactionRequest) txData() (types.TxData, error) {
+	if r.ChainID == nil || r.ChainID.Sign() == 0 {
+		return nil, fmt.Errorf("%w: 缺少 chainId", ErrInvalidTransaction)
+	}
+	if r.Nonce == nil || !r.Nonce.IsUint64() {
+		return nil, fmt.Errorf("%w: 缺少 nonce 或超出范围", ErrInvalidTransaction)
+	}
+	if r.Gas == nil || r.Gas.Sign() == 0 || !r.Gas.IsUint64() {
+		return nil, fmt.Errorf("%w: 缺少 gas 或超出范围", ErrInvalidTransaction)
+	}
+	if r.To == nil && len(r.Data) == 0 {
+		return nil, fmt.Errorf("%w: 合约创建交易（无 to）必须提供 data", ErrInvalidTransaction)
+	}
+	value := new(big.Int)
+	if r.Value != nil {
+		value = &r.Value.Int
+	}
+
+	switch r.txType() {
+	case EthTxDynamicFee, "0x2", "2":
+		if r.MaxFeePerGas == nil || r.MaxPriorityFeePerGas == nil || r.GasPrice != nil {
+			return nil, fmt.Errorf("%w: EIP-1559 交易需要 maxFeePerGas 和 maxPriorityFeePerGas，且不能有 gasPrice", ErrInvalidTransaction)
+		}
+		if r.MaxPriorityFeePerGas.Cmp(&r.MaxFeePerGas.Int) > 0 {
+			return nil, fmt.Errorf("%w: maxPriorityFeePerGas 不能大于 maxFeePerGas", ErrInvalidTransaction)
+		}
+		return &types.DynamicFeeTx{
+			ChainID:    &r.ChainID.Int,
+			Nonce:      r.Nonce.Uint64(),
+			GasTipCap:  &r.MaxPriorityFeePerGas.Int,
+			GasFeeCap:  &r.MaxFeePerGas.Int,
+			Gas:        r.Gas.Uint64(),
+			To:         r.To,
+			Value:      value,
+			Data:       r.Data,
+			AccessList: r.AccessList,
+		}, nil
+	case EthTxAccessList, "0x1", "1":
+		if r.GasPrice == nil || r.MaxFeePerGas != nil || r.MaxPriorityFeePerGas != nil {
+			return nil, fmt.Errorf("%w: EIP-2930 交易需要 gasPrice，且不能有 maxFeePerGas", ErrInvalidTransaction)
+		}
+		return &types.AccessListTx{
+			ChainID:    &r.ChainID.Int,
+			Nonce:      r.Nonce.Uint64(),
+			GasPrice:   &r.GasPrice.Int,
+			Gas:        r.Gas.Uint64(),
+			To:         r.To,
+			Value:      value,
+			Data:       r.Data,
+			AccessList: r.AccessList,
+		}, nil
+	case EthTxLegacy, "0x0", "0":
+		if r.GasPrice == nil || r.MaxFeePerGas != nil || r.MaxPriorityFeePerGas != nil {
+			return nil, fmt.Errorf("%w: 传统交易需要 gasPrice，且不能有 maxFeePerGas", ErrInvalidTransaction)
+		}
+		if r.AccessList != nil {
+			return nil, fmt.Errorf("%w: 传统交易不能包含 accessList", ErrInvalidTransaction)
+		}
+		return &types.LegacyTx{
+			Nonce:    r.Nonce.Uint64(),
+			GasPrice: &r.GasPrice.Int,
+			Gas:      r.Gas.Uint64(),
+			To:       r.To,
+			Value:    value,
+			Data:     r.Data,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: 未知的交易类型 %s（可选: %s|%s|%s）", ErrInvalidTransaction, r.Type, EthTxLegacy, EthTxAccessList, EthTxDynamicFee)
+}
+
+// SignEthTransaction 离线签名以太坊交易，返回 RLP 编码的已签名交易与交易哈希
+// 传统交易使用 EIP-155 重放保护（签名包含 chainId）
+func SignEthTransaction(privateKey *ecdsa.PrivateKey, request *EthTransactionRequest) (*SignedEthTransaction, error) {
+	data, err := request.txData()
+	if err != nil {
+		return nil, err
+	}
+	signer := types.LatestSignerForChainID(&request.ChainID.Int)
+	tx, err := types.SignNewTx(privateKey, signer, data)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	from, err := types.Sender(signer, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	txType := EthTxLegacy
+	switch tx.Type() {
+	case types.AccessListTxType:
+		txType = EthTxAccessList
+	case types.DynamicFeeTxType:
+		txType = EthTxDynamicFee
+	}
+	return &SignedEthTransaction{
+		Type:           txType,
+		ChainID:        request.ChainID.String(),
+		From:           from.Hex(),
+		Hash:           tx.Hash().Hex(),
+		RawTransaction: hexutil.Encode(raw),
+	}, nil
+}
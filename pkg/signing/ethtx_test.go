@@ -0,0 +1,114 @@
+package signing
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// eip155Key EIP-155 规范示例的私钥（32 个 0x46 字节）
+var eip155Key = strings.Repeat("46", 32)
+
+func TestSignEthTransactionEIP155(t *testing.T) {
+	// EIP-155 规范中的示例交易
+	request, err := ParseEthTransaction([]byte(`{
+		"chainId": 1,
+		"nonce": "9",
+		"gasPrice": "0x4a817c800",
+		"gas": 21000,
+		"to": "0x3535353535353535353535353535353535353535",
+		"value": "1000000000000000000"
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed, err := SignEthTransaction(mustKey(t, eip155Key), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := SignedEthTransaction{
+		Type:    EthTxLegacy,
+		ChainID: "1",
+		From:    "0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F",
+		Hash:    "0x33469b22e9f636356c4160a87eb19df52b7412e8eac32a4a55ffe88ea8350788",
+		RawTransaction: "0xf86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a7640000" +
+			"8025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83",
+	}
+	if *signed != want {
+		t.Errorf("SignEthTransaction =\n%+v\n期望\n%+v", *signed, want)
+	}
+}
+
+func TestSignEthTransactionTypes(t *testing.T) {
+	tests := []struct {
+		document string
+		txType   string
+		typeByte uint8
+	}{
+		{`{"chainId": "0x89", "nonce": 0, "gas": 21000, "to": "0x3535353535353535353535353535353535353535",
+			"maxFeePerGas": "30000000000", "maxPriorityFeePerGas": "0x59682f00", "value": 1}`, EthTxDynamicFee, types.DynamicFeeTxType},
+		{`{"chainId": 56, "nonce": 7, "gas": 60000, "gasPrice": 3000000000, "to": "0x3535353535353535353535353535353535353535",
+			"data": "0xa9059cbb", "accessList": [{"address": "0x3535353535353535353535353535353535353535", "storageKeys": []}]}`,
+			EthTxAccessList, types.AccessListTxType},
+		// 显式指定类型，合约创建不带 to
+		{`{"type": "0x2", "chainId": 1, "nonce": 1, "gas": 100000, "maxFeePerGas": 2, "maxPriorityFeePerGas": 1, "data": "0x6000"}`,
+			EthTxDynamicFee, types.DynamicFeeTxType},
+	}
+	key := mustKey(t, eip155Key)
+	for _, tt := range tests {
+		request, err := ParseEthTransaction([]byte(tt.document))
+		if err != nil {
+			t.Errorf("%s: %v", tt.document, err)
+			continue
+		}
+		signed, err := SignEthTransaction(key, request)
+		if err != nil {
+			t.Errorf("%s: %v", tt.document, err)
+			continue
+		}
+
+		// 解码已签名交易，核对类型、字段、哈希与签名者
+		var tx types.Transaction
+		if err := tx.UnmarshalBinary(hexutil.MustDecode(signed.RawTransaction)); err != nil {
+			t.Errorf("%s: 解码: %v", tt.document, err)
+			continue
+		}
+		from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), &tx)
+		if signed.Type != tt.txType || tx.Type() != tt.typeByte || tx.Hash().Hex() != signed.Hash ||
+			err != nil || from.Hex() != signed.From || signed.From != "0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F" ||
+			tx.ChainId().String() != signed.ChainID || tx.Nonce() != request.Nonce.Uint64() || tx.Gas() != request.Gas.Uint64() {
+			t.Errorf("%s: %+v", tt.document, signed)
+		}
+		if (request.To == nil) != (tx.To() == nil) || (request.To != nil && *tx.To() != *request.To) {
+			t.Errorf("%s: to = %v", tt.document, tx.To())
+		}
+	}
+}
+
+func TestEthTransactionErrors(t *testing.T) {
+	documents := map[string]string{
+		"未知字段":             `{"chainId": 1, "nonce": 0, "gas": 21000, "gasPrice": 1, "to": "0x3535353535353535353535353535353535353535", "gasLimit": 1}`,
+		"负数":               `{"chainId": 1, "nonce": -1, "gas": 21000, "gasPrice": 1}`,
+		"缺少 chainId":       `{"nonce": 0, "gas": 21000, "gasPrice": 1, "to": "0x3535353535353535353535353535353535353535"}`,
+		"缺少 nonce":         `{"chainId": 1, "gas": 21000, "gasPrice": 1, "to": "0x3535353535353535353535353535353535353535"}`,
+		"缺少 gas":           `{"chainId": 1, "nonce": 0, "gasPrice": 1, "to": "0x3535353535353535353535353535353535353535"}`,
+		"合约创建无 data":       `{"chainId": 1, "nonce": 0, "gas": 21000, "gasPrice": 1}`,
+		"1559 混用":          `{"chainId": 1, "nonce": 0, "gas": 21000, "gasPrice": 1, "maxFeePerGas": 2, "maxPriorityFeePerGas": 1, "to": "0x3535353535353535353535353535353535353535"}`,
+		"小费大于上限":           `{"chainId": 1, "nonce": 0, "gas": 21000, "maxFeePerGas": 1, "maxPriorityFeePerGas": 2, "to": "0x3535353535353535353535353535353535353535"}`,
+		"传统交易带 accessList": `{"type": "legacy", "chainId": 1, "nonce": 0, "gas": 21000, "gasPrice": 1, "accessList": [], "to": "0x3535353535353535353535353535353535353535"}`,
+		"未知类型":             `{"type": "blob", "chainId": 1, "nonce": 0, "gas": 21000, "gasPrice": 1, "to": "0x3535353535353535353535353535353535353535"}`,
+	}
+	key := mustKey(t, eip155Key)
+	for name, document := range documents {
+		request, err := ParseEthTransaction([]byte(document))
+		if err == nil {
+			_, err = SignEthTransaction(key, request)
+		}
+		if !errors.Is(err, ErrInvalidTransaction) {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
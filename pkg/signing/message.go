@@ -0,0 +1,252 @@
+// Package signing 离线签名：消息签名与验证
+//
+// EVM 链使用 EIP-191 personal_sign，波场使用 TronWeb signMessageV2，
+// 比特币使用 BIP-137 签名消息（兼容 Electrum 对隔离见证地址的写法）。
+package signing
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"wallet_create_address/pkg/chain"
+	"wallet_create_address/pkg/wallet"
+)
+
+// 消息签名方案
+const (
+	SchemeEIP191 = "eip191" // EVM personal_sign
+	SchemeTron   = "tron"   // TronWeb signMessageV2
+	SchemeBIP137 = "bip137" // 比特币签名消息
+)
+
+// 消息前缀
+const (
+	ethereumMessagePrefix = "\x19Ethereum Signed Message:\n"
+	tronMessagePrefix     = "\x19TRON Signed Message:\n"
+	bitcoinMessagePrefix  = "Bitcoin Signed Message:\n"
+)
+
+// BIP-137 签名首字节的起始值，加上恢复 ID（0-3）
+const (
+	headerP2PKHUncompressed = 27
+	headerP2PKH             = 31
+	headerP2SHP2WPKH        = 35
+	headerP2WPKH            = 39
+	headerMax               = 42
+)
+
+var (
+	// ErrUnsupportedChain 不支持该链的消息签名
+	ErrUnsupportedChain = errors.New("不支持该链的消息签名")
+	// ErrUnsupportedAddress 地址类型不支持消息签名（如 P2WSH、P2TR 需要 BIP-322）
+	ErrUnsupportedAddress = errors.New("该地址类型不支持签名消息")
+	// ErrInvalidSignature 签名格式错误或无法恢复公钥
+	ErrInvalidSignature = errors.New("无效的签名")
+)
+
+// MessageOptions 消息签名选项
+type MessageOptions struct {
+	Chain       string // eth|bsc|polygon|tron|btc
+	AddressType string // 比特币地址类型: p2pkh|p2pkh-uncompressed|p2sh-p2wpkh|p2wpkh，默认 p2pkh
+	Network     string // 比特币网络: mainnet|testnet
+	Electrum    bool   // 比特币隔离见证地址使用 Electrum 写法（首字节与压缩 P2PKH 相同）
+}
+
+// SignedMessage 消息签名结果
+type SignedMessage struct {
+	Chain     string `json:"chain"`
+	Scheme    string `json:"scheme"`
+	Address   string `json:"address"`
+	Message   string `json:"message"`
+	Signature string `json:"signature"` // EVM/波场为 0x 开头的 65 字节十六进制（r||s||v），比特币为 Base64
+}
+
+// Verification 消息验证结果
+type Verification struct {
+	Chain     string `json:"chain"`
+	Scheme    string `json:"scheme"`
+	Address   string `json:"address"`
+	Recovered string `json:"recovered"` // 从签名恢复出的地址
+	Valid     bool   `json:"valid"`
+}
+
+// SignMessage 用私钥对消息签名，返回签名者地址与签名
+func SignMessage(privateKey *ecdsa.PrivateKey, message []byte, opts MessageOptions) (*SignedMessage, error) {
+	signed := &SignedMessage{Chain: opts.Chain, Message: string(message)}
+	switch opts.Chain {
+	case chain.ETH, chain.BSC, chain.Polygon:
+		sig, err := crypto.Sign(prefixedHash(ethereumMessagePrefix, message), privateKey)
+		if err != nil {
+			return nil, err
+		}
+		sig[64] += 27
+		signed.Scheme, signed.Address, signed.Signature = SchemeEIP191, chain.EthereumAddress(&privateKey.PublicKey), "0x"+hex.EncodeToString(sig)
+	case chain.Tron:
+		sig, err := crypto.Sign(prefixedHash(tronMessagePrefix, message), privateKey)
+		if err != nil {
+			return nil, err
+		}
+		sig[64] += 27
+		signed.Scheme, signed.Address, signed.Signature = SchemeTron, chain.TronAddress(&privateKey.PublicKey), "0x"+hex.EncodeToString(sig)
+	case chain.BTC:
+		addressType := opts.AddressType
+		if addressType == "" {
+			addressType = chain.BTCP2PKH
+		}
+		header, ok := map[string]byte{
+			chain.BTCP2PKHUncompressed: headerP2PKHUncompressed,
+			chain.BTCP2PKH:             headerP2PKH,
+			chain.BTCP2SHP2WPKH:        headerP2SHP2WPKH,
+			chain.BTCP2WPKH:            headerP2WPKH,
+		}[addressType]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedAddress, addressType)
+		}
+		if opts.Electrum && header > headerP2PKH {
+			header = headerP2PKH
+		}
+		params, err := wallet.NetworkParams(opts.Network)
+		if err != nil {
+			return nil, err
+		}
+		if signed.Address, err = chain.BitcoinAddressOfType(&privateKey.PublicKey, addressType, params); err != nil {
+			return nil, err
+		}
+
+		key, _ := btcec.PrivKeyFromBytes(crypto.FromECDSA(privateKey))
+		compressed := addressType != chain.BTCP2PKHUncompressed
+		sig := btcecdsa.SignCompact(key, bitcoinMessageHash(message), compressed)
+		// SignCompact 的首字节为 27/31 加恢复 ID，按地址类型改写
+		sig[0] = header + (sig[0]-headerP2PKHUncompressed)%4
+		signed.Scheme, signed.Signature = SchemeBIP137, base64.StdEncoding.EncodeToString(sig)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedChain, opts.Chain)
+	}
+	return signed, nil
+}
+
+// VerifyMessage 从签名恢复签名者地址并与 address 比较，链由地址格式识别
+// 签名格式错误时返回错误；签名有效但地址不一致时 Valid 为 false
+func VerifyMessage(address string, message []byte, signature string) (*Verification, error) {
+	info, err := chain.ValidateAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	result := &Verification{Chain: info.Chain, Address: info.Address}
+
+	switch info.Chain {
+	case chain.ETH, chain.Tron:
+		prefix := ethereumMessagePrefix
+		result.Scheme = SchemeEIP191
+		if info.Chain == chain.Tron {
+			prefix, result.Scheme = tronMessagePrefix, SchemeTron
+		}
+		publicKey, err := recoverRecoverable(prefixedHash(prefix, message), signature)
+		if err != nil {
+			return nil, err
+		}
+		if info.Chain == chain.Tron {
+			result.Recovered = chain.TronAddress(publicKey)
+			// 41 开头的十六进制地址与 Base58 地址对应同一哈希
+			result.Valid = chain.TronHexAddress(publicKey) == strings.ToLower(info.Address) || result.Recovered == info.Address
+		} else {
+			result.Recovered = chain.EthereumAddress(publicKey)
+			result.Valid = strings.EqualFold(result.Recovered, info.Address)
+		}
+	case chain.BTC:
+		result.Scheme = SchemeBIP137
+		if result.Recovered, err = recoverBitcoin(info, message, signature); err != nil {
+			return nil, err
+		}
+		result.Valid = result.Recovered == info.Address
+	}
+	return result, nil
+}
+
+// prefixedHash Keccak256(prefix + 十进制长度 + message)
+func prefixedHash(prefix string, message []byte) []byte {
+	return crypto.Keccak256([]byte(prefix+strconv.Itoa(len(message))), message)
+}
+
+// bitcoinMessageHash 双重 SHA256(varstr(前缀) + varstr(message))
+func bitcoinMessageHash(message []byte) []byte {
+	var buf bytes.Buffer
+	wire.WriteVarString(&buf, 0, bitcoinMessagePrefix)
+	wire.WriteVarBytes(&buf, 0, message)
+	return chainhash.DoubleHashB(buf.Bytes())
+}
+
+// recoverRecoverable 从 65 字节 r||s||v 签名（v 为 0/1 或 27/28）恢复公钥
+func recoverRecoverable(hash []byte, signature string) (*ecdsa.PublicKey, error) {
+	sig, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(signature), "0x"))
+	if err != nil || len(sig) != 65 {
+		return nil, fmt.Errorf("%w: 应为 65 字节十六进制", ErrInvalidSignature)
+	}
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+	publicKey, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	return publicKey, nil
+}
+
+// recoverBitcoin 从 BIP-137 签名恢复公钥，按首字节（或 Electrum 写法下按地址本身）的类型计算地址
+func recoverBitcoin(info *chain.AddressInfo, message []byte, signature string) (string, error) {
+	if info.Type != "p2pkh" && info.Type != "p2sh" && info.Type != "p2wpkh" {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedAddress, info.Type)
+	}
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(signature))
+	if err != nil || len(sig) != 65 {
+		return "", fmt.Errorf("%w: 应为 65 字节 Base64", ErrInvalidSignature)
+	}
+	header := sig[0]
+	if header < headerP2PKHUncompressed || header > headerMax {
+		return "", fmt.Errorf("%w: 首字节 %d 超出范围", ErrInvalidSignature, header)
+	}
+
+	addressType := map[byte]string{
+		headerP2PKHUncompressed: chain.BTCP2PKHUncompressed,
+		headerP2PKH:             chain.BTCP2PKH,
+		headerP2SHP2WPKH:        chain.BTCP2SHP2WPKH,
+		headerP2WPKH:            chain.BTCP2WPKH,
+	}[header-(header-headerP2PKHUncompressed)%4]
+	// Electrum 对隔离见证地址也使用压缩 P2PKH 的首字节，此时以地址本身的类型为准
+	if addressType == chain.BTCP2PKH {
+		switch info.Type {
+		case "p2sh":
+			addressType = chain.BTCP2SHP2WPKH
+		case "p2wpkh":
+			addressType = chain.BTCP2WPKH
+		}
+	}
+
+	// RecoverCompact 只接受 27-34 的首字节
+	compact := append([]byte{}, sig...)
+	compact[0] = headerP2PKHUncompressed + (header-headerP2PKHUncompressed)%4
+	if addressType != chain.BTCP2PKHUncompressed {
+		compact[0] += 4
+	}
+	publicKey, _, err := btcecdsa.RecoverCompact(compact, bitcoinMessageHash(message))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	params, err := wallet.NetworkParams(info.Network)
+	if err != nil {
+		return "", err
+	}
+	return chain.BitcoinAddressOfType(publicKey.ToECDSA(), addressType, params)
+}
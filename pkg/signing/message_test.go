@@ -0,0 +1,164 @@
+package signing
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+
+	"wallet_create_address/pkg/chain"
+	"wallet_create_address/pkg/wallet"
+)
+
+// mustKey 解析十六进制或 WIF 私钥
+func mustKey(t *testing.T, s string) *ecdsa.PrivateKey {
+	t.Helper()
+	key, _, err := wallet.ParsePrivateKey(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestSignMessageEIP191(t *testing.T) {
+	// web3.js 文档中 eth.accounts.sign 的示例
+	key := mustKey(t, "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	const (
+		address   = "0x2c7536E3605D9C16a7a3D7b1898e529396a65c23"
+		signature = "0xb91467e570a6466aa9e9876cbcd013baba02900b8979d43fe208a4a4f339f5fd6007e74cd82e037b800186422fc2da167c747ef045e5d18a5f5d4300f8e1a0291c"
+	)
+	signed, err := SignMessage(key, []byte("Some data"), MessageOptions{Chain: chain.ETH})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signed.Scheme != SchemeEIP191 || signed.Address != address || signed.Signature != signature {
+		t.Errorf("SignMessage = %+v", signed)
+	}
+	verification, err := VerifyMessage(strings.ToLower(address), []byte("Some data"), signature)
+	if err != nil || !verification.Valid || verification.Recovered != address {
+		t.Errorf("VerifyMessage = %+v, %v", verification, err)
+	}
+}
+
+func TestSignMessageBIP137(t *testing.T) {
+	// 双重 SHA256(varstr("Bitcoin Signed Message:\n") + varstr(消息))
+	want := chainhash.DoubleHashB([]byte("\x18Bitcoin Signed Message:\n\x10vires is numeris"))
+	if got := bitcoinMessageHash([]byte("vires is numeris")); !bytes.Equal(got, want) {
+		t.Errorf("bitcoinMessageHash = %x", got)
+	}
+
+	key := mustKey(t, "L4rK1yDtCWekvXuE6oXD9jCYfFNV2cWRpVuPLBcCU2z8TrisoyY1")
+	message := []byte("vires is numeris")
+	// 各地址类型的签名只有首字节不同：27/31/35/39 加恢复 ID
+	tests := []struct {
+		opts    MessageOptions
+		address string
+		header  byte
+	}{
+		{MessageOptions{Chain: chain.BTC}, "1F3sAm6ZtwLAUnj7d38pGFxtP3RVEvtsbV", headerP2PKH},
+		{MessageOptions{Chain: chain.BTC, AddressType: chain.BTCP2SHP2WPKH}, "3DnW8JGpPViEZdpqat8qky1zc26EKbXnmM", headerP2SHP2WPKH},
+		{MessageOptions{Chain: chain.BTC, AddressType: chain.BTCP2WPKH}, "bc1qngw83fg8dz0k749cg7k3emc7v98wy0c74dlrkd", headerP2WPKH},
+		// Electrum 写法：隔离见证地址使用压缩 P2PKH 的首字节
+		{MessageOptions{Chain: chain.BTC, AddressType: chain.BTCP2WPKH, Electrum: true}, "bc1qngw83fg8dz0k749cg7k3emc7v98wy0c74dlrkd", headerP2PKH},
+	}
+	var body []byte
+	for _, tt := range tests {
+		signed, err := SignMessage(key, message, tt.opts)
+		if err != nil {
+			t.Errorf("%+v: %v", tt.opts, err)
+			continue
+		}
+		sig, _ := base64.StdEncoding.DecodeString(signed.Signature)
+		if signed.Scheme != SchemeBIP137 || signed.Address != tt.address || len(sig) != 65 || sig[0]-(sig[0]-headerP2PKHUncompressed)%4 != tt.header {
+			t.Errorf("%+v: %+v", tt.opts, signed)
+			continue
+		}
+		if body == nil {
+			body = sig[1:]
+		} else if !bytes.Equal(body, sig[1:]) {
+			t.Errorf("%+v: r||s 与 P2PKH 签名不同", tt.opts)
+		}
+		verification, err := VerifyMessage(tt.address, message, signed.Signature)
+		if err != nil || !verification.Valid || verification.Recovered != tt.address {
+			t.Errorf("%+v: 验证 %+v, %v", tt.opts, verification, err)
+		}
+	}
+}
+
+func TestSignMessageRoundTrip(t *testing.T) {
+	key := mustKey(t, "0000000000000000000000000000000000000000000000000000000000000001")
+	message := []byte("hello")
+	for _, opts := range []MessageOptions{
+		{Chain: chain.BSC},
+		{Chain: chain.Tron},
+		{Chain: chain.BTC, AddressType: chain.BTCP2PKHUncompressed},
+		{Chain: chain.BTC, AddressType: chain.BTCP2SHP2WPKH, Electrum: true},
+		{Chain: chain.BTC, AddressType: chain.BTCP2WPKH, Network: wallet.TestNet},
+	} {
+		signed, err := SignMessage(key, message, opts)
+		if err != nil {
+			t.Errorf("%+v: %v", opts, err)
+			continue
+		}
+		verification, err := VerifyMessage(signed.Address, message, signed.Signature)
+		if err != nil || !verification.Valid {
+			t.Errorf("%+v: 验证 %+v, %v", opts, verification, err)
+		}
+		// 消息被篡改后恢复出其他地址
+		if verification, err := VerifyMessage(signed.Address, []byte("hello!"), signed.Signature); err == nil && verification.Valid {
+			t.Errorf("%+v: 篡改后的消息通过验证", opts)
+		}
+	}
+}
+
+func TestVerifyMessageTron(t *testing.T) {
+	key := mustKey(t, "0000000000000000000000000000000000000000000000000000000000000001")
+	signed, err := SignMessage(key, []byte("hello"), MessageOptions{Chain: chain.Tron})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signed.Scheme != SchemeTron || signed.Address != "TMVQGm1qAQYVdetCeGRRkTWYYrLXuHK2HC" {
+		t.Errorf("SignMessage = %+v", signed)
+	}
+	// 41 开头的十六进制地址同样可以验证
+	verification, err := VerifyMessage("417e5f4552091a69125d5dfcb7b8c2659029395bdf", []byte("hello"), signed.Signature)
+	if err != nil || !verification.Valid || verification.Recovered != signed.Address {
+		t.Errorf("十六进制地址: %+v, %v", verification, err)
+	}
+	// 波场前缀与 EIP-191 不同，同一签名对 ETH 地址无效
+	verification, err = VerifyMessage("0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf", []byte("hello"), signed.Signature)
+	if err != nil || verification.Valid {
+		t.Errorf("ETH 地址: %+v, %v", verification, err)
+	}
+}
+
+func TestMessageErrors(t *testing.T) {
+	key := mustKey(t, "0000000000000000000000000000000000000000000000000000000000000001")
+	if _, err := SignMessage(key, nil, MessageOptions{Chain: "doge"}); !errors.Is(err, ErrUnsupportedChain) {
+		t.Errorf("未知链: %v", err)
+	}
+	if _, err := SignMessage(key, nil, MessageOptions{Chain: chain.BTC, AddressType: chain.BTCP2TR}); !errors.Is(err, ErrUnsupportedAddress) {
+		t.Errorf("P2TR: %v", err)
+	}
+
+	tests := []struct {
+		address, signature string
+		want               error
+	}{
+		{"0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf", "0x1234", ErrInvalidSignature},
+		{"0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf", "0x" + strings.Repeat("00", 65), ErrInvalidSignature},
+		{"1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", "not base64", ErrInvalidSignature},
+		{"1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", "K" + strings.Repeat("A", 86) + "=", ErrInvalidSignature},
+		{"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0", "H" + strings.Repeat("A", 86) + "=", ErrUnsupportedAddress},
+		{"0x123", "0x", chain.ErrUnknownFormat},
+	}
+	for _, tt := range tests {
+		if _, err := VerifyMessage(tt.address, []byte("hello"), tt.signature); !errors.Is(err, tt.want) {
+			t.Errorf("VerifyMessage(%s, %s): %v, 期望 %v", tt.address, tt.signature, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package signing
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// 波场交易只用到 protobuf 的两种线格式，手写编解码以免引入生成代码
+const (
+	wireVarint = 0
+	wireBytes  = 2
+)
+
+// errProtobuf raw_data 不是有效的 protobuf 编码
+var errProtobuf = errors.New("protobuf 编码错误")
+
+// protoField 解码出的一个字段，varint 存在 num，长度前缀字段存在 bytes
+type protoField struct {
+	number int
+	wire   int
+	num    uint64
+	bytes  []byte
+}
+
+// appendVarintField 追加 varint 字段，零值按 proto3 省略
+func appendVarintField(b []byte, number int, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = binary.AppendUvarint(b, uint64(number)<<3|wireVarint)
+	return binary.AppendUvarint(b, v)
+}
+
+// appendBytesField 追加长度前缀字段，空值按 proto3 省略
+func appendBytesField(b []byte, number int, v []byte) []byte {
+	if len(v) == 0 {
+		return b
+	}
+	b = binary.AppendUvarint(b, uint64(number)<<3|wireBytes)
+	b = binary.AppendUvarint(b, uint64(len(v)))
+	return append(b, v...)
+}
+
+// parseProto 解析一条消息的全部字段；出现其他线格式（fixed32/fixed64）时报错
+func parseProto(b []byte) ([]protoField, error) {
+	var fields []protoField
+	for len(b) > 0 {
+		tag, n := binary.Uvarint(b)
+		if n <= 0 {
+			return nil, errProtobuf
+		}
+		b = b[n:]
+		field := protoField{number: int(tag >> 3), wire: int(tag & 7)}
+		if field.number == 0 {
+			return nil, errProtobuf
+		}
+
+		switch field.wire {
+		case wireVarint:
+			if field.num, n = binary.Uvarint(b); n <= 0 {
+				return nil, errProtobuf
+			}
+			b = b[n:]
+		case wireBytes:
+			size, n := binary.Uvarint(b)
+			if n <= 0 || size > uint64(len(b)-n) {
+				return nil, errProtobuf
+			}
+			field.bytes = b[n : n+int(size)]
+			b = b[n+int(size):]
+		default:
+			return nil, errProtobuf
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
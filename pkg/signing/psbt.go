@@ -0,0 +1,348 @@
+package signing
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip32"
+
+	"wallet_create_address/pkg/chain"
+	"wallet_create_address/pkg/wallet"
+)
+
+// psbtMagic BIP174 二进制 PSBT 的开头
+var psbtMagic = []byte{0x70, 0x73, 0x62, 0x74, 0xff}
+
+var (
+	// ErrInvalidPSBT 无法解析为 BIP174 PSBT（二进制、Base64 或十六进制）
+	ErrInvalidPSBT = errors.New("无效的 PSBT")
+	// ErrMissingUTXO 输入缺少被花费输出的信息，无法计算签名哈希
+	ErrMissingUTXO = errors.New("输入缺少 UTXO 信息")
+)
+
+// PSBT 输入的签名结果
+const (
+	PSBTInputSigned    = "signed"    // 本次已签名
+	PSBTInputSkipped   = "skipped"   // 没有匹配的密钥
+	PSBTInputFinalized = "finalized" // 输入在签名前已完成
+)
+
+// PSBTSigner PSBT 签名密钥：助记词主密钥（按输入的 BIP32 派生信息匹配）或单个私钥（按脚本匹配）
+type PSBTSigner struct {
+	master      *bip32.Key
+	fingerprint uint32 // 与 psbt.Bip32Derivation.MasterKeyFingerprint 相同的小端表示
+	key         *btcec.PrivateKey
+}
+
+// NewPSBTSignerFromMaster 用主密钥签名：只签 BIP32 派生信息中主密钥指纹相同、且派生出的公钥一致的输入
+func NewPSBTSignerFromMaster(master *bip32.Key) *PSBTSigner {
+	fingerprint, _ := hex.DecodeString(wallet.MasterFingerprint(master))
+	return &PSBTSigner{master: master, fingerprint: binary.LittleEndian.Uint32(fingerprint)}
+}
+
+// NewPSBTSignerFromKey 用单个私钥签名：签所有输出脚本属于该私钥的输入
+func NewPSBTSignerFromKey(privateKey *ecdsa.PrivateKey) *PSBTSigner {
+	key, _ := btcec.PrivKeyFromBytes(crypto.FromECDSA(privateKey))
+	return &PSBTSigner{key: key}
+}
+
+// PSBTInputResult 单个输入的处理结果
+type PSBTInputResult struct {
+	Index  int    `json:"index"`
+	Type   string `json:"type,omitempty"` // 被花费输出的类型: p2pkh|p2sh-p2wpkh|p2wpkh|p2tr
+	Status string `json:"status"`
+	Path   string `json:"path,omitempty"` // 签名密钥的派生路径（主密钥签名时）
+	Reason string `json:"reason,omitempty"`
+}
+
+// PSBTSignResult PSBT 签名结果
+type PSBTSignResult struct {
+	PSBT        string            `json:"psbt"` // 更新后的 PSBT（Base64）
+	Inputs      []PSBTInputResult `json:"inputs"`
+	Signed      int               `json:"signed"`
+	Complete    bool              `json:"complete"`              // 全部输入已完成
+	Transaction string            `json:"transaction,omitempty"` // 全部输入完成时提取出的可广播交易（十六进制）
+}
+
+// ParsePSBT 解析二进制、Base64 或十六进制编码的 PSBT
+func ParsePSBT(data []byte) (*psbt.Packet, error) {
+	data = bytes.TrimSpace(data)
+	if !bytes.HasPrefix(data, psbtMagic) {
+		if decoded, err := hex.DecodeString(string(data)); err == nil {
+			data = decoded
+		} else if decoded, err := base64.StdEncoding.DecodeString(string(data)); err == nil {
+			data = decoded
+		}
+	}
+	packet, err := psbt.NewFromRawBytes(bytes.NewReader(data), false)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPSBT, err)
+	}
+	return packet, nil
+}
+
+// SignPSBT 为能匹配到密钥的 P2PKH、P2SH-P2WPKH、P2WPKH 和 P2TR（密钥路径）输入添加签名
+// finalize 为 true 时完成已签名的输入，全部输入完成后提取交易
+func SignPSBT(packet *psbt.Packet, signer *PSBTSigner, finalize bool) (*PSBTSignResult, error) {
+	updater, err := psbt.NewUpdater(packet)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPSBT, err)
+	}
+	fetcher, complete := prevOutputFetcher(packet)
+	sigHashes := txscript.NewTxSigHashes(packet.UnsignedTx, fetcher)
+
+	result := &PSBTSignResult{}
+	for i := range packet.Inputs {
+		input := PSBTInputResult{Index: i}
+		if err := signer.signInput(updater, i, complete, sigHashes, &input); err != nil {
+			return nil, fmt.Errorf("输入 %d: %w", i, err)
+		}
+		if input.Status == PSBTInputSigned {
+			result.Signed++
+			if finalize {
+				if _, err := psbt.MaybeFinalize(packet, i); err != nil {
+					return nil, fmt.Errorf("输入 %d: 完成失败: %w", i, err)
+				}
+			}
+		}
+		result.Inputs = append(result.Inputs, input)
+	}
+
+	result.Complete = true
+	for i := range packet.Inputs {
+		if len(packet.Inputs[i].FinalScriptSig) == 0 && len(packet.Inputs[i].FinalScriptWitness) == 0 {
+			result.Complete = false
+		}
+	}
+	if result.Complete {
+		tx, err := psbt.Extract(packet)
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if err := tx.Serialize(&buf); err != nil {
+			return nil, err
+		}
+		result.Transaction = hex.EncodeToString(buf.Bytes())
+	}
+
+	if result.PSBT, err = packet.B64Encode(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// prevOutputFetcher 收集各输入被花费的输出；缺少信息的输入记为空输出，complete 为 false
+func prevOutputFetcher(packet *psbt.Packet) (*txscript.MultiPrevOutFetcher, bool) {
+	fetcher := txscript.NewMultiPrevOutFetcher(nil)
+	complete := true
+	for i, txIn := range packet.UnsignedTx.TxIn {
+		prevOut, err := inputUTXO(packet, i)
+		if err != nil {
+			prevOut, complete = &wire.TxOut{}, false
+		}
+		fetcher.AddPrevOut(txIn.PreviousOutPoint, prevOut)
+	}
+	return fetcher, complete
+}
+
+// inputUTXO 输入被花费的输出，完整前序交易须与输入引用的哈希一致
+func inputUTXO(packet *psbt.Packet, i int) (*wire.TxOut, error) {
+	input := packet.Inputs[i]
+	outpoint := packet.UnsignedTx.TxIn[i].PreviousOutPoint
+	if input.NonWitnessUtxo != nil {
+		if input.NonWitnessUtxo.TxHash() != outpoint.Hash || int(outpoint.Index) >= len(input.NonWitnessUtxo.TxOut) {
+			return nil, fmt.Errorf("%w: 前序交易与输入引用不一致", ErrInvalidPSBT)
+		}
+		return input.NonWitnessUtxo.TxOut[outpoint.Index], nil
+	}
+	if input.WitnessUtxo != nil {
+		return input.WitnessUtxo, nil
+	}
+	return nil, ErrMissingUTXO
+}
+
+// candidate 可能属于某个输入的私钥
+type candidate struct {
+	key  *btcec.PrivateKey
+	path string
+}
+
+// candidates 主密钥模式下按输入的派生信息派生私钥，单私钥模式下直接返回该私钥
+func (s *PSBTSigner) candidates(input *psbt.PInput) []candidate {
+	if s.key != nil {
+		return []candidate{{key: s.key}}
+	}
+
+	var result []candidate
+	derive := func(fingerprint uint32, path []uint32, matches func(*btcec.PublicKey) bool) {
+		if fingerprint != s.fingerprint {
+			return
+		}
+		pathString := wallet.FormatPath(path)
+		child, err := wallet.DeriveKey(s.master, pathString)
+		if err != nil {
+			return
+		}
+		key, pub := btcec.PrivKeyFromBytes(child.Key)
+		if matches(pub) {
+			result = append(result, candidate{key: key, path: pathString})
+		}
+	}
+	for _, d := range input.Bip32Derivation {
+		derive(d.MasterKeyFingerprint, d.Bip32Path, func(pub *btcec.PublicKey) bool {
+			return bytes.Equal(pub.SerializeCompressed(), d.PubKey) || bytes.Equal(pub.SerializeUncompressed(), d.PubKey)
+		})
+	}
+	for _, d := range input.TaprootBip32Derivation {
+		// 只处理密钥路径（不属于任何脚本叶子）的派生
+		if len(d.LeafHashes) > 0 {
+			continue
+		}
+		derive(d.MasterKeyFingerprint, d.Bip32Path, func(pub *btcec.PublicKey) bool {
+			return bytes.Equal(schnorr.SerializePubKey(pub), d.XOnlyPubKey)
+		})
+	}
+	return result
+}
+
+// signInput 按被花费输出的脚本类型为第 i 个输入签名，结果写入 result
+// complete 表示所有输入的 UTXO 都已知（P2TR 签名的前提）
+func (s *PSBTSigner) signInput(updater *psbt.Updater, i int, complete bool, sigHashes *txscript.TxSigHashes,
+	result *PSBTInputResult) error {
+
+	packet := updater.Upsbt
+	input := &packet.Inputs[i]
+	if len(input.FinalScriptSig) > 0 || len(input.FinalScriptWitness) > 0 {
+		result.Status = PSBTInputFinalized
+		return nil
+	}
+	result.Status = PSBTInputSkipped
+	prevOut, err := inputUTXO(packet, i)
+	if errors.Is(err, ErrMissingUTXO) {
+		result.Reason = err.Error()
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	pkScript := prevOut.PkScript
+	hashType := input.SighashType
+	switch txscript.GetScriptClass(pkScript) {
+	case txscript.PubKeyHashTy:
+		result.Type = chain.BTCP2PKH
+		if input.NonWitnessUtxo == nil {
+			result.Reason = "P2PKH 输入需要完整的前序交易"
+			return nil
+		}
+		if hashType == 0 {
+			hashType = txscript.SigHashAll
+		}
+		for _, c := range s.candidates(input) {
+			for _, pub := range [][]byte{c.key.PubKey().SerializeCompressed(), c.key.PubKey().SerializeUncompressed()} {
+				if !bytes.Equal(pkScript[3:23], btcutil.Hash160(pub)) || hasPartialSig(input, pub) {
+					continue
+				}
+				sig, err := txscript.RawTxInSignature(packet.UnsignedTx, i, pkScript, hashType, c.key)
+				if err != nil {
+					return err
+				}
+				if _, err := updater.Sign(i, sig, pub, nil, nil); err != nil {
+					return err
+				}
+				result.Status, result.Path = PSBTInputSigned, c.path
+				return nil
+			}
+		}
+
+	case txscript.WitnessV0PubKeyHashTy, txscript.ScriptHashTy:
+		nested := txscript.GetScriptClass(pkScript) == txscript.ScriptHashTy
+		result.Type = chain.BTCP2WPKH
+		if nested {
+			result.Type = chain.BTCP2SHP2WPKH
+		}
+		if hashType == 0 {
+			hashType = txscript.SigHashAll
+		}
+		for _, c := range s.candidates(input) {
+			pub := c.key.PubKey().SerializeCompressed()
+			witnessProgram := append([]byte{txscript.OP_0, txscript.OP_DATA_20}, btcutil.Hash160(pub)...)
+			var redeemScript []byte
+			if nested {
+				// P2SH 只处理嵌套的 P2WPKH：脚本哈希须等于 HASH160(0014<公钥哈希>)
+				if !bytes.Equal(pkScript[2:22], btcutil.Hash160(witnessProgram)) {
+					continue
+				}
+				redeemScript = witnessProgram
+			} else if !bytes.Equal(pkScript, witnessProgram) {
+				continue
+			}
+			if hasPartialSig(input, pub) {
+				continue
+			}
+			sig, err := txscript.RawTxInWitnessSignature(packet.UnsignedTx, sigHashes, i, prevOut.Value, witnessProgram, hashType, c.key)
+			if err != nil {
+				return err
+			}
+			if _, err := updater.Sign(i, sig, pub, redeemScript, nil); err != nil {
+				return err
+			}
+			result.Status, result.Path = PSBTInputSigned, c.path
+			return nil
+		}
+
+	case txscript.WitnessV1TaprootTy:
+		result.Type = chain.BTCP2TR
+		if len(input.TaprootKeySpendSig) > 0 {
+			return nil
+		}
+		for _, c := range s.candidates(input) {
+			outputKey := txscript.ComputeTaprootOutputKey(c.key.PubKey(), input.TaprootMerkleRoot)
+			if !bytes.Equal(pkScript[2:], schnorr.SerializePubKey(outputKey)) {
+				continue
+			}
+			// BIP341 的签名哈希包含所有输入的金额与脚本
+			if !complete {
+				return fmt.Errorf("%w: P2TR 签名需要所有输入的 UTXO", ErrMissingUTXO)
+			}
+			sig, err := txscript.RawTxInTaprootSignature(packet.UnsignedTx, sigHashes, i, prevOut.Value, pkScript,
+				input.TaprootMerkleRoot, hashType, c.key)
+			if err != nil {
+				return err
+			}
+			input.TaprootKeySpendSig = sig
+			result.Status, result.Path = PSBTInputSigned, c.path
+			return nil
+		}
+
+	default:
+		result.Reason = "不支持的脚本类型"
+		return nil
+	}
+
+	if result.Reason == "" {
+		result.Reason = "没有匹配的密钥"
+	}
+	return nil
+}
+
+// hasPartialSig 输入是否已有该公钥的签名
+func hasPartialSig(input *psbt.PInput, pub []byte) bool {
+	return slices.ContainsFunc(input.PartialSigs, func(sig *psbt.PartialSig) bool {
+		return bytes.Equal(sig.PubKey, pub)
+	})
+}
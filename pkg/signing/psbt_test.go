@@ -0,0 +1,261 @@
+package signing
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+
+	"wallet_create_address/pkg/chain"
+	"wallet_create_address/pkg/wallet"
+)
+
+const psbtMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+// addressScript 地址对应的输出脚本
+func addressScript(t *testing.T, address string) []byte {
+	t.Helper()
+	decoded, err := btcutil.DecodeAddress(address, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	script, err := txscript.PayToAddrScript(decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return script
+}
+
+// newTestPSBT 构造花费 prevScripts 各输出的 PSBT：先生成一笔向这些脚本各支付 10000 聪的前序交易，
+// 再生成依次花费它们的交易；第一个输入附带完整前序交易，其余附带 WitnessUtxo
+func newTestPSBT(t *testing.T, prevScripts ...[]byte) *psbt.Packet {
+	t.Helper()
+	prev := wire.NewMsgTx(2)
+	prev.AddTxIn(wire.NewTxIn(&wire.OutPoint{Hash: chainhash.Hash{1}}, nil, nil))
+	outpoints := make([]*wire.OutPoint, len(prevScripts))
+	sequences := make([]uint32, len(prevScripts))
+	for i, script := range prevScripts {
+		prev.AddTxOut(wire.NewTxOut(10000, script))
+		sequences[i] = wire.MaxTxInSequenceNum
+	}
+	for i := range prevScripts {
+		outpoints[i] = wire.NewOutPoint(&chainhash.Hash{}, uint32(i))
+		outpoints[i].Hash = prev.TxHash()
+	}
+	output := wire.NewTxOut(int64(len(prevScripts))*10000-1000, addressScript(t, "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"))
+	packet, err := psbt.New(outpoints, []*wire.TxOut{output}, 2, 0, sequences)
+	if err != nil {
+		t.Fatal(err)
+	}
+	packet.Inputs[0].NonWitnessUtxo = prev
+	for i := 1; i < len(prevScripts); i++ {
+		packet.Inputs[i].WitnessUtxo = prev.TxOut[i]
+	}
+	return packet
+}
+
+// verifyTransaction 用脚本引擎校验已提取交易的每个输入
+func verifyTransaction(t *testing.T, txHex string, packet *psbt.Packet) {
+	t.Helper()
+	raw, err := hex.DecodeString(txHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tx wire.MsgTx
+	if err := tx.Deserialize(bytes.NewReader(raw)); err != nil {
+		t.Fatal(err)
+	}
+	fetcher, _ := prevOutputFetcher(packet)
+	sigHashes := txscript.NewTxSigHashes(&tx, fetcher)
+	for i, txIn := range tx.TxIn {
+		prevOut := fetcher.FetchPrevOutput(txIn.PreviousOutPoint)
+		engine, err := txscript.NewEngine(prevOut.PkScript, &tx, i, txscript.StandardVerifyFlags, nil, sigHashes, prevOut.Value, fetcher)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := engine.Execute(); err != nil {
+			t.Errorf("输入 %d 校验失败: %v", i, err)
+		}
+	}
+}
+
+func TestSignPSBTKeyAllTypes(t *testing.T) {
+	key := mustKey(t, "0000000000000000000000000000000000000000000000000000000000000001")
+	packet := newTestPSBT(t,
+		addressScript(t, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH"),
+		addressScript(t, "3JvL6Ymt8MVWiCNHC7oWU6nLeHNJKLZGLN"),
+		addressScript(t, "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"),
+		addressScript(t, "bc1pmfr3p9j00pfxjh0zmgp99y8zftmd3s5pmedqhyptwy6lm87hf5sspknck9"),
+	)
+	encoded, err := packet.B64Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 不完成时只添加部分签名，输出的 PSBT 可以再次解析
+	result, err := SignPSBT(packet, NewPSBTSignerFromKey(key), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Signed != 4 || result.Complete || result.Transaction != "" {
+		t.Errorf("未完成: %+v", result)
+	}
+	for i, wantType := range []string{chain.BTCP2PKH, chain.BTCP2SHP2WPKH, chain.BTCP2WPKH, chain.BTCP2TR} {
+		if input := result.Inputs[i]; input.Type != wantType || input.Status != PSBTInputSigned || input.Path != "" {
+			t.Errorf("输入 %d = %+v", i, input)
+		}
+	}
+	if _, err := ParsePSBT([]byte(result.PSBT)); err != nil {
+		t.Errorf("解析签名后的 PSBT: %v", err)
+	}
+
+	// 完成后提取出的交易通过脚本校验；再次签名时各输入均为已完成
+	packet, err = ParsePSBT([]byte(encoded))
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err = SignPSBT(packet, NewPSBTSignerFromKey(key), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Signed != 4 || !result.Complete || result.Transaction == "" {
+		t.Fatalf("完成: %+v", result)
+	}
+	verifyTransaction(t, result.Transaction, packet)
+
+	result, err = SignPSBT(packet, NewPSBTSignerFromKey(key), true)
+	if err != nil || result.Signed != 0 || !result.Complete || result.Inputs[3].Status != PSBTInputFinalized {
+		t.Errorf("重复签名: %+v, %v", result, err)
+	}
+}
+
+func TestSignPSBTMaster(t *testing.T) {
+	master, err := wallet.MasterKeyFromMnemonic(psbtMnemonic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fingerprint := binary.LittleEndian.Uint32([]byte{0x73, 0xc5, 0xda, 0x0a}) // 主密钥指纹 73c5da0a
+	derive := func(path string) ([]uint32, *btcec.PublicKey) {
+		indexes, err := wallet.ParsePath(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		child, err := wallet.DeriveKey(master, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, pub := btcec.PrivKeyFromBytes(child.Key)
+		return indexes, pub
+	}
+	wpkhPath, wpkhPub := derive("m/84'/0'/0'/0/0")
+	trPath, trPub := derive("m/86'/0'/0'/0/0")
+	trScript, err := txscript.PayToTaprootScript(txscript.ComputeTaprootKeyNoScript(trPub))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	packet := newTestPSBT(t,
+		addressScript(t, "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu"),
+		trScript,
+		addressScript(t, "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"),
+	)
+	packet.Inputs[0].Bip32Derivation = []*psbt.Bip32Derivation{{PubKey: wpkhPub.SerializeCompressed(),
+		MasterKeyFingerprint: fingerprint, Bip32Path: wpkhPath}}
+	packet.Inputs[1].TaprootBip32Derivation = []*psbt.TaprootBip32Derivation{{XOnlyPubKey: schnorr.SerializePubKey(trPub),
+		MasterKeyFingerprint: fingerprint, Bip32Path: trPath}}
+	// 私钥 1 的输入：派生信息的指纹属于其他钱包
+	packet.Inputs[2].Bip32Derivation = []*psbt.Bip32Derivation{{PubKey: wpkhPub.SerializeCompressed(),
+		MasterKeyFingerprint: fingerprint + 1, Bip32Path: wpkhPath}}
+	encoded, err := packet.B64Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := SignPSBT(packet, NewPSBTSignerFromMaster(master), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []PSBTInputResult{
+		{Index: 0, Type: chain.BTCP2WPKH, Status: PSBTInputSigned, Path: "m/84'/0'/0'/0/0"},
+		{Index: 1, Type: chain.BTCP2TR, Status: PSBTInputSigned, Path: "m/86'/0'/0'/0/0"},
+		{Index: 2, Type: chain.BTCP2WPKH, Status: PSBTInputSkipped, Reason: "没有匹配的密钥"},
+	}
+	if result.Signed != 2 || result.Complete || len(result.Inputs) != len(want) {
+		t.Fatalf("主密钥: %+v", result)
+	}
+	for i := range want {
+		if result.Inputs[i] != want[i] {
+			t.Errorf("输入 %d = %+v, 期望 %+v", i, result.Inputs[i], want[i])
+		}
+	}
+
+	// 单私钥模式按脚本匹配，不需要派生信息：m/84'/0'/0'/0/0 的私钥只能签第一个输入
+	child, _ := wallet.DeriveKey(master, "m/84'/0'/0'/0/0")
+	key := mustKey(t, hex.EncodeToString(child.Key))
+	packet, _ = ParsePSBT([]byte(encoded))
+	result, err = SignPSBT(packet, NewPSBTSignerFromKey(key), false)
+	if err != nil || result.Signed != 1 || result.Inputs[0].Status != PSBTInputSigned || result.Inputs[0].Path != "" {
+		t.Errorf("单私钥: %+v, %v", result, err)
+	}
+}
+
+func TestSignPSBTMissingUTXO(t *testing.T) {
+	key := mustKey(t, "0000000000000000000000000000000000000000000000000000000000000001")
+	packet := newTestPSBT(t,
+		addressScript(t, "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"),
+		addressScript(t, "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"),
+	)
+	packet.Inputs[0].NonWitnessUtxo = nil
+
+	// 缺少 UTXO 的输入被跳过，其余输入照常签名
+	result, err := SignPSBT(packet, NewPSBTSignerFromKey(key), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Signed != 1 || result.Complete || result.Inputs[0].Status != PSBTInputSkipped ||
+		result.Inputs[0].Reason != ErrMissingUTXO.Error() || result.Inputs[1].Status != PSBTInputSigned {
+		t.Errorf("缺少 UTXO: %+v", result)
+	}
+
+	// P2TR 的签名哈希覆盖所有输入的 UTXO，任一缺失时无法签名
+	packet = newTestPSBT(t,
+		addressScript(t, "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"),
+		addressScript(t, "bc1pmfr3p9j00pfxjh0zmgp99y8zftmd3s5pmedqhyptwy6lm87hf5sspknck9"),
+	)
+	packet.Inputs[0].NonWitnessUtxo = nil
+	if _, err := SignPSBT(packet, NewPSBTSignerFromKey(key), false); !errors.Is(err, ErrMissingUTXO) {
+		t.Errorf("P2TR 缺少其他输入的 UTXO: %v", err)
+	}
+}
+
+func TestParsePSBT(t *testing.T) {
+	packet := newTestPSBT(t, addressScript(t, "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"))
+	var buf bytes.Buffer
+	if err := packet.Serialize(&buf); err != nil {
+		t.Fatal(err)
+	}
+	encoded, _ := packet.B64Encode()
+	for name, data := range map[string][]byte{
+		"二进制":    buf.Bytes(),
+		"Base64": []byte(encoded + "\n"),
+		"十六进制":   []byte(hex.EncodeToString(buf.Bytes())),
+	} {
+		parsed, err := ParsePSBT(data)
+		if err != nil || parsed.UnsignedTx.TxHash() != packet.UnsignedTx.TxHash() {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+	if _, err := ParsePSBT([]byte("cHNidP8=")); !errors.Is(err, ErrInvalidPSBT) {
+		t.Errorf("无效 PSBT: %v", err)
+	}
+}
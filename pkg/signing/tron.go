@@ -0,0 +1,426 @@
+package signing
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"wallet_create_address/pkg/chain"
+)
+
+// 支持的波场合约类型（protocol.Transaction.Contract.ContractType）
+const (
+	TronTransferContract       = "TransferContract"
+	TronTriggerSmartContract   = "TriggerSmartContract"
+	tronTransferContractType   = 1
+	tronTriggerSmartContractID = 31
+	tronTypeURLPrefix          = "type.googleapis.com/protocol."
+)
+
+// trc20TransferSelector transfer(address,uint256) 的函数选择器
+var trc20TransferSelector = []byte{0xa9, 0x05, 0x9c, 0xbb}
+
+var (
+	// ErrInvalidTronTransaction 波场交易格式错误
+	ErrInvalidTronTransaction = errors.New("无效的波场交易")
+	// ErrUnsupportedContract 合约类型不在支持范围内（仅 TRX 转账与 TriggerSmartContract）
+	ErrUnsupportedContract = errors.New("不支持的波场合约类型")
+	// ErrOwnerMismatch 交易的发起地址不是签名私钥对应的地址
+	ErrOwnerMismatch = errors.New("交易发起地址与私钥不匹配")
+	// ErrRawDataMismatch raw_data JSON 与实际签名的 raw_data_hex 内容不一致
+	ErrRawDataMismatch = errors.New("raw_data 与 raw_data_hex 不一致")
+)
+
+// TronTransaction 波场交易，字段与 TronGrid /wallet/createtransaction 返回的 JSON 一致
+type TronTransaction struct {
+	Visible    bool         `json:"visible"` // 为 true 时地址为 T 开头的 Base58，否则为 41 开头的十六进制
+	TxID       string       `json:"txID"`
+	RawData    *TronRawData `json:"raw_data,omitempty"`
+	RawDataHex string       `json:"raw_data_hex"`
+	Signature  []string     `json:"signature,omitempty"`
+}
+
+// TronRawData protocol.Transaction.raw
+type TronRawData struct {
+	RefBlockBytes string         `json:"ref_block_bytes"`
+	RefBlockNum   int64          `json:"ref_block_num,omitempty"`
+	RefBlockHash  string         `json:"ref_block_hash"`
+	Expiration    int64          `json:"expiration"`
+	Data          string         `json:"data,omitempty"` // 备注（十六进制）
+	Contract      []TronContract `json:"contract"`
+	Timestamp     int64          `json:"timestamp,omitempty"`
+	FeeLimit      int64          `json:"fee_limit,omitempty"`
+}
+
+// TronContract protocol.Transaction.Contract
+type TronContract struct {
+	Type         string        `json:"type"`
+	Parameter    TronParameter `json:"parameter"`
+	PermissionID int64         `json:"Permission_id,omitempty"`
+}
+
+// TronParameter 合约参数（google.protobuf.Any）
+type TronParameter struct {
+	Value   TronContractValue `json:"value"`
+	TypeURL string            `json:"type_url"`
+}
+
+// TronContractValue TransferContract 与 TriggerSmartContract 的字段
+type TronContractValue struct {
+	OwnerAddress    string `json:"owner_address"`
+	ToAddress       string `json:"to_address,omitempty"`
+	Amount          int64  `json:"amount,omitempty"`
+	ContractAddress string `json:"contract_address,omitempty"`
+	CallValue       int64  `json:"call_value,omitempty"`
+	Data            string `json:"data,omitempty"`
+}
+
+// TronTransferSummary 交易内容摘要，供离线签名前人工核对
+type TronTransferSummary struct {
+	Type     string `json:"type"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Contract string `json:"contract,omitempty"` // TRC20 合约地址
+	Amount   string `json:"amount"`             // TRX 转账单位为 sun，TRC20 为代币最小单位
+}
+
+// ParseTronTransaction 解析待签名的波场交易
+// 输入可以是 raw_data 的十六进制、TronGrid 返回的完整交易 JSON，或单独的 raw_data JSON；
+// 有 raw_data_hex 时以它为准，否则由 raw_data 编码得到；两者都有时 raw_data 必须编码为相同的字节，
+// 以免摘要与发起地址校验读取的内容和实际签名的不同。已有 txID 时校验其等于 sha256(raw_data)。
+func ParseTronTransaction(document []byte) (*TronTransaction, error) {
+	document = bytes.TrimSpace(document)
+	tx := &TronTransaction{}
+	if len(document) > 0 && document[0] == '{' {
+		if err := json.Unmarshal(document, tx); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidTronTransaction, err)
+		}
+		if tx.RawData == nil && tx.RawDataHex == "" {
+			// 单独的 raw_data JSON
+			tx.RawData = &TronRawData{}
+			if err := json.Unmarshal(document, tx.RawData); err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrInvalidTronTransaction, err)
+			}
+			tx.Visible = strings.HasPrefix(tx.RawData.firstOwner(), "T")
+		}
+	} else {
+		tx.RawDataHex = string(document)
+	}
+
+	if tx.RawDataHex == "" {
+		raw, err := tx.RawData.encode()
+		if err != nil {
+			return nil, err
+		}
+		tx.RawDataHex = hex.EncodeToString(raw)
+	}
+	raw, err := hex.DecodeString(strings.TrimPrefix(tx.RawDataHex, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: raw_data_hex 不是十六进制", ErrInvalidTronTransaction)
+	}
+	tx.RawDataHex = hex.EncodeToString(raw)
+	if tx.RawData == nil {
+		if tx.RawData, err = decodeTronRawData(raw); err != nil {
+			return nil, err
+		}
+	} else if encoded, err := tx.RawData.encode(); err != nil {
+		return nil, err
+	} else if !bytes.Equal(encoded, raw) {
+		return nil, ErrRawDataMismatch
+	}
+
+	txID := sha256.Sum256(raw)
+	if tx.TxID != "" && !strings.EqualFold(tx.TxID, hex.EncodeToString(txID[:])) {
+		return nil, fmt.Errorf("%w: txID 与 sha256(raw_data) 不一致", ErrInvalidTronTransaction)
+	}
+	tx.TxID = hex.EncodeToString(txID[:])
+	return tx, nil
+}
+
+// Summary 返回各合约的转账摘要，地址统一为 T 开头的 Base58
+func (tx *TronTransaction) Summary() ([]TronTransferSummary, error) {
+	var summaries []TronTransferSummary
+	for _, contract := range tx.RawData.Contract {
+		value := contract.Parameter.Value
+		from, err := tronAddressBytes(value.OwnerAddress)
+		if err != nil {
+			return nil, err
+		}
+		summary := TronTransferSummary{Type: contract.Type, From: chain.TronAddressFromHash(from[1:])}
+
+		switch contract.Type {
+		case TronTransferContract:
+			to, err := tronAddressBytes(value.ToAddress)
+			if err != nil {
+				return nil, err
+			}
+			summary.To, summary.Amount = chain.TronAddressFromHash(to[1:]), fmt.Sprint(value.Amount)
+		case TronTriggerSmartContract:
+			contractAddress, err := tronAddressBytes(value.ContractAddress)
+			if err != nil {
+				return nil, err
+			}
+			summary.Contract = chain.TronAddressFromHash(contractAddress[1:])
+			data, err := hex.DecodeString(value.Data)
+			if err != nil {
+				return nil, fmt.Errorf("%w: data 不是十六进制", ErrInvalidTronTransaction)
+			}
+			// transfer(address,uint256)：32 字节地址参数的后 20 字节与 32 字节金额
+			if len(data) == 68 && bytes.Equal(data[:4], trc20TransferSelector) {
+				summary.Type = "TRC20 transfer"
+				summary.To = chain.TronAddressFromHash(data[16:36])
+				summary.Amount = new(big.Int).SetBytes(data[36:68]).String()
+			} else {
+				summary.Amount = fmt.Sprint(value.CallValue)
+			}
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries, nil
+}
+
+// SignTronTransaction 校验各合约的发起地址属于私钥后，对 txID 签名并追加到 Signature
+// 签名为 65 字节 r||s||v（v 为 27/28），与 TronWeb 一致
+func SignTronTransaction(privateKey *ecdsa.PrivateKey, tx *TronTransaction) error {
+	signer := chain.TronAddress(&privateKey.PublicKey)
+	for _, contract := range tx.RawData.Contract {
+		owner, err := tronAddressBytes(contract.Parameter.Value.OwnerAddress)
+		if err != nil {
+			return err
+		}
+		if address := chain.TronAddressFromHash(owner[1:]); address != signer {
+			return fmt.Errorf("%w: 交易为 %s，私钥对应 %s", ErrOwnerMismatch, address, signer)
+		}
+	}
+
+	txID, err := hex.DecodeString(tx.TxID)
+	if err != nil {
+		return fmt.Errorf("%w: txID 不是十六进制", ErrInvalidTronTransaction)
+	}
+	sig, err := crypto.Sign(txID, privateKey)
+	if err != nil {
+		return err
+	}
+	sig[64] += 27
+	tx.Signature = append(tx.Signature, hex.EncodeToString(sig))
+	return nil
+}
+
+// firstOwner 第一个合约的发起地址，用于判断 JSON 中的地址格式
+func (raw *TronRawData) firstOwner() string {
+	if len(raw.Contract) == 0 {
+		return ""
+	}
+	return raw.Contract[0].Parameter.Value.OwnerAddress
+}
+
+// encode 把 raw_data JSON 编码为 protobuf
+func (raw *TronRawData) encode() ([]byte, error) {
+	refBlockBytes, err1 := hex.DecodeString(raw.RefBlockBytes)
+	refBlockHash, err2 := hex.DecodeString(raw.RefBlockHash)
+	data, err3 := hex.DecodeString(raw.Data)
+	if err := errors.Join(err1, err2, err3); err != nil {
+		return nil, fmt.Errorf("%w: ref_block_bytes、ref_block_hash 与 data 应为十六进制", ErrInvalidTronTransaction)
+	}
+	if len(raw.Contract) == 0 || raw.Expiration == 0 {
+		return nil, fmt.Errorf("%w: 缺少 contract 或 expiration", ErrInvalidTronTransaction)
+	}
+
+	var b []byte
+	b = appendBytesField(b, 1, refBlockBytes)
+	b = appendVarintField(b, 3, uint64(raw.RefBlockNum))
+	b = appendBytesField(b, 4, refBlockHash)
+	b = appendVarintField(b, 8, uint64(raw.Expiration))
+	b = appendBytesField(b, 10, data)
+	for _, contract := range raw.Contract {
+		encoded, err := contract.encode()
+		if err != nil {
+			return nil, err
+		}
+		b = appendBytesField(b, 11, encoded)
+	}
+	b = appendVarintField(b, 14, uint64(raw.Timestamp))
+	b = appendVarintField(b, 18, uint64(raw.FeeLimit))
+	return b, nil
+}
+
+// encode 把合约编码为 protocol.Transaction.Contract
+func (c *TronContract) encode() ([]byte, error) {
+	value := c.Parameter.Value
+	owner, err := tronAddressBytes(value.OwnerAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	var contractType uint64
+	var v []byte
+	v = appendBytesField(v, 1, owner)
+	switch c.Type {
+	case TronTransferContract:
+		to, err := tronAddressBytes(value.ToAddress)
+		if err != nil {
+			return nil, err
+		}
+		contractType = tronTransferContractType
+		v = appendBytesField(v, 2, to)
+		v = appendVarintField(v, 3, uint64(value.Amount))
+	case TronTriggerSmartContract:
+		contractAddress, err := tronAddressBytes(value.ContractAddress)
+		if err != nil {
+			return nil, err
+		}
+		data, err := hex.DecodeString(value.Data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: data 不是十六进制", ErrInvalidTronTransaction)
+		}
+		contractType = tronTriggerSmartContractID
+		v = appendBytesField(v, 2, contractAddress)
+		v = appendVarintField(v, 3, uint64(value.CallValue))
+		v = appendBytesField(v, 4, data)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedContract, c.Type)
+	}
+
+	typeURL := c.Parameter.TypeURL
+	if typeURL == "" {
+		typeURL = tronTypeURLPrefix + c.Type
+	}
+	var any []byte
+	any = appendBytesField(any, 1, []byte(typeURL))
+	any = appendBytesField(any, 2, v)
+
+	var b []byte
+	b = appendVarintField(b, 1, contractType)
+	b = appendBytesField(b, 2, any)
+	b = appendVarintField(b, 5, uint64(c.PermissionID))
+	return b, nil
+}
+
+// decodeTronRawData 把 raw_data protobuf 解码为 JSON 结构，只接受能原样重新编码的字段
+func decodeTronRawData(b []byte) (*TronRawData, error) {
+	fields, err := parseProto(b)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTronTransaction, err)
+	}
+	raw := &TronRawData{}
+	for _, f := range fields {
+		switch f.number {
+		case 1:
+			raw.RefBlockBytes = hex.EncodeToString(f.bytes)
+		case 3:
+			raw.RefBlockNum = int64(f.num)
+		case 4:
+			raw.RefBlockHash = hex.EncodeToString(f.bytes)
+		case 8:
+			raw.Expiration = int64(f.num)
+		case 10:
+			raw.Data = hex.EncodeToString(f.bytes)
+		case 11:
+			contract, err := decodeTronContract(f.bytes)
+			if err != nil {
+				return nil, err
+			}
+			raw.Contract = append(raw.Contract, *contract)
+		case 14:
+			raw.Timestamp = int64(f.num)
+		case 18:
+			raw.FeeLimit = int64(f.num)
+		default:
+			return nil, fmt.Errorf("%w: raw_data 含不支持的字段 %d", ErrInvalidTronTransaction, f.number)
+		}
+	}
+	return raw, nil
+}
+
+// decodeTronContract 解码 protocol.Transaction.Contract，地址输出为 41 开头的十六进制
+func decodeTronContract(b []byte) (*TronContract, error) {
+	fields, err := parseProto(b)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTronTransaction, err)
+	}
+	contract := &TronContract{}
+	var contractType uint64
+	var value []byte
+	for _, f := range fields {
+		switch f.number {
+		case 1:
+			contractType = f.num
+		case 2:
+			any, err := parseProto(f.bytes)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrInvalidTronTransaction, err)
+			}
+			for _, a := range any {
+				switch a.number {
+				case 1:
+					contract.Parameter.TypeURL = string(a.bytes)
+				case 2:
+					value = a.bytes
+				}
+			}
+		case 5:
+			contract.PermissionID = int64(f.num)
+		default:
+			return nil, fmt.Errorf("%w: contract 含不支持的字段 %d", ErrInvalidTronTransaction, f.number)
+		}
+	}
+
+	fields, err = parseProto(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTronTransaction, err)
+	}
+	v := &contract.Parameter.Value
+	switch contractType {
+	case tronTransferContractType:
+		contract.Type = TronTransferContract
+		for _, f := range fields {
+			switch f.number {
+			case 1:
+				v.OwnerAddress = hex.EncodeToString(f.bytes)
+			case 2:
+				v.ToAddress = hex.EncodeToString(f.bytes)
+			case 3:
+				v.Amount = int64(f.num)
+			default:
+				return nil, fmt.Errorf("%w: TransferContract 含不支持的字段 %d", ErrInvalidTronTransaction, f.number)
+			}
+		}
+	case tronTriggerSmartContractID:
+		contract.Type = TronTriggerSmartContract
+		for _, f := range fields {
+			switch f.number {
+			case 1:
+				v.OwnerAddress = hex.EncodeToString(f.bytes)
+			case 2:
+				v.ContractAddress = hex.EncodeToString(f.bytes)
+			case 3:
+				v.CallValue = int64(f.num)
+			case 4:
+				v.Data = hex.EncodeToString(f.bytes)
+			default:
+				return nil, fmt.Errorf("%w: TriggerSmartContract 含不支持的字段 %d", ErrInvalidTronTransaction, f.number)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%w: 类型 %d", ErrUnsupportedContract, contractType)
+	}
+	return contract, nil
+}
+
+// tronAddressBytes 解析 T 开头的 Base58 或 41 开头的十六进制地址，返回 21 字节
+func tronAddressBytes(address string) ([]byte, error) {
+	info, err := chain.ValidateAddress(address)
+	if err != nil || info.Chain != chain.Tron {
+		return nil, fmt.Errorf("%w: 无效的波场地址 %q", ErrInvalidTronTransaction, address)
+	}
+	payload, _ := hex.DecodeString(info.Payload)
+	return append([]byte{chain.TronAddressPrefix}, payload...), nil
+}
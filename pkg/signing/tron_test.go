@@ -0,0 +1,146 @@
+package signing
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// 波场开发者文档中 /wallet/createtransaction 的示例交易
+const (
+	docsRawData = `{
+	"contract": [{
+		"parameter": {
+			"value": {
+				"amount": 1000,
+				"owner_address": "41608f8da72479edc7dd921e4c30bb7e7cddbe722e",
+				"to_address": "41e9d79cc47518930bc322d9bf7cddd260a0260a8d"
+			},
+			"type_url": "type.googleapis.com/protocol.TransferContract"
+		},
+		"type": "TransferContract"
+	}],
+	"ref_block_bytes": "5e4b",
+	"ref_block_hash": "47c9dc89341b300d",
+	"expiration": 1591089627000,
+	"timestamp": 1591089567635
+}`
+	docsRawDataHex = "0a025e4b220847c9dc89341b300d40f8fed3a2a72e5a66080112620a2d747970652e676f6f676c65617069732e636f6d2f70726f746f636f6c2e5472616e73666572436f6e747261637412310a1541608f8da72479edc7dd921e4c30bb7e7cddbe722e121541e9d79cc47518930bc322d9bf7cddd260a0260a8d18e8077093afd0a2a72e"
+	docsTxID       = "77ddfa7093cc5f745c0d3a54abb89ef070f983343c05e0f89e5a52f3e5401299"
+)
+
+// tronTransactionJSON 组装 TronGrid 返回格式的交易 JSON
+func tronTransactionJSON(rawData, rawDataHex, txID string) string {
+	return fmt.Sprintf(`{"visible": false, "txID": %q, "raw_data": %s, "raw_data_hex": %q}`, txID, rawData, rawDataHex)
+}
+
+func TestParseTronTransaction(t *testing.T) {
+	documents := map[string]string{
+		"raw_data":     docsRawData,
+		"raw_data_hex": docsRawDataHex,
+		"完整交易":         tronTransactionJSON(docsRawData, docsRawDataHex, docsTxID),
+		"0x 前缀":        "0x" + docsRawDataHex + "\n",
+	}
+	for name, document := range documents {
+		tx, err := ParseTronTransaction([]byte(document))
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if tx.RawDataHex != docsRawDataHex || tx.TxID != docsTxID {
+			t.Errorf("%s: raw_data_hex = %s, txID = %s", name, tx.RawDataHex, tx.TxID)
+		}
+		summaries, err := tx.Summary()
+		want := TronTransferSummary{Type: TronTransferContract, From: "TJmmqjb1DK9TTZbQXzRQ2AuA94z4gKAPFh",
+			To: "TXHejmNKzgHFmjtt4qx9TWTv3majM9TP6B", Amount: "1000"}
+		if err != nil || len(summaries) != 1 || summaries[0] != want {
+			t.Errorf("%s: Summary = %+v, %v", name, summaries, err)
+		}
+	}
+}
+
+func TestParseTronTransactionMismatch(t *testing.T) {
+	// raw_data 的金额被改动，raw_data_hex 仍为原交易：摘要显示的内容与实际签名的不同，必须拒绝
+	tampered := strings.Replace(docsRawData, `"amount": 1000,`, `"amount": 1,`, 1)
+	if _, err := ParseTronTransaction([]byte(tronTransactionJSON(tampered, docsRawDataHex, ""))); !errors.Is(err, ErrRawDataMismatch) {
+		t.Errorf("金额不一致: %v", err)
+	}
+	// 发起地址被改为其他账户
+	tampered = strings.Replace(docsRawData, "41608f8da72479edc7dd921e4c30bb7e7cddbe722e", "417e5f4552091a69125d5dfcb7b8c2659029395bdf", 1)
+	if _, err := ParseTronTransaction([]byte(tronTransactionJSON(tampered, docsRawDataHex, docsTxID))); !errors.Is(err, ErrRawDataMismatch) {
+		t.Errorf("发起地址不一致: %v", err)
+	}
+
+	if _, err := ParseTronTransaction([]byte(tronTransactionJSON(docsRawData, docsRawDataHex, strings.Repeat("0", 64)))); !errors.Is(err, ErrInvalidTronTransaction) {
+		t.Errorf("txID 不一致: %v", err)
+	}
+	for _, document := range []string{"zz", docsRawDataHex + "ff", `{"raw_data": {"contract": []}}`} {
+		if _, err := ParseTronTransaction([]byte(document)); !errors.Is(err, ErrInvalidTronTransaction) {
+			t.Errorf("ParseTronTransaction(%.20s): %v", document, err)
+		}
+	}
+}
+
+func TestSignTronTransaction(t *testing.T) {
+	key := mustKey(t, "0000000000000000000000000000000000000000000000000000000000000001")
+	const (
+		owner    = "TMVQGm1qAQYVdetCeGRRkTWYYrLXuHK2HC"
+		usdt     = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+		receiver = "TJmmqjb1DK9TTZbQXzRQ2AuA94z4gKAPFh"
+	)
+	// TRC20 transfer(receiver, 1500000)，地址参数为去掉 41 前缀的 20 字节
+	data := "a9059cbb" + strings.Repeat("0", 24) + "608f8da72479edc7dd921e4c30bb7e7cddbe722e" + fmt.Sprintf("%064x", 1500000)
+	document := fmt.Sprintf(`{
+		"contract": [{
+			"parameter": {"value": {"owner_address": %q, "contract_address": %q, "data": %q}},
+			"type": "TriggerSmartContract"
+		}],
+		"ref_block_bytes": "5e4b",
+		"ref_block_hash": "47c9dc89341b300d",
+		"expiration": 1591089627000,
+		"timestamp": 1591089567635,
+		"fee_limit": 30000000
+	}`, owner, usdt, data)
+
+	tx, err := ParseTronTransaction([]byte(document))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tx.Visible {
+		t.Error("Base58 地址的交易应为 visible")
+	}
+	summaries, err := tx.Summary()
+	want := TronTransferSummary{Type: "TRC20 transfer", From: owner, To: receiver, Contract: usdt, Amount: "1500000"}
+	if err != nil || len(summaries) != 1 || summaries[0] != want {
+		t.Errorf("Summary = %+v, %v", summaries, err)
+	}
+
+	// 重新解析 raw_data_hex 得到相同的交易
+	decoded, err := ParseTronTransaction([]byte(tx.RawDataHex))
+	if err != nil || decoded.TxID != tx.TxID {
+		t.Errorf("解析 raw_data_hex: %v", err)
+	}
+
+	if err := SignTronTransaction(key, tx); err != nil {
+		t.Fatal(err)
+	}
+	sig, _ := hex.DecodeString(tx.Signature[0])
+	txID, _ := hex.DecodeString(tx.TxID)
+	if len(tx.Signature) != 1 || len(sig) != 65 || (sig[64] != 27 && sig[64] != 28) {
+		t.Fatalf("Signature = %v", tx.Signature)
+	}
+	sig[64] -= 27
+	if publicKey, err := crypto.SigToPub(txID, sig); err != nil || crypto.PubkeyToAddress(*publicKey) != crypto.PubkeyToAddress(key.PublicKey) {
+		t.Errorf("签名恢复出的公钥不匹配: %v", err)
+	}
+
+	// 文档示例交易的发起地址不是私钥 1
+	other, _ := ParseTronTransaction([]byte(docsRawDataHex))
+	if err := SignTronTransaction(key, other); !errors.Is(err, ErrOwnerMismatch) || len(other.Signature) != 0 {
+		t.Errorf("发起地址不匹配: %v", err)
+	}
+}
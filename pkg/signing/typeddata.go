@@ -0,0 +1,68 @@
+package signing
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"wallet_create_address/pkg/chain"
+)
+
+// ErrInvalidTypedData EIP-712 文档格式错误或与类型定义不符
+var ErrInvalidTypedData = errors.New("无效的 EIP-712 数据")
+
+// TypedDataSignature EIP-712 签名结果
+type TypedDataSignature struct {
+	Signer          string `json:"signer"`
+	PrimaryType     string `json:"primary_type"`
+	DomainSeparator string `json:"domain_separator"` // hashStruct(EIP712Domain)
+	StructHash      string `json:"struct_hash"`      // hashStruct(message)
+	Digest          string `json:"digest"`           // keccak256(0x1901 || domainSeparator || structHash)
+	Signature       string `json:"signature"`        // 65 字节 r||s||v，v 为 27/28
+	V               int    `json:"v"`
+	R               string `json:"r"`
+	S               string `json:"s"`
+}
+
+// SignTypedData 解析 eth_signTypedData_v4 格式的 JSON 文档，按 EIP-712 计算摘要并签名
+func SignTypedData(privateKey *ecdsa.PrivateKey, document []byte) (*TypedDataSignature, error) {
+	var typedData apitypes.TypedData
+	if err := json.Unmarshal(document, &typedData); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTypedData, err)
+	}
+	if typedData.PrimaryType == "" {
+		return nil, fmt.Errorf("%w: 缺少 primaryType", ErrInvalidTypedData)
+	}
+
+	domainSeparator, err := typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
+	if err != nil {
+		return nil, fmt.Errorf("%w: domain: %v", ErrInvalidTypedData, err)
+	}
+	structHash, err := typedData.HashStruct(typedData.PrimaryType, typedData.Message)
+	if err != nil {
+		return nil, fmt.Errorf("%w: message: %v", ErrInvalidTypedData, err)
+	}
+	digest := crypto.Keccak256([]byte{0x19, 0x01}, domainSeparator, structHash)
+
+	sig, err := crypto.Sign(digest, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	sig[64] += 27
+	return &TypedDataSignature{
+		Signer:          chain.EthereumAddress(&privateKey.PublicKey),
+		PrimaryType:     typedData.PrimaryType,
+		DomainSeparator: domainSeparator.String(),
+		StructHash:      structHash.String(),
+		Digest:          hexutil.Encode(digest),
+		Signature:       hexutil.Encode(sig),
+		V:               int(sig[64]),
+		R:               hexutil.Encode(sig[:32]),
+		S:               hexutil.Encode(sig[32:64]),
+	}, nil
+}
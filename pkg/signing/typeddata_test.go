@@ -0,0 +1,81 @@
+package signing
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// mailTypedData EIP-712 规范中的 Mail 示例
+const mailTypedData = `{
+  "types": {
+    "EIP712Domain": [
+      {"name": "name", "type": "string"},
+      {"name": "version", "type": "string"},
+      {"name": "chainId", "type": "uint256"},
+      {"name": "verifyingContract", "type": "address"}
+    ],
+    "Person": [
+      {"name": "name", "type": "string"},
+      {"name": "wallet", "type": "address"}
+    ],
+    "Mail": [
+      {"name": "from", "type": "Person"},
+      {"name": "to", "type": "Person"},
+      {"name": "contents", "type": "string"}
+    ]
+  },
+  "primaryType": "Mail",
+  "domain": {
+    "name": "Ether Mail",
+    "version": "1",
+    "chainId": 1,
+    "verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+  },
+  "message": {
+    "from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+    "to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+    "contents": "Hello, Bob!"
+  }
+}`
+
+func TestSignTypedDataMail(t *testing.T) {
+	// 规范示例中的签名私钥为 keccak256("cow")
+	key := crypto.ToECDSAUnsafe(crypto.Keccak256([]byte("cow")))
+	signed, err := SignTypedData(key, []byte(mailTypedData))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := TypedDataSignature{
+		Signer:          "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826",
+		PrimaryType:     "Mail",
+		DomainSeparator: "0xf2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f",
+		StructHash:      "0xc52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e",
+		Digest:          "0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2",
+		Signature: "0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d" +
+			"07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562" + "1c",
+		V: 28,
+		R: "0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d",
+		S: "0x07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562",
+	}
+	if *signed != want {
+		t.Errorf("SignTypedData =\n%+v\n期望\n%+v", *signed, want)
+	}
+}
+
+func TestSignTypedDataErrors(t *testing.T) {
+	key := crypto.ToECDSAUnsafe(crypto.Keccak256([]byte("cow")))
+	documents := map[string]string{
+		"非 JSON":         "{",
+		"缺少 primaryType": strings.Replace(mailTypedData, `"primaryType": "Mail",`, "", 1),
+		"未定义的类型":         strings.Replace(mailTypedData, `"primaryType": "Mail"`, `"primaryType": "Letter"`, 1),
+		"字段类型不符":         strings.Replace(mailTypedData, `"wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"`, `"wallet": 7`, 1),
+	}
+	for name, document := range documents {
+		if _, err := SignTypedData(key, []byte(document)); !errors.Is(err, ErrInvalidTypedData) {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
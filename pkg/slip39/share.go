@@ -0,0 +1,190 @@
+package slip39
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	radixBits          = 10 // 每个单词 10 位
+	idBits             = 15 // 标识符位数
+	checksumWords      = 3  // RS1024 校验和单词数
+	metadataWords      = 7  // 4 个参数单词 + 3 个校验和单词
+	minMnemonicWords   = 20 // 128 位主密钥对应的单词数
+	customization      = "shamir"
+	customizationExtra = "shamir_extendable"
+)
+
+// Share 一个 SLIP-39 份额
+type Share struct {
+	Identifier        uint16 // 15 位随机标识符，同一次拆分的份额相同
+	Extendable        bool   // 可扩展标志：加密时不以标识符作盐
+	IterationExponent int    // PBKDF2 迭代次数指数
+	GroupIndex        int    // 组序号（从 0 开始）
+	GroupThreshold    int    // 恢复所需的组数
+	GroupCount        int    // 组总数
+	MemberIndex       int    // 组内成员序号（从 0 开始）
+	MemberThreshold   int    // 组内恢复所需的份额数
+	Value             []byte // 份额值
+}
+
+// Mnemonic 把份额编码为助记词
+func (s Share) Mnemonic() string {
+	// 前 40 位：标识符(15) 可扩展(1) 迭代指数(4) 组序号(4) 组阈值-1(4) 组数-1(4) 成员序号(4) 成员阈值-1(4)
+	ext := 0
+	if s.Extendable {
+		ext = 1
+	}
+	prefix := uint64(s.Identifier)<<25 | uint64(ext)<<24 | uint64(s.IterationExponent)<<20 |
+		uint64(s.GroupIndex)<<16 | uint64(s.GroupThreshold-1)<<12 | uint64(s.GroupCount-1)<<8 |
+		uint64(s.MemberIndex)<<4 | uint64(s.MemberThreshold-1)
+
+	var indexes []int
+	for shift := 30; shift >= 0; shift -= radixBits {
+		indexes = append(indexes, int(prefix>>shift)&1023)
+	}
+	indexes = append(indexes, bytesToIndexes(s.Value)...)
+	indexes = append(indexes, checksumIndexes(s.Extendable, indexes)...)
+
+	words := make([]string, len(indexes))
+	for i, index := range indexes {
+		words[i] = wordlist[index]
+	}
+	return strings.Join(words, " ")
+}
+
+// ParseShare 解析并校验一个份额助记词（校验和、填充位与参数）
+func ParseShare(mnemonic string) (Share, error) {
+	fields := strings.Fields(strings.ToLower(mnemonic))
+	if len(fields) < minMnemonicWords {
+		return Share{}, fmt.Errorf("%w: 至少需要 %d 个单词，实际 %d 个", ErrInvalidMnemonic, minMnemonicWords, len(fields))
+	}
+
+	indexes := make([]int, len(fields))
+	for i, word := range fields {
+		index, ok := wordIndex[word]
+		if !ok {
+			return Share{}, &WordError{Position: i + 1, Word: word}
+		}
+		indexes[i] = index
+	}
+
+	prefix := uint64(indexes[0])<<30 | uint64(indexes[1])<<20 | uint64(indexes[2])<<10 | uint64(indexes[3])
+	s := Share{
+		Identifier:        uint16(prefix >> 25),
+		Extendable:        prefix>>24&1 == 1,
+		IterationExponent: int(prefix >> 20 & 0xF),
+		GroupIndex:        int(prefix >> 16 & 0xF),
+		GroupThreshold:    int(prefix>>12&0xF) + 1,
+		GroupCount:        int(prefix>>8&0xF) + 1,
+		MemberIndex:       int(prefix >> 4 & 0xF),
+		MemberThreshold:   int(prefix&0xF) + 1,
+	}
+
+	if rs1024Polymod(customizationValues(s.Extendable), indexes) != 1 {
+		return Share{}, ErrInvalidChecksum
+	}
+	if s.GroupCount < s.GroupThreshold {
+		return Share{}, fmt.Errorf("%w: 组阈值 %d 大于组数 %d", ErrInvalidMnemonic, s.GroupThreshold, s.GroupCount)
+	}
+
+	value, err := indexesToBytes(indexes[4 : len(indexes)-checksumWords])
+	if err != nil {
+		return Share{}, err
+	}
+	s.Value = value
+	return s, nil
+}
+
+// bytesToIndexes 把份额值按 10 位分组，不足时在高位补零
+func bytesToIndexes(value []byte) []int {
+	wordCount := (len(value)*8 + radixBits - 1) / radixBits
+	indexes := make([]int, wordCount)
+	// 从最低位开始取，高位自然补零
+	acc, bits, pos := 0, 0, wordCount-1
+	for i := len(value) - 1; i >= 0; i-- {
+		acc |= int(value[i]) << bits
+		bits += 8
+		for bits >= radixBits {
+			indexes[pos] = acc & 1023
+			acc >>= radixBits
+			bits -= radixBits
+			pos--
+		}
+	}
+	if pos >= 0 {
+		indexes[pos] = acc
+	}
+	return indexes
+}
+
+// indexesToBytes bytesToIndexes 的逆运算，填充位必须少于 9 位且全为零
+func indexesToBytes(indexes []int) ([]byte, error) {
+	totalBits := len(indexes) * radixBits
+	padding := totalBits % 16
+	if padding > 8 {
+		return nil, ErrInvalidPadding
+	}
+	value := make([]byte, (totalBits-padding)/8)
+
+	acc, bits, pos := 0, 0, len(value)-1
+	for i := len(indexes) - 1; i >= 0; i-- {
+		acc |= indexes[i] << bits
+		bits += radixBits
+		for bits >= 8 && pos >= 0 {
+			value[pos] = byte(acc)
+			acc >>= 8
+			bits -= 8
+			pos--
+		}
+	}
+	if acc != 0 {
+		return nil, ErrInvalidPadding
+	}
+	return value, nil
+}
+
+// RS1024 校验和（GF(1024) 上的 Reed-Solomon 码）
+var rs1024Generators = [10]uint32{
+	0xE0E040, 0x1C1C080, 0x3838100, 0x7070200, 0xE0E0009,
+	0x1C0C2412, 0x38086C24, 0x3090FC48, 0x21B1F890, 0x3F3F120,
+}
+
+func rs1024Polymod(parts ...[]int) uint32 {
+	chk := uint32(1)
+	for _, values := range parts {
+		for _, v := range values {
+			b := chk >> 20
+			chk = (chk&0xFFFFF)<<10 ^ uint32(v)
+			for i, g := range rs1024Generators {
+				if (b>>i)&1 != 0 {
+					chk ^= g
+				}
+			}
+		}
+	}
+	return chk
+}
+
+// customizationValues 校验和的定制字符串，可扩展份额使用 shamir_extendable
+func customizationValues(extendable bool) []int {
+	cs := customization
+	if extendable {
+		cs = customizationExtra
+	}
+	values := make([]int, len(cs))
+	for i := range cs {
+		values[i] = int(cs[i])
+	}
+	return values
+}
+
+// checksumIndexes 计算 3 个校验和单词
+func checksumIndexes(extendable bool, indexes []int) []int {
+	polymod := rs1024Polymod(customizationValues(extendable), indexes, make([]int, checksumWords)) ^ 1
+	result := make([]int, checksumWords)
+	for i := range result {
+		result[i] = int(polymod>>(radixBits*(checksumWords-1-i))) & 1023
+	}
+	return result
+}
@@ -0,0 +1,368 @@
+// Package slip39 实现 SLIP-39 Shamir 秘密分享助记词
+//
+// 主密钥先用口令经 4 轮 Feistel（PBKDF2-HMAC-SHA256）加密，再做两层分享：
+// 先拆分为若干组（需要 GroupThreshold 组），每组再拆分为若干成员份额（需要该组的成员阈值）。
+// 每个份额编码为带 RS1024 校验和的助记词。
+package slip39
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sort"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+var (
+	// ErrInvalidMnemonic 份额助记词格式无效
+	ErrInvalidMnemonic = errors.New("SLIP-39 助记词无效")
+	// ErrInvalidChecksum 份额助记词校验和错误
+	ErrInvalidChecksum = errors.New("SLIP-39 助记词校验和错误")
+	// ErrInvalidPadding 份额值的填充位无效
+	ErrInvalidPadding = errors.New("SLIP-39 助记词填充位无效")
+	// ErrInvalidParams 拆分参数无效
+	ErrInvalidParams = errors.New("SLIP-39 拆分参数无效")
+	// ErrMismatchedShares 份额不属于同一次拆分或参数不一致
+	ErrMismatchedShares = errors.New("份额不属于同一组秘密")
+	// ErrInsufficientShares 份额数量不足以恢复
+	ErrInsufficientShares = errors.New("份额数量不足")
+	// ErrExtraShares 组数或组内份额数多于阈值（与参考实现一致，不从多余的份额中挑选）
+	ErrExtraShares = errors.New("份额数量多于阈值")
+	// ErrInvalidDigest 恢复结果的摘要校验失败（份额有误或被篡改）
+	ErrInvalidDigest = errors.New("份额摘要校验失败")
+)
+
+// WordError 助记词中出现不在 SLIP-39 词表内的单词
+type WordError struct {
+	Position int
+	Word     string
+}
+
+func (e *WordError) Error() string {
+	return fmt.Sprintf("第 %d 个单词 %q 不在 SLIP-39 词表中", e.Position, e.Word)
+}
+
+func (e *WordError) Unwrap() error {
+	return ErrInvalidMnemonic
+}
+
+const (
+	maxShareCount       = 16
+	baseIterationCount  = 10000
+	roundCount          = 4
+	digestLength        = 4
+	digestIndex         = 254
+	secretIndex         = 255
+	minMasterSecretSize = 16
+)
+
+// Group 一个组的拆分参数：需要 Threshold 个份额，共 Count 个
+type Group struct {
+	Threshold int
+	Count     int
+}
+
+// Options 拆分选项
+type Options struct {
+	Passphrase        []byte // 可选：加密主密钥的口令，恢复时必须相同
+	IterationExponent int    // PBKDF2 迭代次数为 10000 << IterationExponent，参考实现默认 1
+	Extendable        bool   // 可扩展份额（SLIP-39 新版默认），以后可用相同口令追加份额
+}
+
+// Split 把主密钥拆分为份额助记词，返回值按组排列
+// 主密钥长度须为不少于 16 字节的偶数（如 BIP39 助记词的熵）
+func Split(masterSecret []byte, groupThreshold int, groups []Group, opts Options) ([][]string, error) {
+	if len(masterSecret) < minMasterSecretSize || len(masterSecret)%2 != 0 {
+		return nil, fmt.Errorf("%w: 主密钥须为不少于 %d 字节的偶数长度", ErrInvalidParams, minMasterSecretSize)
+	}
+	if groupThreshold < 1 || groupThreshold > len(groups) || len(groups) > maxShareCount {
+		return nil, fmt.Errorf("%w: 组阈值须在 1 到组数之间，组数不能超过 %d", ErrInvalidParams, maxShareCount)
+	}
+	for i, g := range groups {
+		if g.Threshold < 1 || g.Threshold > g.Count || g.Count > maxShareCount {
+			return nil, fmt.Errorf("%w: 第 %d 组须满足 1 <= 阈值 <= 份额数 <= %d", ErrInvalidParams, i+1, maxShareCount)
+		}
+		if g.Threshold == 1 && g.Count > 1 {
+			return nil, fmt.Errorf("%w: 第 %d 组阈值为 1 时只能有 1 个份额", ErrInvalidParams, i+1)
+		}
+	}
+	if opts.IterationExponent < 0 || opts.IterationExponent > 15 {
+		return nil, fmt.Errorf("%w: 迭代指数须在 0-15 之间", ErrInvalidParams)
+	}
+
+	var idBytes [2]byte
+	if _, err := rand.Read(idBytes[:]); err != nil {
+		return nil, err
+	}
+	identifier := (uint16(idBytes[0])<<8 | uint16(idBytes[1])) & (1<<idBits - 1)
+
+	encrypted := encrypt(masterSecret, opts.Passphrase, opts.IterationExponent, identifier, opts.Extendable)
+	groupShares, err := splitSecret(groupThreshold, len(groups), encrypted)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([][]string, len(groups))
+	for groupIndex, g := range groups {
+		memberShares, err := splitSecret(g.Threshold, g.Count, groupShares[groupIndex].value)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range memberShares {
+			share := Share{
+				Identifier:        identifier,
+				Extendable:        opts.Extendable,
+				IterationExponent: opts.IterationExponent,
+				GroupIndex:        groupIndex,
+				GroupThreshold:    groupThreshold,
+				GroupCount:        len(groups),
+				MemberIndex:       int(member.x),
+				MemberThreshold:   g.Threshold,
+				Value:             member.value,
+			}
+			result[groupIndex] = append(result[groupIndex], share.Mnemonic())
+		}
+	}
+	return result, nil
+}
+
+// Combine 由份额助记词恢复主密钥
+// 须恰好提供 GroupThreshold 个组、每组恰好成员阈值个不同的份额；重复的同一份额只计一次
+func Combine(mnemonics []string, passphrase []byte) ([]byte, error) {
+	if len(mnemonics) == 0 {
+		return nil, ErrInsufficientShares
+	}
+
+	var first Share
+	groups := make(map[int]map[int]Share)
+	for i, mnemonic := range mnemonics {
+		share, err := ParseShare(mnemonic)
+		if err != nil {
+			return nil, fmt.Errorf("第 %d 个份额: %w", i+1, err)
+		}
+		if i == 0 {
+			first = share
+		} else if share.Identifier != first.Identifier || share.Extendable != first.Extendable ||
+			share.IterationExponent != first.IterationExponent || share.GroupThreshold != first.GroupThreshold ||
+			share.GroupCount != first.GroupCount || len(share.Value) != len(first.Value) {
+			return nil, fmt.Errorf("%w: 第 %d 个份额的标识符或参数不同", ErrMismatchedShares, i+1)
+		}
+		if share.GroupIndex >= share.GroupCount {
+			return nil, fmt.Errorf("%w: 第 %d 个份额的组序号超出组数", ErrInvalidMnemonic, i+1)
+		}
+
+		members := groups[share.GroupIndex]
+		if members == nil {
+			members = make(map[int]Share)
+			groups[share.GroupIndex] = members
+		}
+		if prev, ok := members[share.MemberIndex]; ok {
+			if !bytes.Equal(prev.Value, share.Value) || prev.MemberThreshold != share.MemberThreshold {
+				return nil, fmt.Errorf("%w: 第 %d 组存在序号相同但内容不同的份额", ErrMismatchedShares, share.GroupIndex+1)
+			}
+			continue
+		}
+		for _, other := range members {
+			if other.MemberThreshold != share.MemberThreshold {
+				return nil, fmt.Errorf("%w: 第 %d 组的成员阈值不一致", ErrMismatchedShares, share.GroupIndex+1)
+			}
+			break
+		}
+		members[share.MemberIndex] = share
+	}
+
+	if len(groups) < first.GroupThreshold {
+		return nil, fmt.Errorf("%w: 需要 %d 个组，当前 %d 个", ErrInsufficientShares, first.GroupThreshold, len(groups))
+	}
+	if len(groups) > first.GroupThreshold {
+		return nil, fmt.Errorf("%w: 需要 %d 个组，当前 %d 个", ErrExtraShares, first.GroupThreshold, len(groups))
+	}
+
+	// 恢复各组份额
+	groupIndexes := make([]int, 0, len(groups))
+	for index := range groups {
+		groupIndexes = append(groupIndexes, index)
+	}
+	sort.Ints(groupIndexes)
+
+	groupShares := make([]point, 0, len(groups))
+	for _, groupIndex := range groupIndexes {
+		members := groups[groupIndex]
+		var threshold int
+		points := make([]point, 0, len(members))
+		for index, share := range members {
+			points = append(points, point{x: byte(index), value: share.Value})
+			threshold = share.MemberThreshold
+		}
+		if len(members) < threshold {
+			return nil, fmt.Errorf("%w: 第 %d 组需要 %d 个份额，当前 %d 个", ErrInsufficientShares, groupIndex+1, threshold, len(members))
+		}
+		if len(members) > threshold {
+			return nil, fmt.Errorf("%w: 第 %d 组需要 %d 个份额，当前 %d 个", ErrExtraShares, groupIndex+1, threshold, len(members))
+		}
+		sort.Slice(points, func(i, j int) bool { return points[i].x < points[j].x })
+
+		value, err := recoverSecret(threshold, points)
+		if err != nil {
+			return nil, fmt.Errorf("第 %d 组: %w", groupIndex+1, err)
+		}
+		groupShares = append(groupShares, point{x: byte(groupIndex), value: value})
+	}
+
+	encrypted, err := recoverSecret(first.GroupThreshold, groupShares)
+	if err != nil {
+		return nil, err
+	}
+	return decrypt(encrypted, passphrase, first.IterationExponent, first.Identifier, first.Extendable), nil
+}
+
+// point 分享多项式上的一个点
+type point struct {
+	x     byte
+	value []byte
+}
+
+// splitSecret 把秘密拆分为 count 个份额，任意 threshold 个可恢复
+// 多项式经过 x=255 的秘密和 x=254 的摘要点，用于恢复时发现错误份额
+func splitSecret(threshold, count int, secret []byte) ([]point, error) {
+	if threshold == 1 {
+		shares := make([]point, count)
+		for i := range shares {
+			shares[i] = point{x: byte(i), value: secret}
+		}
+		return shares, nil
+	}
+
+	randomCount := threshold - 2
+	shares := make([]point, 0, count)
+	for i := 0; i < randomCount; i++ {
+		value := make([]byte, len(secret))
+		if _, err := rand.Read(value); err != nil {
+			return nil, err
+		}
+		shares = append(shares, point{x: byte(i), value: value})
+	}
+
+	randomPart := make([]byte, len(secret)-digestLength)
+	if _, err := rand.Read(randomPart); err != nil {
+		return nil, err
+	}
+	digest := append(createDigest(randomPart, secret), randomPart...)
+
+	base := append(append([]point{}, shares...),
+		point{x: digestIndex, value: digest},
+		point{x: secretIndex, value: secret})
+	for i := randomCount; i < count; i++ {
+		shares = append(shares, point{x: byte(i), value: interpolate(base, byte(i))})
+	}
+	return shares, nil
+}
+
+// recoverSecret 由 threshold 个份额恢复秘密并校验摘要
+func recoverSecret(threshold int, shares []point) ([]byte, error) {
+	if threshold == 1 {
+		return shares[0].value, nil
+	}
+	secret := interpolate(shares, secretIndex)
+	digestShare := interpolate(shares, digestIndex)
+	if !hmac.Equal(digestShare[:digestLength], createDigest(digestShare[digestLength:], secret)) {
+		return nil, ErrInvalidDigest
+	}
+	return secret, nil
+}
+
+func createDigest(randomPart, secret []byte) []byte {
+	mac := hmac.New(sha256.New, randomPart)
+	mac.Write(secret)
+	return mac.Sum(nil)[:digestLength]
+}
+
+// GF(256) 运算，既约多项式 x^8 + x^4 + x^3 + x + 1，生成元 3
+var gfExp, gfLog = func() (exp [255]int, log [256]int) {
+	poly := 1
+	for i := 0; i < 255; i++ {
+		exp[i] = poly
+		log[poly] = i
+		poly = (poly << 1) ^ poly
+		if poly&0x100 != 0 {
+			poly ^= 0x11B
+		}
+	}
+	return
+}()
+
+// interpolate 拉格朗日插值求多项式在 x 处的值
+func interpolate(shares []point, x byte) []byte {
+	for _, s := range shares {
+		if s.x == x {
+			return s.value
+		}
+	}
+
+	logProd := 0
+	for _, s := range shares {
+		logProd += gfLog[s.x^x]
+	}
+
+	result := make([]byte, len(shares[0].value))
+	for _, s := range shares {
+		logBasis := logProd - gfLog[s.x^x]
+		for _, other := range shares {
+			if other.x != s.x {
+				logBasis -= gfLog[s.x^other.x]
+			}
+		}
+		logBasis = ((logBasis % 255) + 255) % 255
+
+		for i, v := range s.value {
+			if v != 0 {
+				result[i] ^= byte(gfExp[(gfLog[v]+logBasis)%255])
+			}
+		}
+	}
+	return result
+}
+
+// encrypt 4 轮 Feistel 加密主密钥
+func encrypt(secret, passphrase []byte, exponent int, identifier uint16, extendable bool) []byte {
+	half := len(secret) / 2
+	l, r := append([]byte{}, secret[:half]...), append([]byte{}, secret[half:]...)
+	for i := 0; i < roundCount; i++ {
+		l, r = r, xorBytes(l, roundFunction(i, passphrase, exponent, identifier, extendable, r))
+	}
+	return append(r, l...)
+}
+
+// decrypt encrypt 的逆运算
+func decrypt(encrypted, passphrase []byte, exponent int, identifier uint16, extendable bool) []byte {
+	half := len(encrypted) / 2
+	l, r := append([]byte{}, encrypted[:half]...), append([]byte{}, encrypted[half:]...)
+	for i := roundCount - 1; i >= 0; i-- {
+		l, r = r, xorBytes(l, roundFunction(i, passphrase, exponent, identifier, extendable, r))
+	}
+	return append(r, l...)
+}
+
+// roundFunction Feistel 轮函数：PBKDF2-HMAC-SHA256(轮次 || 口令, 盐 || R)
+// 不可扩展份额的盐为 "shamir" || 标识符，可扩展份额不加盐
+func roundFunction(round int, passphrase []byte, exponent int, identifier uint16, extendable bool, r []byte) []byte {
+	var salt []byte
+	if !extendable {
+		salt = append([]byte(customization), byte(identifier>>8), byte(identifier))
+	}
+	password := append([]byte{byte(round)}, passphrase...)
+	iterations := (baseIterationCount << exponent) / roundCount
+	return pbkdf2.Key(password, append(salt, r...), iterations, len(r), sha256.New)
+}
+
+func xorBytes(a, b []byte) []byte {
+	out := make([]byte, len(a))
+	for i := range a {
+		out[i] = a[i] ^ b[i]
+	}
+	return out
+}
@@ -0,0 +1,152 @@
+package slip39
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/tyler-smith/go-bip32"
+)
+
+// testdata/vectors.json 采用参考实现 python-shamir-mnemonic 的 vectors.json 格式：
+// [描述, [份额...], 主密钥十六进制, BIP32 根 xprv]，主密钥为空表示应恢复失败，口令均为 TREZOR；
+// 包含 128/256 位与可扩展份额的向量，描述中含 extendable 的向量其份额均带可扩展标志
+func TestVectors(t *testing.T) {
+	data, err := os.ReadFile("testdata/vectors.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var vectors [][4]json.RawMessage
+	if err := json.Unmarshal(data, &vectors); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, v := range vectors {
+		var description, secretHex, xprv string
+		var mnemonics []string
+		json.Unmarshal(v[0], &description)
+		json.Unmarshal(v[1], &mnemonics)
+		json.Unmarshal(v[2], &secretHex)
+		json.Unmarshal(v[3], &xprv)
+
+		if strings.Contains(strings.ToLower(description), "extendable") {
+			for _, m := range mnemonics {
+				if share, err := ParseShare(m); err != nil || !share.Extendable {
+					t.Errorf("%d. %s: 份额应带可扩展标志: %+v, %v", i+1, description, share, err)
+				}
+			}
+		}
+
+		secret, err := Combine(mnemonics, []byte("TREZOR"))
+		if secretHex == "" {
+			if err == nil {
+				t.Errorf("%d. %s: 应恢复失败，得到 %x", i+1, description, secret)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%d. %s: %v", i+1, description, err)
+			continue
+		}
+		if hex.EncodeToString(secret) != secretHex {
+			t.Errorf("%d. %s: 主密钥 = %x", i+1, description, secret)
+		}
+		if key, err := bip32.NewMasterKey(secret); err != nil || key.B58Serialize() != xprv {
+			t.Errorf("%d. %s: xprv 不一致", i+1, description)
+		}
+	}
+}
+
+func TestCombineErrors(t *testing.T) {
+	checksum := "duckling enlarge academic academic agency result length solution fridge kidney coal piece deal husband erode duke ajar critical decision kidney"
+	if _, err := Combine([]string{checksum}, nil); !errors.Is(err, ErrInvalidChecksum) {
+		t.Errorf("校验和错误: %v", err)
+	}
+	var wordErr *WordError
+	if _, err := Combine([]string{"duckling enlarge academic academic agency result length solution fridge kidney coal piece deal husband erode duke ajar critical decision bitcoin"}, nil); !errors.As(err, &wordErr) || wordErr.Position != 20 {
+		t.Errorf("词表外单词: %v", err)
+	}
+	if _, err := Combine(nil, nil); !errors.Is(err, ErrInsufficientShares) {
+		t.Errorf("无份额: %v", err)
+	}
+
+	secret := bytes.Repeat([]byte{0x5a}, 16)
+	shares, err := Split(secret, 2, []Group{{1, 1}, {2, 3}, {3, 5}}, Options{IterationExponent: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		mnemonics []string
+		err       error
+	}{
+		{"多出一个组", []string{shares[0][0], shares[1][0], shares[1][1], shares[2][0], shares[2][1], shares[2][2]}, ErrExtraShares},
+		{"组内多出一个份额", []string{shares[0][0], shares[1][0], shares[1][1], shares[1][2]}, ErrExtraShares},
+		{"组内份额不足", []string{shares[0][0], shares[2][0], shares[2][1]}, ErrInsufficientShares},
+		{"组数不足", []string{shares[2][0], shares[2][1], shares[2][2]}, ErrInsufficientShares},
+	}
+	for _, tt := range tests {
+		if _, err := Combine(tt.mnemonics, nil); !errors.Is(err, tt.err) {
+			t.Errorf("%s: 错误 = %v, 期望 %v", tt.name, err, tt.err)
+		}
+	}
+
+	// 重复提交同一份额只计一次
+	got, err := Combine([]string{shares[1][2], shares[0][0], shares[1][0], shares[1][2]}, nil)
+	if err != nil || !bytes.Equal(got, secret) {
+		t.Errorf("重复份额: %x, %v", got, err)
+	}
+}
+
+func TestSplitCombine(t *testing.T) {
+	secret := bytes.Repeat([]byte{7}, 32)
+	for _, extendable := range []bool{false, true} {
+		shares, err := Split(secret, 2, []Group{{1, 1}, {2, 3}, {3, 5}}, Options{Passphrase: []byte("x"), Extendable: extendable})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(shares) != 3 || len(shares[2]) != 5 {
+			t.Fatalf("份额结构 = %d 组", len(shares))
+		}
+		share, err := ParseShare(shares[2][4])
+		if err != nil || share.Extendable != extendable || share.IterationExponent != 0 || share.MemberThreshold != 3 {
+			t.Errorf("ParseShare = %+v, %v", share, err)
+		}
+
+		for _, subset := range [][]string{
+			{shares[0][0], shares[2][4], shares[2][1], shares[2][2]},
+			{shares[1][2], shares[1][0], shares[0][0]},
+			{shares[1][1], shares[1][2], shares[2][0], shares[2][3], shares[2][4]},
+		} {
+			got, err := Combine(subset, []byte("x"))
+			if err != nil || !bytes.Equal(got, secret) {
+				t.Errorf("extendable=%v: Combine = %x, %v", extendable, got, err)
+			}
+		}
+		// 口令错误得到另一个主密钥而不报错
+		if got, err := Combine([]string{shares[0][0], shares[1][0], shares[1][1]}, []byte("y")); err != nil || bytes.Equal(got, secret) {
+			t.Errorf("extendable=%v: 错误口令 = %x, %v", extendable, got, err)
+		}
+	}
+
+	for _, tt := range []struct {
+		secret    []byte
+		threshold int
+		groups    []Group
+	}{
+		{make([]byte, 15), 1, []Group{{1, 1}}},
+		{make([]byte, 17), 1, []Group{{1, 1}}},
+		{secret, 2, []Group{{1, 1}}},
+		{secret, 1, []Group{{3, 2}}},
+		{secret, 1, []Group{{1, 2}}},
+		{secret, 1, []Group{{2, 17}}},
+	} {
+		if _, err := Split(tt.secret, tt.threshold, tt.groups, Options{}); !errors.Is(err, ErrInvalidParams) {
+			t.Errorf("Split(%d 字节, %d, %v): %v", len(tt.secret), tt.threshold, tt.groups, err)
+		}
+	}
+}
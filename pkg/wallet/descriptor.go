@@ -0,0 +1,163 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tyler-smith/go-bip32"
+
+	"wallet_create_address/pkg/chain"
+)
+
+// 描述符类型（BIP381/382/386）
+const (
+	DescriptorLegacy       = "pkh"      // BIP44 传统地址
+	DescriptorNestedSegwit = "sh(wpkh)" // BIP49 嵌套隔离见证
+	DescriptorSegwit       = "wpkh"     // BIP84 原生隔离见证
+	DescriptorTaproot      = "tr"       // BIP86 Taproot 单密钥
+)
+
+// ErrInvalidDescriptorChar 描述符包含校验和字符集之外的字符
+var ErrInvalidDescriptorChar = errors.New("描述符包含无效字符")
+
+// descriptorPurposes 各描述符类型对应的 BIP 用途编号，按输出顺序排列
+var descriptorPurposes = []struct {
+	kind    string
+	purpose int
+}{
+	{DescriptorLegacy, 44},
+	{DescriptorNestedSegwit, 49},
+	{DescriptorSegwit, 84},
+	{DescriptorTaproot, 86},
+}
+
+// Descriptor 一个账户的输出描述符
+type Descriptor struct {
+	Type        string `json:"type"`         // pkh / sh(wpkh) / wpkh / tr
+	AccountPath string `json:"account_path"` // 如 m/84'/0'/0'
+	Receive     string `json:"receive"`      // 收款链 .../0/*，含校验和
+	Change      string `json:"change"`       // 找零链 .../1/*，含校验和
+}
+
+// MasterFingerprint 主密钥指纹：主公钥 HASH160 的前 4 字节
+func MasterFingerprint(masterKey *bip32.Key) string {
+	return hex.EncodeToString(chain.Hash160(masterKey.PublicKey().Key)[:4])
+}
+
+// AccountDescriptors 生成助记词主密钥在 account 账户下的 pkh、sh(wpkh)、wpkh、tr 描述符
+// private 为 true 时使用扩展私钥（可签名钱包），否则使用扩展公钥（观察钱包）
+func AccountDescriptors(masterKey *bip32.Key, network string, account uint32, private bool) ([]Descriptor, error) {
+	if network == "" {
+		network = MainNet
+	}
+	versions, ok := extendedVersions[network]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownNetwork, network)
+	}
+	if account >= bip32.FirstHardenedChild {
+		return nil, fmt.Errorf("账户编号超出范围: %d", account)
+	}
+	coin := 0
+	if network == TestNet {
+		coin = 1
+	}
+	fingerprint := MasterFingerprint(masterKey)
+
+	var descriptors []Descriptor
+	for _, dp := range descriptorPurposes {
+		accountPath := fmt.Sprintf("m/%d'/%d'/%d'", dp.purpose, coin, account)
+		key, err := DeriveKey(masterKey, accountPath)
+		if err != nil {
+			return nil, err
+		}
+
+		// 描述符只使用 BIP32 的 xpub/xprv（tpub/tprv）版本字节，脚本类型由描述符函数表示
+		var encoded string
+		if private {
+			encoded = SerializeExtendedKey(key, versions[44].private)
+		} else {
+			encoded = SerializeExtendedKey(key.PublicKey(), versions[44].public)
+		}
+		origin := fmt.Sprintf("[%s/%s]%s", fingerprint, strings.TrimPrefix(accountPath, "m/"), encoded)
+
+		d := Descriptor{Type: dp.kind, AccountPath: accountPath}
+		if d.Receive, err = AddDescriptorChecksum(wrapDescriptor(dp.kind, origin+"/0/*")); err != nil {
+			return nil, err
+		}
+		if d.Change, err = AddDescriptorChecksum(wrapDescriptor(dp.kind, origin+"/1/*")); err != nil {
+			return nil, err
+		}
+		descriptors = append(descriptors, d)
+	}
+	return descriptors, nil
+}
+
+// wrapDescriptor 用描述符函数包裹密钥表达式，如 sh(wpkh(KEY))
+func wrapDescriptor(kind, key string) string {
+	if kind == DescriptorNestedSegwit {
+		return "sh(wpkh(" + key + "))"
+	}
+	return kind + "(" + key + ")"
+}
+
+// BIP380 描述符校验和
+const (
+	descriptorInputCharset    = "0123456789()[],'/*abcdefgh@:$%{}IJKLMNOPQRSTUVWXYZ&+-.;<=>?!^_|~ijklmnopqrstuvwxyzABCDEFGH`#\"\\ "
+	descriptorChecksumCharset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+)
+
+var descriptorGenerators = [5]uint64{0xf5dee51989, 0xa9fdca3312, 0x1bab10e32d, 0x3706b1677a, 0x644d626ffd}
+
+func descriptorPolymod(c uint64, value int) uint64 {
+	top := c >> 35
+	c = (c&0x7ffffffff)<<5 ^ uint64(value)
+	for i, g := range descriptorGenerators {
+		if (top>>i)&1 != 0 {
+			c ^= g
+		}
+	}
+	return c
+}
+
+// DescriptorChecksum 计算描述符（不含 #校验和）的 8 字符 BIP380 校验和
+func DescriptorChecksum(desc string) (string, error) {
+	c := uint64(1)
+	cls, clsCount := 0, 0
+	for _, ch := range desc {
+		pos := strings.IndexRune(descriptorInputCharset, ch)
+		if pos < 0 {
+			return "", fmt.Errorf("%w: %q", ErrInvalidDescriptorChar, ch)
+		}
+		// 低 5 位直接参与运算，高位每 3 个字符合并为一个符号
+		c = descriptorPolymod(c, pos&31)
+		cls = cls*3 + pos>>5
+		if clsCount++; clsCount == 3 {
+			c = descriptorPolymod(c, cls)
+			cls, clsCount = 0, 0
+		}
+	}
+	if clsCount > 0 {
+		c = descriptorPolymod(c, cls)
+	}
+	for i := 0; i < 8; i++ {
+		c = descriptorPolymod(c, 0)
+	}
+	c ^= 1
+
+	var sb strings.Builder
+	for i := 0; i < 8; i++ {
+		sb.WriteByte(descriptorChecksumCharset[(c>>(5*(7-i)))&31])
+	}
+	return sb.String(), nil
+}
+
+// AddDescriptorChecksum 返回带 #校验和 的描述符
+func AddDescriptorChecksum(desc string) (string, error) {
+	checksum, err := DescriptorChecksum(desc)
+	if err != nil {
+		return "", err
+	}
+	return desc + "#" + checksum, nil
+}
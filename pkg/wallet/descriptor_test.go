@@ -0,0 +1,75 @@
+package wallet
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDescriptorChecksum(t *testing.T) {
+	// BIP380 测试向量与 Bitcoin Core 文档中的示例
+	tests := map[string]string{
+		"raw(deadbeef)": "89f8spxm",
+		"addr(mkmZxiEcEd8ZqjQWVZuC6so5dFMKEFpN2j)": "02wpgw69",
+		"wpkh([d34db33f/84h/0h/0h]xpub6DJ2dNUysrn5Vt36jH2KLBT2i1auw1tTSSomg8PhqNiUtx8QX2SvC9nrHu81fT41fvDUnhMjEzQgXnQjKEu3oaqMSzhSrHMxyyoEAmUHQbY/0/*)": "cjjspncu",
+	}
+	for desc, want := range tests {
+		if got, err := DescriptorChecksum(desc); err != nil || got != want {
+			t.Errorf("DescriptorChecksum(%s) = %s, %v, 期望 %s", desc, got, err, want)
+		}
+	}
+	if _, err := DescriptorChecksum("raw(deadbeef)\n"); !errors.Is(err, ErrInvalidDescriptorChar) {
+		t.Errorf("无效字符: %v", err)
+	}
+}
+
+func TestAccountDescriptors(t *testing.T) {
+	masterKey := testMasterKey(t)
+	if fp := MasterFingerprint(masterKey); fp != "73c5da0a" {
+		t.Errorf("MasterFingerprint = %s", fp)
+	}
+
+	descriptors, err := AccountDescriptors(masterKey, MainNet, 0, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantPrefixes := []string{
+		"pkh([73c5da0a/44'/0'/0']xpub",
+		"sh(wpkh([73c5da0a/49'/0'/0']xpub",
+		"wpkh([73c5da0a/84'/0'/0']xpub6CatWdiZiodmUeTDp8LT5or8nmbKNcuyvz7WyksVFkKB4RHwCD3XyuvPEbvqAQY3rAPshWcMLoP2fMFMKHPJ4ZeZXYVUhLv1VMrjPC7PW6V/0/*)#",
+		"tr([73c5da0a/86'/0'/0']xpub",
+	}
+	if len(descriptors) != len(wantPrefixes) {
+		t.Fatalf("描述符数量 = %d", len(descriptors))
+	}
+	for i, d := range descriptors {
+		if !strings.HasPrefix(d.Receive, wantPrefixes[i]) {
+			t.Errorf("%s 收款描述符 = %s", d.Type, d.Receive)
+		}
+		// 找零描述符只有链编号不同，校验和须各自有效
+		for _, desc := range []string{d.Receive, d.Change} {
+			body, checksum, _ := strings.Cut(desc, "#")
+			if got, _ := DescriptorChecksum(body); got != checksum {
+				t.Errorf("%s 校验和 = %s, 期望 %s", body, checksum, got)
+			}
+		}
+		if strings.Replace(strings.Split(d.Receive, "#")[0], "/0/*", "/1/*", 1) != strings.Split(d.Change, "#")[0] {
+			t.Errorf("%s 找零描述符 = %s", d.Type, d.Change)
+		}
+	}
+
+	testnet, err := AccountDescriptors(masterKey, TestNet, 2, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := testnet[2]; d.AccountPath != "m/84'/1'/2'" || !strings.HasPrefix(d.Receive, "wpkh([73c5da0a/84'/1'/2']tprv") {
+		t.Errorf("测试网私钥描述符 = %+v", d)
+	}
+
+	if _, err := AccountDescriptors(masterKey, MainNet, 1<<31, false); err == nil {
+		t.Error("账户编号超出范围应返回错误")
+	}
+	if _, err := AccountDescriptors(masterKey, "signet", 0, false); !errors.Is(err, ErrUnknownNetwork) {
+		t.Errorf("未知网络: %v", err)
+	}
+}
@@ -0,0 +1,139 @@
+package wallet
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tyler-smith/go-bip39"
+)
+
+// 用户熵来源
+const (
+	EntropyDice = "dice" // 六面骰子，每次 1-6
+	EntropyCoin = "coin" // 抛硬币，H/T 或 1/0
+	EntropyHex  = "hex"  // 十六进制
+)
+
+// EntropySources 支持的用户熵来源
+var EntropySources = []string{EntropyDice, EntropyCoin, EntropyHex}
+
+var (
+	// ErrInsufficientEntropy 用户输入提供的熵不足
+	ErrInsufficientEntropy = errors.New("熵不足")
+	// ErrUnknownEntropySource 不支持的熵来源
+	ErrUnknownEntropySource = errors.New("未知的熵来源")
+	// ErrInvalidWordCount 助记词单词数无效
+	ErrInvalidWordCount = errors.New("助记词单词数须为 12、15、18、21 或 24")
+)
+
+// EntropyInputError 用户熵输入中有无效字符
+type EntropyInputError struct {
+	Source   string
+	Position int
+	Char     rune
+}
+
+func (e *EntropyInputError) Error() string {
+	return fmt.Sprintf("%s 输入的第 %d 个字符 %q 无效", e.Source, e.Position, e.Char)
+}
+
+// EntropyBits 助记词单词数对应的熵位数
+func EntropyBits(words int) (int, error) {
+	if words < 12 || words > 24 || words%3 != 0 {
+		return 0, ErrInvalidWordCount
+	}
+	return words / 3 * 32, nil
+}
+
+// UserEntropy 把用户提供的骰子、硬币或十六进制输入转换为 bits 位熵
+// 转换无偏：骰子 1-4 各产生 2 位（00/01/10/11），5-6 各产生 1 位（0/1）；硬币每次 1 位；十六进制每字符 4 位。
+// 输入产生的位数少于 bits 时返回 ErrInsufficientEntropy，多余的位被忽略，结果可手工复核。
+// mixOS 为 true 时结果再与 32 字节系统随机数一起做 SHA-256，用户输入有偏或泄露时仍有保障（但不再可复核）。
+func UserEntropy(source, input string, bits int, mixOS bool) ([]byte, error) {
+	if bits <= 0 || bits%8 != 0 || bits > 256 {
+		return nil, fmt.Errorf("熵位数须为 8 的倍数且不超过 256: %d", bits)
+	}
+
+	switch source {
+	case EntropyDice, EntropyCoin:
+	case EntropyHex:
+		input = strings.TrimPrefix(strings.TrimSpace(input), "0x")
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEntropySource, source)
+	}
+
+	var stream []byte // 每个元素是一位（0/1）
+	position := 0
+	for _, ch := range input {
+		if ch == ' ' || ch == ',' || ch == '\t' || ch == '\n' || ch == '\r' || ch == '-' {
+			continue
+		}
+		position++
+
+		switch source {
+		case EntropyDice:
+			switch {
+			case ch >= '1' && ch <= '4':
+				v := byte(ch - '1')
+				stream = append(stream, v>>1, v&1)
+			case ch == '5' || ch == '6':
+				stream = append(stream, byte(ch-'5'))
+			default:
+				return nil, &EntropyInputError{Source: source, Position: position, Char: ch}
+			}
+		case EntropyCoin:
+			switch ch {
+			case 'H', 'h', '1':
+				stream = append(stream, 1)
+			case 'T', 't', '0':
+				stream = append(stream, 0)
+			default:
+				return nil, &EntropyInputError{Source: source, Position: position, Char: ch}
+			}
+		case EntropyHex:
+			var v byte
+			switch {
+			case ch >= '0' && ch <= '9':
+				v = byte(ch - '0')
+			case ch >= 'a' && ch <= 'f':
+				v = byte(ch-'a') + 10
+			case ch >= 'A' && ch <= 'F':
+				v = byte(ch-'A') + 10
+			default:
+				return nil, &EntropyInputError{Source: source, Position: position, Char: ch}
+			}
+			stream = append(stream, v>>3&1, v>>2&1, v>>1&1, v&1)
+		}
+	}
+
+	if len(stream) < bits {
+		return nil, fmt.Errorf("%w: %s 输入只提供了 %d 位无偏熵，至少需要 %d 位", ErrInsufficientEntropy, source, len(stream), bits)
+	}
+
+	entropy := make([]byte, bits/8)
+	for i := 0; i < bits; i++ {
+		entropy[i/8] |= stream[i] << (7 - i%8)
+	}
+
+	if mixOS {
+		osRandom := make([]byte, 32)
+		if _, err := rand.Read(osRandom); err != nil {
+			return nil, fmt.Errorf("读取系统随机数失败: %w", err)
+		}
+		sum := sha256.Sum256(append(entropy, osRandom...))
+		copy(entropy, sum[:])
+	}
+	return entropy, nil
+}
+
+// MnemonicFromEntropy 把熵编码为 BIP39 助记词
+func MnemonicFromEntropy(entropy []byte) (string, error) {
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		return "", fmt.Errorf("生成助记词失败: %w", err)
+	}
+	return mnemonic, nil
+}
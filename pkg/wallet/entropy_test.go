@@ -0,0 +1,79 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestUserEntropy(t *testing.T) {
+	tests := []struct {
+		source string
+		input  string
+		want   string
+	}{
+		{EntropyHex, "0x" + strings.Repeat("00", 16), strings.Repeat("00", 16)},
+		{EntropyHex, strings.Repeat("7F", 8) + "\n" + strings.Repeat("7f", 8), strings.Repeat("7f", 16)},
+		// 骰子 1-4 各 2 位，5-6 各 1 位
+		{EntropyDice, strings.Repeat("1", 64), strings.Repeat("00", 16)},
+		{EntropyDice, strings.Repeat("4", 64), strings.Repeat("ff", 16)},
+		{EntropyDice, strings.Repeat("5, 6, ", 64), strings.Repeat("55", 16)},
+		{EntropyDice, strings.Repeat("2356", 32), strings.Repeat("659", 11)[:32]}, // 01 10 0 1
+		{EntropyCoin, strings.Repeat("HT", 64), strings.Repeat("aa", 16)},
+		{EntropyCoin, strings.Repeat("t-1-", 64), strings.Repeat("55", 16)},
+		// 多余的输入被忽略
+		{EntropyHex, strings.Repeat("ab", 20), strings.Repeat("ab", 16)},
+	}
+	for _, tt := range tests {
+		got, err := UserEntropy(tt.source, tt.input, 128, false)
+		if err != nil || hex.EncodeToString(got) != tt.want {
+			t.Errorf("UserEntropy(%s, %.20q) = %x, %v, 期望 %s", tt.source, tt.input, got, err, tt.want)
+		}
+	}
+
+	mnemonic, err := MnemonicFromEntropy(make([]byte, 16))
+	if err != nil || mnemonic != testMnemonic {
+		t.Errorf("MnemonicFromEntropy = %q, %v", mnemonic, err)
+	}
+
+	// 混入系统随机数后结果不可预测但长度不变
+	plain, _ := UserEntropy(EntropyCoin, strings.Repeat("H", 256), 256, false)
+	mixed, err := UserEntropy(EntropyCoin, strings.Repeat("H", 256), 256, true)
+	if err != nil || len(mixed) != 32 || bytes.Equal(mixed, plain) {
+		t.Errorf("mixOS = %x, %v", mixed, err)
+	}
+}
+
+func TestUserEntropyErrors(t *testing.T) {
+	var inputErr *EntropyInputError
+	if _, err := UserEntropy(EntropyDice, "1 2 3 7", 128, false); !errors.As(err, &inputErr) || inputErr.Position != 4 || inputErr.Char != '7' {
+		t.Errorf("无效骰子点数: %v", err)
+	}
+	if _, err := UserEntropy(EntropyCoin, "HTX", 128, false); !errors.As(err, &inputErr) || inputErr.Position != 3 {
+		t.Errorf("无效硬币: %v", err)
+	}
+	if _, err := UserEntropy(EntropyHex, "0g", 128, false); !errors.As(err, &inputErr) {
+		t.Errorf("无效十六进制: %v", err)
+	}
+	// 99 次骰子全为 5/6 只有 99 位无偏熵
+	if _, err := UserEntropy(EntropyDice, strings.Repeat("5", 99), 128, false); !errors.Is(err, ErrInsufficientEntropy) {
+		t.Errorf("熵不足: %v", err)
+	}
+	if _, err := UserEntropy("cards", "A", 128, false); !errors.Is(err, ErrUnknownEntropySource) {
+		t.Errorf("未知来源: %v", err)
+	}
+	if _, err := UserEntropy(EntropyHex, "00", 12, false); err == nil {
+		t.Error("熵位数不是 8 的倍数应返回错误")
+	}
+
+	for words, want := range map[int]int{12: 128, 15: 160, 18: 192, 21: 224, 24: 256} {
+		if bits, err := EntropyBits(words); err != nil || bits != want {
+			t.Errorf("EntropyBits(%d) = %d, %v", words, bits, err)
+		}
+	}
+	if _, err := EntropyBits(13); !errors.Is(err, ErrInvalidWordCount) {
+		t.Errorf("EntropyBits(13): %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidMnemonic 助记词无效
+var ErrInvalidMnemonic = errors.New("无效的助记词")
+
+// ChainError 未知链类型
+type ChainError struct {
+	Chain string
+}
+
+func (e *ChainError) Error() string {
+	return fmt.Sprintf("未知链类型: %s", e.Chain)
+}
+
+// GenerateError 生成第 Index 个钱包失败
+type GenerateError struct {
+	Index int
+	Err   error
+}
+
+func (e *GenerateError) Error() string {
+	return fmt.Sprintf("生成第 %d 个钱包失败: %v", e.Index+1, e.Err)
+}
+
+func (e *GenerateError) Unwrap() error {
+	return e.Err
+}
+
+// LineError 导入文件第 Line 行（从 1 开始）无效
+type LineError struct {
+	Line int
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("第 %d 行: %v", e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,151 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/tyler-smith/go-bip32"
+)
+
+// 比特币网络
+const (
+	MainNet = "mainnet"
+	TestNet = "testnet"
+)
+
+// ErrUnknownNetwork 不支持的网络
+var ErrUnknownNetwork = errors.New("未知网络")
+
+// ExtendedKeys 可选的导出字段：WIF 与账户级扩展密钥
+type ExtendedKeys struct {
+	WIF         string `json:"wif,omitempty"`          // 压缩公钥 WIF
+	AccountPath string `json:"account_path,omitempty"` // 派生路径的账户层级（前三段）
+	AccountXprv string `json:"account_xprv,omitempty"` // 账户扩展私钥
+	AccountXpub string `json:"account_xpub,omitempty"` // 账户扩展公钥
+	BIP49Ypub   string `json:"bip49_ypub,omitempty"`   // m/49'/币种'/账户' 的 SLIP-132 ypub
+	BIP84Zpub   string `json:"bip84_zpub,omitempty"`   // m/84'/币种'/账户' 的 SLIP-132 zpub
+}
+
+// 扩展密钥的版本字节（BIP32 与 SLIP-132）
+type keyVersions struct {
+	private, public []byte
+}
+
+var extendedVersions = map[string]map[int]keyVersions{
+	MainNet: {
+		44: {[]byte{0x04, 0x88, 0xAD, 0xE4}, []byte{0x04, 0x88, 0xB2, 0x1E}}, // xprv/xpub
+		49: {[]byte{0x04, 0x9D, 0x78, 0x78}, []byte{0x04, 0x9D, 0x7C, 0xB2}}, // yprv/ypub
+		84: {[]byte{0x04, 0xB2, 0x43, 0x0C}, []byte{0x04, 0xB2, 0x47, 0x46}}, // zprv/zpub
+	},
+	TestNet: {
+		44: {[]byte{0x04, 0x35, 0x83, 0x94}, []byte{0x04, 0x35, 0x87, 0xCF}}, // tprv/tpub
+		49: {[]byte{0x04, 0x4A, 0x4E, 0x28}, []byte{0x04, 0x4A, 0x52, 0x62}}, // uprv/upub
+		84: {[]byte{0x04, 0x5F, 0x18, 0xBC}, []byte{0x04, 0x5F, 0x1C, 0xF6}}, // vprv/vpub
+	},
+}
+
+// NetworkParams 获取网络参数，空表示主网
+func NetworkParams(network string) (*chaincfg.Params, error) {
+	switch network {
+	case "", MainNet:
+		return &chaincfg.MainNetParams, nil
+	case TestNet:
+		return &chaincfg.TestNet3Params, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownNetwork, network)
+}
+
+// EncodeWIF 把十六进制私钥编码为 WIF
+func EncodeWIF(privateKeyHex, network string, compressed bool) (string, error) {
+	params, err := NetworkParams(network)
+	if err != nil {
+		return "", err
+	}
+	data, err := hex.DecodeString(privateKeyHex)
+	if err != nil || len(data) != 32 {
+		return "", fmt.Errorf("私钥无效")
+	}
+	privateKey, _ := btcec.PrivKeyFromBytes(data)
+	wif, err := btcutil.NewWIF(privateKey, params, compressed)
+	if err != nil {
+		return "", err
+	}
+	return wif.String(), nil
+}
+
+// SerializeExtendedKey 以指定版本字节序列化扩展密钥（用于 SLIP-132 ypub/zpub 等）
+func SerializeExtendedKey(key *bip32.Key, version []byte) string {
+	clone := *key
+	clone.Version = version
+	return clone.B58Serialize()
+}
+
+// AccountKeys 计算账户级扩展密钥
+// 账户路径取 derivePath 的前三段（如 m/44'/60'/0'），另外给出同一账户序号下 BIP49/BIP84 比特币账户的 ypub/zpub；
+// 路径没有强化的账户层级时不输出账户级密钥
+func AccountKeys(masterKey *bip32.Key, derivePath, network string) (ExtendedKeys, error) {
+	versions, ok := extendedVersions[network]
+	if network == "" {
+		versions, ok = extendedVersions[MainNet], true
+	}
+	if !ok {
+		return ExtendedKeys{}, fmt.Errorf("%w: %s", ErrUnknownNetwork, network)
+	}
+
+	var keys ExtendedKeys
+	segments, err := ParsePath(derivePath)
+	if err != nil {
+		return keys, err
+	}
+	if len(segments) < 3 || segments[2] < bip32.FirstHardenedChild {
+		return keys, nil
+	}
+	keys.AccountPath = FormatPath(segments[:3])
+	account, err := DeriveKey(masterKey, keys.AccountPath)
+	if err != nil {
+		return keys, err
+	}
+	keys.AccountXprv = SerializeExtendedKey(account, versions[44].private)
+	keys.AccountXpub = SerializeExtendedKey(account.PublicKey(), versions[44].public)
+
+	coin := 0
+	if network == TestNet {
+		coin = 1
+	}
+	index := segments[2] - bip32.FirstHardenedChild
+	for _, purpose := range []int{49, 84} {
+		account, err := DeriveKey(masterKey, fmt.Sprintf("m/%d'/%d'/%d'", purpose, coin, index))
+		if err != nil {
+			return keys, err
+		}
+		xpub := SerializeExtendedKey(account.PublicKey(), versions[purpose].public)
+		if purpose == 49 {
+			keys.BIP49Ypub = xpub
+		} else {
+			keys.BIP84Zpub = xpub
+		}
+	}
+	return keys, nil
+}
+
+// AddExtendedKeys 为钱包填充 WIF；提供主密钥时（助记词模式）同时填充账户级扩展密钥
+func AddExtendedKeys(w *MultiChainWallet, masterKey *bip32.Key, network string) error {
+	var keys ExtendedKeys
+	if masterKey != nil && w.DerivePath != "" {
+		var err error
+		if keys, err = AccountKeys(masterKey, w.DerivePath, network); err != nil {
+			return err
+		}
+	}
+	wif, err := EncodeWIF(w.PrivateKey, network, true)
+	if err != nil {
+		return err
+	}
+	keys.WIF = wif
+	w.ExtendedKeys = keys
+	return nil
+}
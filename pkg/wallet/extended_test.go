@@ -0,0 +1,102 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/tyler-smith/go-bip32"
+	"github.com/tyler-smith/go-bip39"
+)
+
+func testMasterKey(t *testing.T) *bip32.Key {
+	t.Helper()
+	masterKey, err := bip32.NewMasterKey(bip39.NewSeed(testMnemonic, ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return masterKey
+}
+
+func TestAccountKeys(t *testing.T) {
+	masterKey := testMasterKey(t)
+
+	// BIP49/BIP84 规范中的测试向量
+	keys, err := AccountKeys(masterKey, "m/44'/60'/0'/0/0", MainNet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keys.AccountPath != "m/44'/60'/0'" {
+		t.Errorf("AccountPath = %s", keys.AccountPath)
+	}
+	if keys.BIP84Zpub != "zpub6rFR7y4Q2AijBEqTUquhVz398htDFrtymD9xYYfG1m4wAcvPhXNfE3EfH1r1ADqtfSdVCToUG868RvUUkgDKf31mGDtKsAYz2oz2AGutZYs" {
+		t.Errorf("BIP84Zpub = %s", keys.BIP84Zpub)
+	}
+	testnet, err := AccountKeys(masterKey, "m/44'/1'/0'/0/0", TestNet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if testnet.BIP49Ypub != "upub5EFU65HtV5TeiSHmZZm7FUffBGy8UKeqp7vw43jYbvZPpoVsgU93oac7Wk3u6moKegAEWtGNF8DehrnHtv21XXEMYRUocHqguyjknFHYfgY" {
+		t.Errorf("测试网 BIP49Ypub = %s", testnet.BIP49Ypub)
+	}
+
+	// 扩展密钥应与按账户路径直接派生的结果一致
+	account, _ := DeriveKey(masterKey, "m/44'/60'/0'")
+	if keys.AccountXprv != account.B58Serialize() || keys.AccountXpub != account.PublicKey().B58Serialize() {
+		t.Errorf("账户扩展密钥 = %s %s", keys.AccountXprv, keys.AccountXpub)
+	}
+
+	// ypub/zpub 跟随配置的账户序号
+	second, err := AccountKeys(masterKey, "m/44'/60'/1'/0/0", MainNet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for purpose, got := range map[int]string{49: second.BIP49Ypub, 84: second.BIP84Zpub} {
+		key, _ := DeriveKey(masterKey, fmt.Sprintf("m/%d'/0'/1'", purpose))
+		if want := SerializeExtendedKey(key.PublicKey(), extendedVersions[MainNet][purpose].public); got != want {
+			t.Errorf("账户 1 的 BIP%d 扩展公钥 = %s, 期望 %s", purpose, got, want)
+		}
+	}
+	if second.BIP84Zpub == keys.BIP84Zpub {
+		t.Error("账户 1 的 zpub 不应与账户 0 相同")
+	}
+
+	// 没有强化账户层级的路径不输出账户级密钥
+	for _, path := range []string{"m/0", "m/44'/60'/0/0"} {
+		if keys, err := AccountKeys(masterKey, path, MainNet); err != nil || keys != (ExtendedKeys{}) {
+			t.Errorf("%s: %+v, %v", path, keys, err)
+		}
+	}
+	if _, err := AccountKeys(masterKey, "m/44'/60'/0'", "regtest"); !errors.Is(err, ErrUnknownNetwork) {
+		t.Errorf("未知网络: %v", err)
+	}
+}
+
+func TestAddExtendedKeys(t *testing.T) {
+	w := MultiChainWallet{
+		PrivateKey: "0000000000000000000000000000000000000000000000000000000000000001",
+		DerivePath: "m/84'/0'/0'/0/0",
+	}
+	if err := AddExtendedKeys(&w, nil, MainNet); err != nil {
+		t.Fatal(err)
+	}
+	if w.WIF != "KwDiBf89QgGbjEhKnhXJuH7LrciVrZi3qYjgd9M7rFU73sVHnoWn" || w.AccountXpub != "" {
+		t.Errorf("无主密钥: %+v", w.ExtendedKeys)
+	}
+
+	if err := AddExtendedKeys(&w, testMasterKey(t), TestNet); err != nil {
+		t.Fatal(err)
+	}
+	wif, err := btcutil.DecodeWIF(w.WIF)
+	if err != nil || !wif.CompressPubKey || wif.String()[0] != 'c' {
+		t.Errorf("测试网 WIF = %s, %v", w.WIF, err)
+	}
+	if w.AccountPath != "m/84'/0'/0'" || w.AccountXpub[:4] != "tpub" || w.BIP84Zpub[:4] != "vpub" {
+		t.Errorf("扩展密钥 = %+v", w.ExtendedKeys)
+	}
+
+	if _, err := EncodeWIF("zz", MainNet, true); err == nil {
+		t.Error("无效私钥应返回错误")
+	}
+}
@@ -0,0 +1,121 @@
+package wallet
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip32"
+	"github.com/tyler-smith/go-bip39"
+
+	"wallet_create_address/pkg/chain"
+)
+
+// WalletGenerator 钱包生成器
+type WalletGenerator struct{}
+
+// NewWalletGenerator 创建钱包生成器
+func NewWalletGenerator() *WalletGenerator {
+	return &WalletGenerator{}
+}
+
+// GenerateRandomWallet 生成随机钱包
+func (wg *WalletGenerator) GenerateRandomWallet() (MultiChainWallet, error) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		return MultiChainWallet{}, fmt.Errorf("生成私钥失败: %w", err)
+	}
+
+	return wg.createWalletFromPrivateKey(privateKey, "", "")
+}
+
+// GenerateWalletFromMnemonic 从助记词生成钱包，派生路径为 DefaultBasePath/{index}
+func (wg *WalletGenerator) GenerateWalletFromMnemonic(mnemonic string, index int) (MultiChainWallet, error) {
+	return wg.GenerateWalletFromMnemonicPath(mnemonic, fmt.Sprintf("%s/%d", DefaultBasePath, index))
+}
+
+// GenerateWalletFromMnemonicPath 从助记词按指定派生路径生成钱包
+func (wg *WalletGenerator) GenerateWalletFromMnemonicPath(mnemonic, derivePath string) (MultiChainWallet, error) {
+	masterKey, err := MasterKeyFromMnemonic(mnemonic)
+	if err != nil {
+		return MultiChainWallet{}, err
+	}
+	return wg.walletFromMasterKey(masterKey, mnemonic, derivePath)
+}
+
+// MasterKeyFromMnemonic 验证助记词并生成 BIP32 主密钥
+func MasterKeyFromMnemonic(mnemonic string) (*bip32.Key, error) {
+	// 验证助记词
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, ErrInvalidMnemonic
+	}
+
+	// 生成种子
+	seed := bip39.NewSeed(mnemonic, "")
+
+	// 生成主密钥
+	masterKey, err := bip32.NewMasterKey(seed)
+	if err != nil {
+		return nil, fmt.Errorf("生成主密钥失败: %w", err)
+	}
+	return masterKey, nil
+}
+
+// walletFromMasterKey 从主密钥按路径派生钱包
+func (wg *WalletGenerator) walletFromMasterKey(masterKey *bip32.Key, mnemonic, derivePath string) (MultiChainWallet, error) {
+	// 派生子密钥
+	childKey, err := DeriveKey(masterKey, derivePath)
+	if err != nil {
+		return MultiChainWallet{}, fmt.Errorf("派生密钥失败: %w", err)
+	}
+
+	// 转换为 ECDSA 私钥
+	privateKey, err := crypto.ToECDSA(childKey.Key)
+	if err != nil {
+		return MultiChainWallet{}, fmt.Errorf("转换私钥失败: %w", err)
+	}
+
+	return wg.createWalletFromPrivateKey(privateKey, mnemonic, derivePath)
+}
+
+// NewMnemonic 生成新的 12 词助记词
+func NewMnemonic() (string, error) {
+	entropy, err := bip39.NewEntropy(128)
+	if err != nil {
+		return "", fmt.Errorf("生成熵失败: %w", err)
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// createWalletFromPrivateKey 从私钥创建钱包
+func (wg *WalletGenerator) createWalletFromPrivateKey(privateKey *ecdsa.PrivateKey, mnemonic, derivePath string) (MultiChainWallet, error) {
+	wallet, err := wg.createWalletFromPublicKey(&privateKey.PublicKey, derivePath, chain.BTCP2PKH, &chaincfg.MainNetParams)
+	wallet.Mnemonic = mnemonic
+	wallet.PrivateKey = hex.EncodeToString(crypto.FromECDSA(privateKey))
+	return wallet, err
+}
+
+// createWalletFromPublicKey 从公钥创建钱包（不含私钥），btcType 与 params 决定比特币地址的类型和网络
+func (wg *WalletGenerator) createWalletFromPublicKey(publicKey *ecdsa.PublicKey, derivePath, btcType string, params *chaincfg.Params) (MultiChainWallet, error) {
+	wallet := MultiChainWallet{
+		PublicKey:  hex.EncodeToString(crypto.FromECDSAPub(publicKey)),
+		DerivePath: derivePath,
+	}
+
+	// 生成各链地址
+	wallet.EthAddress = chain.EthereumAddress(publicKey)
+	wallet.BscAddress = wallet.EthAddress
+	wallet.PolygonAddress = wallet.EthAddress
+
+	btcAddr, err := chain.BitcoinAddressOfType(publicKey, btcType, params)
+	if err != nil {
+		return wallet, fmt.Errorf("生成比特币地址失败: %w", err)
+	}
+	wallet.BtcAddress = btcAddr
+
+	wallet.TronAddress = chain.TronAddress(publicKey)
+
+	return wallet, nil
+}
@@ -0,0 +1,158 @@
+package wallet
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+	"sync"
+)
+
+// ErrUnrecognizedLine 导入的行既不是私钥也不是助记词
+var ErrUnrecognizedLine = errors.New("无法识别的行，应为十六进制私钥、WIF 或助记词")
+
+// importJob 导入文件中的一行
+type importJob struct {
+	seq  int // 有效行的顺序号，用于按输入顺序产出
+	line int
+	text string
+}
+
+// importResult 一行的处理结果
+type importResult struct {
+	seq int
+	generateResult
+}
+
+// Import 逐行导入已有的私钥（十六进制或 WIF）和助记词，计算完整的多链地址
+// 空行和 # 开头的注释行被跳过；助记词按 BasePath/StartIndex 派生，私钥行没有派生路径。
+// 各行由 WorkerCount 个协程并行处理，结果按输入顺序产出，钱包的 Index 为行号减 1。
+// 无效行产出 *LineError 并继续；读取失败或 ctx 被取消时产出错误后结束。
+func (wg *WalletGenerator) Import(parent context.Context, r io.Reader, opts Options) iter.Seq2[MultiChainWallet, error] {
+	return func(yield func(MultiChainWallet, error) bool) {
+		ctx, cancel := context.WithCancel(parent)
+		defer cancel()
+
+		workerCount := opts.WorkerCount
+		if workerCount < 1 {
+			workerCount = 1
+		}
+
+		jobs := make(chan importJob)
+		results := make(chan importResult, workerCount)
+		var wgSync sync.WaitGroup
+
+		// 读取失败在所有已读行产出之后报告
+		var readErr error
+		go func() {
+			defer close(jobs)
+			scanner := bufio.NewScanner(r)
+			seq := 0
+			for line := 1; scanner.Scan(); line++ {
+				text := strings.TrimSpace(scanner.Text())
+				if text == "" || strings.HasPrefix(text, "#") {
+					continue
+				}
+				select {
+				case jobs <- importJob{seq: seq, line: line, text: text}:
+					seq++
+				case <-ctx.Done():
+					return
+				}
+			}
+			readErr = scanner.Err()
+		}()
+
+		for w := 0; w < workerCount; w++ {
+			wgSync.Add(1)
+			go func() {
+				defer wgSync.Done()
+				for job := range jobs {
+					wallet, err := wg.importLine(job.text, opts)
+					if err != nil {
+						err = &LineError{Line: job.line, Err: err}
+					} else {
+						wallet.Index = job.line - 1
+					}
+
+					select {
+					case results <- importResult{job.seq, generateResult{wallet: wallet, err: err}}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}()
+		}
+
+		go func() {
+			wgSync.Wait()
+			close(results)
+		}()
+
+		// 乱序完成的结果暂存，按顺序号依次产出
+		pending := make(map[int]generateResult)
+		next := 0
+		stopped := false
+	collect:
+		for result := range results {
+			pending[result.seq] = result.generateResult
+			for {
+				r, ok := pending[next]
+				if !ok {
+					break
+				}
+				delete(pending, next)
+				next++
+				if ctx.Err() != nil {
+					break collect
+				}
+				if !yield(r.wallet, r.err) {
+					stopped = true
+					break collect
+				}
+			}
+		}
+
+		// 通知协程退出并等待其结束
+		cancel()
+		for range results {
+		}
+
+		switch {
+		case stopped:
+		case parent.Err() != nil:
+			yield(MultiChainWallet{}, parent.Err())
+		case readErr != nil:
+			yield(MultiChainWallet{}, fmt.Errorf("读取导入文件失败: %w", readErr))
+		}
+	}
+}
+
+// importLine 把一行解析为钱包：含空格的按助记词处理，否则按私钥处理
+func (wg *WalletGenerator) importLine(text string, opts Options) (MultiChainWallet, error) {
+	if strings.ContainsAny(text, " \t") {
+		mnemonic := strings.Join(strings.Fields(text), " ")
+		masterKey, err := MasterKeyFromMnemonic(mnemonic)
+		if err != nil {
+			return MultiChainWallet{}, err
+		}
+		wallet, err := wg.walletFromMasterKey(masterKey, mnemonic, fmt.Sprintf("%s/%d", opts.basePath(), opts.StartIndex))
+		if err == nil && opts.ExtendedKeys {
+			err = AddExtendedKeys(&wallet, masterKey, opts.Network)
+		}
+		return wallet, err
+	}
+
+	privateKey, _, err := ParsePrivateKey(text)
+	if err != nil {
+		return MultiChainWallet{}, ErrUnrecognizedLine
+	}
+	wallet, err := wg.WalletFromPrivateKey(privateKey)
+	if err == nil && opts.ExtendedKeys {
+		err = AddExtendedKeys(&wallet, nil, opts.Network)
+	}
+	return wallet, err
+}
@@ -0,0 +1,140 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestImport(t *testing.T) {
+	input := strings.Join([]string{
+		"# 注释行",
+		keyOneHex,
+		"",
+		"  " + keyOneWIF + "  ",
+		"abandon  abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon\tabout",
+		"not-a-key",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon",
+		"0x" + keyOneHex,
+	}, "\n")
+
+	type line struct {
+		index int
+		eth   string
+		err   error
+	}
+	want := []line{
+		{1, "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf", nil},
+		{3, "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf", nil},
+		{4, "0x9858EfFD232B4033E47d90003D41EC34EcaEda94", nil},
+		{5, "", ErrUnrecognizedLine},
+		{6, "", ErrInvalidMnemonic},
+		{7, "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf", nil},
+	}
+
+	for _, workers := range []int{1, 4} {
+		var got []line
+		opts := Options{WorkerCount: workers, ExtendedKeys: true}
+		for w, err := range NewWalletGenerator().Import(context.Background(), strings.NewReader(input), opts) {
+			var lineErr *LineError
+			if err != nil {
+				if !errors.As(err, &lineErr) {
+					t.Fatalf("非行错误: %v", err)
+				}
+				got = append(got, line{lineErr.Line - 1, "", errors.Unwrap(err)})
+				continue
+			}
+			got = append(got, line{w.Index, w.EthAddress, nil})
+			if w.WIF == "" {
+				t.Errorf("第 %d 行缺少 WIF", w.Index+1)
+			}
+		}
+		if len(got) != len(want) {
+			t.Fatalf("workers=%d: 产出 %d 行: %+v", workers, len(got), got)
+		}
+		for i := range want {
+			if got[i].index != want[i].index || got[i].eth != want[i].eth || !errors.Is(got[i].err, want[i].err) {
+				t.Errorf("workers=%d: 第 %d 个结果 = %+v, 期望 %+v", workers, i, got[i], want[i])
+			}
+		}
+	}
+}
+
+func TestImportOrder(t *testing.T) {
+	// 大量行并行处理后仍按输入顺序产出
+	lines := make([]string, 60)
+	for i := range lines {
+		if i%2 == 0 {
+			lines[i] = keyOneHex
+		} else {
+			lines[i] = testMnemonic
+		}
+	}
+	i := 0
+	for w, err := range NewWalletGenerator().Import(context.Background(), strings.NewReader(strings.Join(lines, "\n")), Options{WorkerCount: 8}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if w.Index != i || (w.Mnemonic != "") != (i%2 == 1) {
+			t.Fatalf("第 %d 个结果的 Index = %d, 助记词 = %q", i, w.Index, w.Mnemonic)
+		}
+		i++
+	}
+	if i != len(lines) {
+		t.Errorf("产出 %d 行", i)
+	}
+}
+
+func TestImportBreakAndCancel(t *testing.T) {
+	input := strings.Repeat(testMnemonic+"\n", 1000)
+
+	base := runtime.NumGoroutine()
+	seen := 0
+	for _, err := range NewWalletGenerator().Import(context.Background(), strings.NewReader(input), Options{WorkerCount: 4}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if seen++; seen == 3 {
+			break
+		}
+	}
+	waitGoroutines(t, base)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	count, last := 0, error(nil)
+	for _, err := range NewWalletGenerator().Import(ctx, strings.NewReader(input), Options{WorkerCount: 4}) {
+		if err != nil {
+			last = err
+			continue
+		}
+		if count++; count == 5 {
+			cancel()
+		}
+	}
+	cancel()
+	if !errors.Is(last, context.Canceled) || count >= 1000 {
+		t.Errorf("取消后: count = %d, 最后的错误 = %v", count, last)
+	}
+	waitGoroutines(t, base)
+}
+
+func TestImportReadError(t *testing.T) {
+	readErr := errors.New("磁盘错误")
+	r := io.MultiReader(strings.NewReader(keyOneHex+"\n"+keyOneWIF+"\n"), iotest.ErrReader(readErr))
+	var wallets int
+	var last error
+	for _, err := range NewWalletGenerator().Import(context.Background(), r, Options{WorkerCount: 2}) {
+		if err != nil {
+			last = err
+			continue
+		}
+		wallets++
+	}
+	if wallets != 2 || !errors.Is(last, readErr) {
+		t.Errorf("产出 %d 个钱包, 最后的错误 = %v", wallets, last)
+	}
+}
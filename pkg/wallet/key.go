@@ -0,0 +1,117 @@
+package wallet
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"wallet_create_address/pkg/chain"
+)
+
+// ErrInvalidPrivateKey 私钥既不是十六进制也不是 WIF
+var ErrInvalidPrivateKey = errors.New("私钥应为 64 位十六进制或 WIF")
+
+// KeyInfo 单个私钥的全部地址与编码
+type KeyInfo struct {
+	Mnemonic   string `json:"mnemonic,omitempty"`
+	DerivePath string `json:"derive_path,omitempty"`
+	Network    string `json:"network"` // WIF 与比特币地址的网络
+
+	PrivateKey            string `json:"private_key"`
+	WIF                   string `json:"wif"`
+	WIFUncompressed       string `json:"wif_uncompressed"`
+	PublicKey             string `json:"public_key"`              // 压缩公钥（33 字节）
+	PublicKeyUncompressed string `json:"public_key_uncompressed"` // 未压缩公钥（65 字节）
+
+	EthAddress     string `json:"eth_address"` // EIP-55 校验和格式，BSC/Polygon 相同
+	TronAddress    string `json:"tron_address"`
+	TronHexAddress string `json:"tron_hex_address"`
+
+	BtcP2PKH             string `json:"btc_p2pkh"`
+	BtcP2PKHUncompressed string `json:"btc_p2pkh_uncompressed"`
+	BtcP2SHP2WPKH        string `json:"btc_p2sh_p2wpkh"`
+	BtcP2WPKH            string `json:"btc_p2wpkh"`
+	BtcP2TR              string `json:"btc_p2tr"`
+}
+
+// ParsePrivateKey 解析十六进制（可带 0x）或 WIF 私钥
+// WIF 同时给出网络（mainnet/testnet），十六进制私钥返回的网络为空
+func ParsePrivateKey(s string) (*ecdsa.PrivateKey, string, error) {
+	s = strings.TrimSpace(s)
+	if wif, err := btcutil.DecodeWIF(s); err == nil {
+		network := TestNet
+		if wif.IsForNet(&chaincfg.MainNetParams) {
+			network = MainNet
+		}
+		return wif.PrivKey.ToECDSA(), network, nil
+	}
+
+	data, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil || len(data) != 32 {
+		return nil, "", ErrInvalidPrivateKey
+	}
+	privateKey, err := crypto.ToECDSA(data)
+	if err != nil {
+		return nil, "", ErrInvalidPrivateKey
+	}
+	return privateKey, "", nil
+}
+
+// WalletFromPrivateKey 从已有私钥创建钱包（无助记词与派生路径）
+func (wg *WalletGenerator) WalletFromPrivateKey(privateKey *ecdsa.PrivateKey) (MultiChainWallet, error) {
+	return wg.createWalletFromPrivateKey(privateKey, "", "")
+}
+
+// InspectKey 计算私钥的全部地址与编码，network 决定 WIF 与比特币地址的网络
+func (wg *WalletGenerator) InspectKey(privateKey *ecdsa.PrivateKey, network string) (KeyInfo, error) {
+	params, err := NetworkParams(network)
+	if err != nil {
+		return KeyInfo{}, err
+	}
+	if network == "" {
+		network = MainNet
+	}
+
+	w, err := wg.WalletFromPrivateKey(privateKey)
+	if err != nil {
+		return KeyInfo{}, err
+	}
+	publicKey := &privateKey.PublicKey
+	info := KeyInfo{
+		Network:               network,
+		PrivateKey:            w.PrivateKey,
+		PublicKey:             hex.EncodeToString(crypto.CompressPubkey(publicKey)),
+		PublicKeyUncompressed: w.PublicKey,
+		EthAddress:            w.EthAddress,
+		TronAddress:           w.TronAddress,
+		TronHexAddress:        chain.TronHexAddress(publicKey),
+	}
+
+	if info.WIF, err = EncodeWIF(w.PrivateKey, network, true); err != nil {
+		return KeyInfo{}, err
+	}
+	if info.WIFUncompressed, err = EncodeWIF(w.PrivateKey, network, false); err != nil {
+		return KeyInfo{}, err
+	}
+
+	for _, address := range []struct {
+		addressType string
+		field       *string
+	}{
+		{chain.BTCP2PKH, &info.BtcP2PKH},
+		{chain.BTCP2PKHUncompressed, &info.BtcP2PKHUncompressed},
+		{chain.BTCP2SHP2WPKH, &info.BtcP2SHP2WPKH},
+		{chain.BTCP2WPKH, &info.BtcP2WPKH},
+		{chain.BTCP2TR, &info.BtcP2TR},
+	} {
+		if *address.field, err = chain.BitcoinAddressOfType(publicKey, address.addressType, params); err != nil {
+			return KeyInfo{}, err
+		}
+	}
+	return info, nil
+}
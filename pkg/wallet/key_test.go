@@ -0,0 +1,78 @@
+package wallet
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// 私钥 1 的各种编码
+const (
+	keyOneHex = "0000000000000000000000000000000000000000000000000000000000000001"
+	keyOneWIF = "KwDiBf89QgGbjEhKnhXJuH7LrciVrZi3qYjgd9M7rFU73sVHnoWn"
+)
+
+func TestParsePrivateKey(t *testing.T) {
+	tests := []struct {
+		input   string
+		network string
+	}{
+		{keyOneHex, ""},
+		{" 0x" + keyOneHex + "\n", ""},
+		{keyOneWIF, MainNet},
+		{"5HpHagT65TZzG1PH3CSu63k8DbpvD8s5ip4nEB3kEsreAnchuDf", MainNet},
+		{"cMahea7zqjxrtgAbB7LSGbcQUr1uX1ojuat9jZodMN87JcbXMTcA", TestNet},
+	}
+	for _, tt := range tests {
+		key, network, err := ParsePrivateKey(tt.input)
+		if err != nil || network != tt.network || key.D.Int64() != 1 {
+			t.Errorf("ParsePrivateKey(%q) = %v, %q, %v", tt.input, key, network, err)
+		}
+	}
+
+	for _, input := range []string{"", "0x01", keyOneHex + "00", "zz" + keyOneHex[2:], strings.Repeat("0", 64)} {
+		if _, _, err := ParsePrivateKey(input); !errors.Is(err, ErrInvalidPrivateKey) {
+			t.Errorf("ParsePrivateKey(%q): %v", input, err)
+		}
+	}
+}
+
+func TestInspectKey(t *testing.T) {
+	wg := NewWalletGenerator()
+	key, _, _ := ParsePrivateKey(keyOneHex)
+	info, err := wg.InspectKey(key, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := KeyInfo{
+		Network:               MainNet,
+		PrivateKey:            keyOneHex,
+		WIF:                   keyOneWIF,
+		WIFUncompressed:       "5HpHagT65TZzG1PH3CSu63k8DbpvD8s5ip4nEB3kEsreAnchuDf",
+		PublicKey:             "0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798",
+		PublicKeyUncompressed: "0479be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8",
+		EthAddress:            "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf",
+		TronAddress:           "TMVQGm1qAQYVdetCeGRRkTWYYrLXuHK2HC",
+		TronHexAddress:        "417e5f4552091a69125d5dfcb7b8c2659029395bdf",
+		BtcP2PKH:              "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH",
+		BtcP2PKHUncompressed:  "1EHNa6Q4Jz2uvNExL497mE43ikXhwF6kZm",
+		BtcP2SHP2WPKH:         "3JvL6Ymt8MVWiCNHC7oWU6nLeHNJKLZGLN",
+		BtcP2WPKH:             "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4",
+		BtcP2TR:               "bc1pmfr3p9j00pfxjh0zmgp99y8zftmd3s5pmedqhyptwy6lm87hf5sspknck9",
+	}
+	if info != want {
+		t.Errorf("InspectKey =\n%+v\n期望\n%+v", info, want)
+	}
+
+	testnet, err := wg.InspectKey(key, TestNet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if testnet.WIF != "cMahea7zqjxrtgAbB7LSGbcQUr1uX1ojuat9jZodMN87JcbXMTcA" ||
+		testnet.BtcP2WPKH != "tb1qw508d6qejxtdg4y5r3zarvary0c5xw7kxpjzsx" || testnet.EthAddress != want.EthAddress {
+		t.Errorf("测试网 = %+v", testnet)
+	}
+	if _, err := wg.InspectKey(key, "regtest"); !errors.Is(err, ErrUnknownNetwork) {
+		t.Errorf("未知网络: %v", err)
+	}
+}
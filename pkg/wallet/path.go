@@ -0,0 +1,109 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tyler-smith/go-bip32"
+)
+
+// DefaultBasePath 默认派生基础路径（以太坊标准），完整路径为 DefaultBasePath/{index}
+const DefaultBasePath = "m/44'/60'/0'/0"
+
+var (
+	// ErrEmptyPath 派生路径为空
+	ErrEmptyPath = errors.New("派生路径为空")
+	// ErrInvalidSegment 派生路径中存在无效的层级
+	ErrInvalidSegment = errors.New("无效的路径层级")
+)
+
+// PathError 派生路径无效
+type PathError struct {
+	Path    string
+	Segment string
+	Err     error
+}
+
+func (e *PathError) Error() string {
+	if e.Segment != "" {
+		return fmt.Sprintf("派生路径 %q 无效 (%s): %v", e.Path, e.Segment, e.Err)
+	}
+	return fmt.Sprintf("派生路径 %q 无效: %v", e.Path, e.Err)
+}
+
+func (e *PathError) Unwrap() error {
+	return e.Err
+}
+
+// ParsePath 解析 BIP32 派生路径，如 m/44'/60'/0'/0/0
+// 硬化层级可用 ' 或 h 表示；开头的 m/ 可省略
+func ParsePath(path string) ([]uint32, error) {
+	trimmed := strings.TrimSpace(path)
+	trimmed = strings.TrimPrefix(trimmed, "m")
+	trimmed = strings.TrimPrefix(trimmed, "/")
+	if trimmed == "" {
+		if strings.TrimSpace(path) == "m" {
+			return nil, nil
+		}
+		return nil, &PathError{Path: path, Err: ErrEmptyPath}
+	}
+
+	segments := strings.Split(trimmed, "/")
+	indexes := make([]uint32, 0, len(segments))
+	for _, segment := range segments {
+		s := segment
+		hardened := false
+		if strings.HasSuffix(s, "'") || strings.HasSuffix(s, "h") || strings.HasSuffix(s, "H") {
+			hardened = true
+			s = s[:len(s)-1]
+		}
+
+		n, err := strconv.ParseUint(s, 10, 32)
+		if err != nil || n >= uint64(bip32.FirstHardenedChild) {
+			return nil, &PathError{Path: path, Segment: segment, Err: ErrInvalidSegment}
+		}
+
+		index := uint32(n)
+		if hardened {
+			index += bip32.FirstHardenedChild
+		}
+		indexes = append(indexes, index)
+	}
+
+	return indexes, nil
+}
+
+// FormatPath 将路径层级格式化为 m/44'/60'/0'/0/0 形式
+func FormatPath(indexes []uint32) string {
+	var sb strings.Builder
+	sb.WriteString("m")
+	for _, index := range indexes {
+		sb.WriteByte('/')
+		if index >= bip32.FirstHardenedChild {
+			sb.WriteString(strconv.FormatUint(uint64(index-bip32.FirstHardenedChild), 10))
+			sb.WriteByte('\'')
+		} else {
+			sb.WriteString(strconv.FormatUint(uint64(index), 10))
+		}
+	}
+	return sb.String()
+}
+
+// DeriveKey 按路径从主密钥派生子密钥
+func DeriveKey(masterKey *bip32.Key, path string) (*bip32.Key, error) {
+	indexes, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key := masterKey
+	for _, index := range indexes {
+		key, err = key.NewChildKey(index)
+		if err != nil {
+			return nil, &PathError{Path: path, Err: err}
+		}
+	}
+	return key, nil
+}
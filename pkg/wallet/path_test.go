@@ -0,0 +1,79 @@
+package wallet
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		path string
+		want []uint32
+	}{
+		{"m", nil},
+		{"m/44'/60'/0'/0/0", []uint32{0x8000002c, 0x8000003c, 0x80000000, 0, 0}},
+		{"44h/0H/7", []uint32{0x8000002c, 0x80000000, 7}},
+		{" m/2147483647' ", []uint32{0xffffffff}},
+	}
+	for _, tt := range tests {
+		got, err := ParsePath(tt.path)
+		if err != nil {
+			t.Errorf("ParsePath(%q): %v", tt.path, err)
+			continue
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("ParsePath(%q) = %v", tt.path, got)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("ParsePath(%q) = %v", tt.path, got)
+				break
+			}
+		}
+	}
+
+	for path, want := range map[string]error{
+		"":             ErrEmptyPath,
+		"m/":           ErrEmptyPath,
+		"m/44'/x":      ErrInvalidSegment,
+		"m/2147483648": ErrInvalidSegment,
+		"m/44'//0":     ErrInvalidSegment,
+		"m/-1":         ErrInvalidSegment,
+		"m/44''":       ErrInvalidSegment,
+	} {
+		var pathErr *PathError
+		if _, err := ParsePath(path); !errors.Is(err, want) || !errors.As(err, &pathErr) {
+			t.Errorf("ParsePath(%q) 错误 = %v, 期望 %v", path, err, want)
+		}
+	}
+}
+
+func TestFormatPathRoundTrip(t *testing.T) {
+	for _, path := range []string{"m", "m/44'/60'/0'/0/0", "m/84'/1'/3'/1/99"} {
+		indexes, err := ParsePath(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := FormatPath(indexes); got != path {
+			t.Errorf("FormatPath(ParsePath(%q)) = %q", path, got)
+		}
+	}
+}
+
+func TestDeriveKey(t *testing.T) {
+	master, err := MasterKeyFromMnemonic(testMnemonic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := NewWalletGenerator().walletFromMasterKey(master, testMnemonic, "m/44'/60'/0'/0/0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.EthAddress != "0x9858EfFD232B4033E47d90003D41EC34EcaEda94" {
+		t.Errorf("m/44'/60'/0'/0/0 = %s", w.EthAddress)
+	}
+	if _, err := DeriveKey(master, "m/x"); !errors.Is(err, ErrInvalidSegment) {
+		t.Errorf("DeriveKey 无效路径: %v", err)
+	}
+}
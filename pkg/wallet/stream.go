@@ -0,0 +1,366 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tyler-smith/go-bip32"
+)
+
+// Options 钱包生成选项
+type Options struct {
+	Count          int
+	UseMnemonic    bool
+	Mnemonic       string // 可选：使用指定助记词
+	BasePath       string // 可选：助记词派生基础路径，默认 DefaultBasePath
+	StartIndex     int    // 可选：助记词派生起始索引
+	ConcurrentMode bool
+	WorkerCount    int
+
+	// Filter 可选：并发模式下只产出满足条件的钱包（如地址匹配），
+	// 每个钱包最多尝试 MaxAttempts 次（<=0 时为 10000 次）；
+	// 指定 Mnemonic 时每个索引的派生结果是确定的，Filter 不生效
+	Filter      func(MultiChainWallet) bool
+	MaxAttempts int
+
+	// ExtendedKeys 可选：填充 WIF，助记词模式下同时填充账户级 xprv/xpub 与 BIP49/84 的 ypub/zpub
+	ExtendedKeys bool
+	Network      string // 可选：WIF 与扩展密钥的网络，mainnet（默认）或 testnet
+
+	// ExtendedPublicKey 可选：观察钱包模式，从账户扩展公钥（xpub/ypub/zpub）按 BasePath/{index} 派生，
+	// BasePath 为相对路径（默认 DefaultWatchPath）且不能包含强化层级；此模式忽略 UseMnemonic 与并发设置
+	ExtendedPublicKey string
+}
+
+// basePath 获取助记词派生基础路径
+func (opts Options) basePath() string {
+	if opts.BasePath == "" {
+		return DefaultBasePath
+	}
+	return strings.TrimSuffix(opts.BasePath, "/")
+}
+
+// GenerationResult 生成结果
+type GenerationResult struct {
+	Count         int
+	Failed        int
+	Duration      time.Duration
+	AvgTime       time.Duration
+	WalletsPerSec float64
+}
+
+// GenerateWallets 生成钱包并全部返回
+// 结果全部保存在内存中，大批量生成请使用 Stream 或 GenerateBatch；
+// 单个钱包的失败会被跳过，并合并到返回的错误中
+func (wg *WalletGenerator) GenerateWallets(ctx context.Context, opts Options) ([]MultiChainWallet, error) {
+	var wallets []MultiChainWallet
+	var errs []error
+	for wallet, err := range wg.Stream(ctx, opts) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		wallets = append(wallets, wallet)
+	}
+	return wallets, errors.Join(errs...)
+}
+
+// GenerateBatch 批量生成钱包，逐个交给 handle 处理并返回结果统计
+// handle 为 nil 时只统计；onError 可选，接收单个钱包的生成错误；
+// handle 返回错误或 ctx 被取消时停止生成
+func (wg *WalletGenerator) GenerateBatch(ctx context.Context, opts Options, handle func(MultiChainWallet) error, onError func(error)) (*GenerationResult, error) {
+	result := &GenerationResult{}
+	start := time.Now()
+
+	var stopErr error
+	for wallet, err := range wg.Stream(ctx, opts) {
+		if err != nil {
+			if ctx.Err() != nil {
+				stopErr = err
+				break
+			}
+			if onError != nil {
+				onError(err)
+			}
+			result.Failed++
+			continue
+		}
+
+		if handle != nil {
+			if err := handle(wallet); err != nil {
+				stopErr = err
+				break
+			}
+		}
+		result.Count++
+	}
+
+	result.Duration = time.Since(start)
+	if result.Count > 0 {
+		result.AvgTime = result.Duration / time.Duration(result.Count)
+	}
+	result.WalletsPerSec = float64(result.Count) / result.Duration.Seconds()
+
+	return result, stopErr
+}
+
+// Stream 以流的方式生成钱包
+// 钱包在被消费时才继续生成（背压），ctx 取消或调用方停止迭代时所有协程随之退出。
+// 单个钱包生成失败时产出 *GenerateError 并继续；ctx 被取消时产出 ctx.Err() 后结束。
+func (wg *WalletGenerator) Stream(ctx context.Context, opts Options) iter.Seq2[MultiChainWallet, error] {
+	return func(yield func(MultiChainWallet, error) bool) {
+		if opts.ExtendedPublicKey != "" {
+			wg.streamWatchOnly(ctx, opts, yield)
+			return
+		}
+		if opts.ConcurrentMode && opts.Count > 1 {
+			wg.streamConcurrent(ctx, opts, yield)
+			return
+		}
+		wg.streamSequential(ctx, opts, yield)
+	}
+}
+
+// streamSequential 顺序生成钱包
+func (wg *WalletGenerator) streamSequential(ctx context.Context, opts Options, yield func(MultiChainWallet, error) bool) {
+	var masterMnemonic string
+
+	// 如果使用助记词且只生成一个钱包，生成新助记词
+	if opts.UseMnemonic && opts.Count == 1 && opts.Mnemonic == "" {
+		mnemonic, err := NewMnemonic()
+		if err != nil {
+			yield(MultiChainWallet{}, &GenerateError{Index: 0, Err: err})
+			return
+		}
+		masterMnemonic = mnemonic
+	} else if opts.Mnemonic != "" {
+		masterMnemonic = opts.Mnemonic
+	}
+
+	var source *mnemonicSource
+	if opts.UseMnemonic {
+		var err error
+		source, err = newMnemonicSource(masterMnemonic, opts)
+		if err != nil {
+			yield(MultiChainWallet{}, &GenerateError{Index: opts.StartIndex, Err: err})
+			return
+		}
+	}
+
+	for i := opts.StartIndex; i < opts.StartIndex+opts.Count; i++ {
+		if err := ctx.Err(); err != nil {
+			yield(MultiChainWallet{}, err)
+			return
+		}
+
+		var wallet MultiChainWallet
+		var err error
+
+		if source != nil {
+			wallet, err = wg.deriveWallet(source, i, opts)
+		} else {
+			wallet, err = wg.GenerateRandomWallet()
+			if err == nil && opts.ExtendedKeys {
+				wallet.WIF, err = EncodeWIF(wallet.PrivateKey, opts.Network, true)
+			}
+		}
+
+		if err != nil {
+			err = &GenerateError{Index: i, Err: err}
+		} else {
+			wallet.Index = i
+		}
+
+		if !yield(wallet, err) {
+			return
+		}
+	}
+}
+
+// mnemonicSource 指定助记词的派生源，主密钥与账户级扩展密钥只计算一次
+type mnemonicSource struct {
+	mnemonic    string
+	masterKey   *bip32.Key
+	basePath    string
+	accountKeys ExtendedKeys
+}
+
+// newMnemonicSource 由助记词创建派生源
+func newMnemonicSource(mnemonic string, opts Options) (*mnemonicSource, error) {
+	masterKey, err := MasterKeyFromMnemonic(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+	source := &mnemonicSource{mnemonic: mnemonic, masterKey: masterKey, basePath: opts.basePath()}
+	if opts.ExtendedKeys {
+		if source.accountKeys, err = AccountKeys(masterKey, source.basePath+"/0", opts.Network); err != nil {
+			return nil, err
+		}
+	}
+	return source, nil
+}
+
+// deriveWallet 按 BasePath/{index} 派生钱包，派生只读取主密钥，可在多个协程中并发调用
+func (wg *WalletGenerator) deriveWallet(source *mnemonicSource, index int, opts Options) (MultiChainWallet, error) {
+	wallet, err := wg.walletFromMasterKey(source.masterKey, source.mnemonic, fmt.Sprintf("%s/%d", source.basePath, index))
+	if err == nil && opts.ExtendedKeys {
+		wallet.ExtendedKeys = source.accountKeys
+		wallet.WIF, err = EncodeWIF(wallet.PrivateKey, opts.Network, true)
+	}
+	return wallet, err
+}
+
+// generateResult 并发生成的单个结果
+type generateResult struct {
+	wallet MultiChainWallet
+	err    error
+}
+
+// streamConcurrent 并发生成钱包
+// 指定助记词时与顺序模式相同，按 BasePath/{StartIndex+i} 派生；否则每个钱包随机生成
+func (wg *WalletGenerator) streamConcurrent(parent context.Context, opts Options, yield func(MultiChainWallet, error) bool) {
+	var source *mnemonicSource
+	if opts.UseMnemonic && opts.Mnemonic != "" {
+		var err error
+		source, err = newMnemonicSource(opts.Mnemonic, opts)
+		if err != nil {
+			yield(MultiChainWallet{}, &GenerateError{Index: opts.StartIndex, Err: err})
+			return
+		}
+	}
+
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	workerCount := opts.WorkerCount
+	if workerCount < 1 {
+		workerCount = 1
+	}
+
+	// 结果通道只缓冲与协程数相当的数量，消费变慢时生成随之放缓
+	jobs := make(chan int)
+	results := make(chan generateResult, workerCount)
+	var wgSync sync.WaitGroup
+
+	// 启动工作协程
+	for w := 0; w < workerCount; w++ {
+		wgSync.Add(1)
+		go func() {
+			defer wgSync.Done()
+			for index := range jobs {
+				var wallet MultiChainWallet
+				var err error
+				if source != nil {
+					wallet, err = wg.deriveWallet(source, index, opts)
+				} else {
+					wallet, err = wg.generateFilteredWallet(ctx, opts)
+				}
+				if err != nil {
+					err = &GenerateError{Index: index, Err: err}
+				} else {
+					wallet.Index = index
+				}
+
+				select {
+				case results <- generateResult{wallet: wallet, err: err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	// 发送任务
+	go func() {
+		defer close(jobs)
+		for i := opts.StartIndex; i < opts.StartIndex+opts.Count; i++ {
+			select {
+			case jobs <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// 等待所有协程完成
+	go func() {
+		wgSync.Wait()
+		close(results)
+	}()
+
+	// 逐个产出结果
+	stopped := false
+	for result := range results {
+		if ctx.Err() != nil {
+			break
+		}
+		if !yield(result.wallet, result.err) {
+			stopped = true
+			break
+		}
+	}
+
+	// 通知协程退出并等待其结束
+	cancel()
+	for range results {
+	}
+
+	// 外部 ctx 被取消时告知调用方生成未完成
+	if !stopped && parent.Err() != nil {
+		yield(MultiChainWallet{}, parent.Err())
+	}
+}
+
+// generateFilteredWallet 生成一个钱包；设置了 Filter 时循环生成直到满足条件
+func (wg *WalletGenerator) generateFilteredWallet(ctx context.Context, opts Options) (MultiChainWallet, error) {
+	maxAttempts := 1
+	if opts.Filter != nil {
+		maxAttempts = opts.MaxAttempts
+		if maxAttempts <= 0 {
+			maxAttempts = 10000 // 默认最大尝试次数
+		}
+	}
+
+	var wallet MultiChainWallet
+	var masterKey *bip32.Key
+	var err error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return MultiChainWallet{}, ctxErr
+		}
+
+		if opts.UseMnemonic {
+			// 每个钱包生成独立的助记词
+			var mnemonic string
+			mnemonic, err = NewMnemonic()
+			if err == nil {
+				masterKey, err = MasterKeyFromMnemonic(mnemonic)
+			}
+			if err == nil {
+				wallet, err = wg.walletFromMasterKey(masterKey, mnemonic, opts.basePath()+"/0")
+			}
+		} else {
+			wallet, err = wg.GenerateRandomWallet()
+		}
+
+		if err != nil {
+			continue
+		}
+
+		// 检查过滤条件
+		if opts.Filter == nil || opts.Filter(wallet) {
+			break
+		}
+	}
+
+	// 扩展字段只为最终产出的钱包计算
+	if err == nil && opts.ExtendedKeys {
+		err = AddExtendedKeys(&wallet, masterKey, opts.Network)
+	}
+	return wallet, err
+}
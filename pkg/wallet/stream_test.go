@@ -0,0 +1,186 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// testMnemonic BIP39 测试向量中的全零熵助记词
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+// waitGoroutines 等待协程数回落到 base，超时则失败
+func waitGoroutines(t *testing.T, base int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for runtime.NumGoroutine() > base {
+		if time.Now().After(deadline) {
+			t.Fatalf("协程未退出: %d > %d", runtime.NumGoroutine(), base)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestStreamSequentialMnemonic(t *testing.T) {
+	wg := NewWalletGenerator()
+	opts := Options{Count: 3, UseMnemonic: true, Mnemonic: testMnemonic, StartIndex: 1}
+	wallets, err := wg.GenerateWallets(context.Background(), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0",
+		"0xb6716976A3ebe8D39aCEB04372f22Ff8e6802D7A",
+		"0xF3f50213C1d2e255e4B2bAD430F8A38EEF8D718E",
+	}
+	for i, w := range wallets {
+		if w.Index != i+1 || w.EthAddress != want[i] || w.DerivePath == "" {
+			t.Errorf("钱包 %d = %d %s %s", i, w.Index, w.EthAddress, w.DerivePath)
+		}
+	}
+	if len(wallets) != len(want) {
+		t.Fatalf("生成 %d 个钱包, 期望 %d", len(wallets), len(want))
+	}
+}
+
+func TestStreamInvalidMnemonic(t *testing.T) {
+	wg := NewWalletGenerator()
+	_, err := wg.GenerateWallets(context.Background(), Options{Count: 2, UseMnemonic: true, Mnemonic: "abandon"})
+	var genErr *GenerateError
+	if !errors.As(err, &genErr) || !errors.Is(err, ErrInvalidMnemonic) {
+		t.Fatalf("错误 = %v, 期望 GenerateError 包装 ErrInvalidMnemonic", err)
+	}
+}
+
+func TestStreamConcurrentMnemonic(t *testing.T) {
+	wg := NewWalletGenerator()
+	opts := Options{Count: 3, UseMnemonic: true, Mnemonic: testMnemonic, StartIndex: 1, BasePath: "m/44'/60'/0'/0", ConcurrentMode: true, WorkerCount: 3}
+	wallets, err := wg.GenerateWallets(context.Background(), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[int]string{
+		1: "0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0",
+		2: "0xb6716976A3ebe8D39aCEB04372f22Ff8e6802D7A",
+		3: "0xF3f50213C1d2e255e4B2bAD430F8A38EEF8D718E",
+	}
+	if len(wallets) != len(want) {
+		t.Fatalf("生成 %d 个钱包, 期望 %d", len(wallets), len(want))
+	}
+	for _, w := range wallets {
+		if w.EthAddress != want[w.Index] || w.Mnemonic != testMnemonic || w.DerivePath != fmt.Sprintf("m/44'/60'/0'/0/%d", w.Index) {
+			t.Errorf("钱包 %d = %s %s", w.Index, w.EthAddress, w.DerivePath)
+		}
+		delete(want, w.Index)
+	}
+
+	// 并发模式与顺序模式派生结果一致
+	opts.BasePath = "m/44'/60'/1'/0"
+	concurrent, err := wg.GenerateWallets(context.Background(), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts.ConcurrentMode = false
+	sequential, err := wg.GenerateWallets(context.Background(), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byIndex := make(map[int]string)
+	for _, w := range sequential {
+		byIndex[w.Index] = w.EthAddress
+	}
+	for _, w := range concurrent {
+		if byIndex[w.Index] != w.EthAddress {
+			t.Errorf("索引 %d: 并发 %s, 顺序 %s", w.Index, w.EthAddress, byIndex[w.Index])
+		}
+	}
+
+	opts.ConcurrentMode = true
+	opts.Mnemonic = "abandon"
+	if _, err := wg.GenerateWallets(context.Background(), opts); !errors.Is(err, ErrInvalidMnemonic) {
+		t.Errorf("无效助记词: %v", err)
+	}
+}
+
+func TestStreamConcurrentBreak(t *testing.T) {
+	base := runtime.NumGoroutine()
+	wg := NewWalletGenerator()
+	seen := 0
+	for _, err := range wg.Stream(context.Background(), Options{Count: 1000, ConcurrentMode: true, WorkerCount: 4}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if seen++; seen == 5 {
+			break
+		}
+	}
+	if seen != 5 {
+		t.Fatalf("产出 %d 个钱包", seen)
+	}
+	waitGoroutines(t, base)
+}
+
+func TestStreamCancel(t *testing.T) {
+	for _, concurrent := range []bool{false, true} {
+		base := runtime.NumGoroutine()
+		ctx, cancel := context.WithCancel(context.Background())
+		wg := NewWalletGenerator()
+		count, last := 0, error(nil)
+		for _, err := range wg.Stream(ctx, Options{Count: 1 << 20, ConcurrentMode: concurrent, WorkerCount: 4}) {
+			if err != nil {
+				last = err
+				continue
+			}
+			if count++; count == 3 {
+				cancel()
+			}
+		}
+		cancel()
+		if !errors.Is(last, context.Canceled) {
+			t.Errorf("concurrent=%v: 最后的错误 = %v, 期望 context.Canceled", concurrent, last)
+		}
+		if count >= 1<<20 {
+			t.Errorf("concurrent=%v: 取消后仍生成了全部钱包", concurrent)
+		}
+		waitGoroutines(t, base)
+	}
+}
+
+func TestStreamConcurrentFilter(t *testing.T) {
+	wg := NewWalletGenerator()
+	filter := func(w MultiChainWallet) bool { return w.EthAddress[2] == 'a' }
+	wallets, err := wg.GenerateWallets(context.Background(), Options{
+		Count: 4, ConcurrentMode: true, WorkerCount: 2, Filter: filter, MaxAttempts: 100000,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	indexes := map[int]bool{}
+	for _, w := range wallets {
+		if !filter(w) {
+			t.Errorf("%s 不满足过滤条件", w.EthAddress)
+		}
+		indexes[w.Index] = true
+	}
+	if len(wallets) != 4 || len(indexes) != 4 {
+		t.Errorf("产出 %d 个钱包, %d 个不同序号", len(wallets), len(indexes))
+	}
+}
+
+func TestGenerateBatchStopsOnHandleError(t *testing.T) {
+	stop := errors.New("stop")
+	wg := NewWalletGenerator()
+	handled := 0
+	result, err := wg.GenerateBatch(context.Background(), Options{Count: 10}, func(MultiChainWallet) error {
+		if handled++; handled == 3 {
+			return stop
+		}
+		return nil
+	}, nil)
+	if !errors.Is(err, stop) || result.Count != 2 {
+		t.Fatalf("GenerateBatch = %d, %v", result.Count, err)
+	}
+}
@@ -0,0 +1,40 @@
+// Package wallet 提供多链钱包的生成与派生
+package wallet
+
+import (
+	"wallet_create_address/pkg/chain"
+)
+
+// MultiChainWallet 多链钱包结构
+type MultiChainWallet struct {
+	Index          int    `json:"index"`
+	Mnemonic       string `json:"mnemonic,omitempty"`
+	PrivateKey     string `json:"private_key,omitempty"` // 观察钱包为空
+	PublicKey      string `json:"public_key"`
+	DerivePath     string `json:"derive_path,omitempty"`
+	EthAddress     string `json:"eth_address"`
+	BtcAddress     string `json:"btc_address"`
+	BscAddress     string `json:"bsc_address"`
+	PolygonAddress string `json:"polygon_address"`
+	TronAddress    string `json:"tron_address"`
+
+	// ExtendedKeys 可选字段，仅在 Options.ExtendedKeys 为 true 时填充
+	ExtendedKeys
+}
+
+// Address 获取钱包在指定链上的地址
+func (w MultiChainWallet) Address(name string) (string, error) {
+	switch name {
+	case chain.ETH:
+		return w.EthAddress, nil
+	case chain.BTC:
+		return w.BtcAddress, nil
+	case chain.Tron:
+		return w.TronAddress, nil
+	case chain.BSC:
+		return w.BscAddress, nil
+	case chain.Polygon:
+		return w.PolygonAddress, nil
+	}
+	return "", &ChainError{Chain: name}
+}
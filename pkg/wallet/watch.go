@@ -0,0 +1,180 @@
+package wallet
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip32"
+
+	"wallet_create_address/pkg/chain"
+)
+
+// DefaultWatchPath 观察钱包默认的相对派生路径（外部链），完整路径为 DefaultWatchPath/{index}
+const DefaultWatchPath = "0"
+
+var (
+	// ErrPrivateExtendedKey 观察钱包只接受扩展公钥
+	ErrPrivateExtendedKey = errors.New("观察钱包只接受扩展公钥，不接受扩展私钥")
+	// ErrUnknownKeyVersion 扩展公钥的版本字节无法识别
+	ErrUnknownKeyVersion = errors.New("无法识别的扩展公钥版本（支持 xpub/ypub/zpub/tpub/upub/vpub）")
+	// ErrHardenedSegment 扩展公钥无法派生强化路径
+	ErrHardenedSegment = errors.New("扩展公钥只能派生非强化路径，强化层级（' 或 h）需要私钥")
+)
+
+// WatchKey 观察钱包使用的账户扩展公钥
+type WatchKey struct {
+	Key         *bip32.Key // 版本字节已规范为 xpub/tpub
+	Network     string     // mainnet 或 testnet
+	Purpose     int        // 44（xpub/tpub）、49（ypub/upub）、84（zpub/vpub）
+	AddressType string     // 对应的比特币地址类型
+}
+
+// purposeAddressTypes 扩展公钥用途对应的比特币地址类型
+var purposeAddressTypes = map[int]string{
+	44: chain.BTCP2PKH,
+	49: chain.BTCP2SHP2WPKH,
+	84: chain.BTCP2WPKH,
+}
+
+// ParseWatchKey 解析账户扩展公钥，根据 SLIP-132 版本字节识别网络和比特币地址类型
+func ParseWatchKey(s string) (*WatchKey, error) {
+	key, err := bip32.B58Deserialize(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("解析扩展公钥失败: %w", err)
+	}
+
+	for network, purposes := range extendedVersions {
+		for purpose, versions := range purposes {
+			if bytes.Equal(key.Version, versions.private) {
+				return nil, ErrPrivateExtendedKey
+			}
+			if !bytes.Equal(key.Version, versions.public) {
+				continue
+			}
+			if key.IsPrivate {
+				return nil, ErrPrivateExtendedKey
+			}
+			if _, err := crypto.DecompressPubkey(key.Key); err != nil {
+				return nil, fmt.Errorf("扩展公钥中的公钥无效: %w", err)
+			}
+			key.Version = extendedVersions[network][44].public
+			return &WatchKey{
+				Key:         key,
+				Network:     network,
+				Purpose:     purpose,
+				AddressType: purposeAddressTypes[purpose],
+			}, nil
+		}
+	}
+	return nil, ErrUnknownKeyVersion
+}
+
+// ParseWatchPath 解析相对于扩展公钥的路径（如 0/5），路径中出现强化层级时返回 ErrHardenedSegment
+func ParseWatchPath(path string) ([]uint32, error) {
+	indexes, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, index := range indexes {
+		if index >= bip32.FirstHardenedChild {
+			segment := strings.TrimPrefix(FormatPath([]uint32{index}), "m/")
+			return nil, &PathError{Path: path, Segment: segment, Err: ErrHardenedSegment}
+		}
+	}
+	return indexes, nil
+}
+
+// Derive 按相对路径（如 0/5）派生子公钥，路径中出现强化层级时返回 ErrHardenedSegment
+func (k *WatchKey) Derive(path string) (*bip32.Key, error) {
+	indexes, err := ParseWatchPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key := k.Key
+	for _, index := range indexes {
+		key, err = key.NewChildKey(index)
+		if err != nil {
+			return nil, &PathError{Path: path, Err: err}
+		}
+	}
+	return key, nil
+}
+
+// WatchOnlyWallet 从扩展公钥按相对路径派生观察钱包（只有公钥和地址）
+// 钱包的 DerivePath 为相对于扩展公钥的路径，以 M 开头，如 M/0/5
+func (wg *WalletGenerator) WatchOnlyWallet(key *WatchKey, path string) (MultiChainWallet, error) {
+	childKey, err := key.Derive(path)
+	if err != nil {
+		return MultiChainWallet{}, fmt.Errorf("派生公钥失败: %w", err)
+	}
+
+	publicKey, err := crypto.DecompressPubkey(childKey.Key)
+	if err != nil {
+		return MultiChainWallet{}, fmt.Errorf("解析公钥失败: %w", err)
+	}
+	params, err := NetworkParams(key.Network)
+	if err != nil {
+		return MultiChainWallet{}, err
+	}
+
+	indexes, _ := ParsePath(path)
+	return wg.createWalletFromPublicKey(publicKey, publicPath(indexes), key.AddressType, params)
+}
+
+// publicPath 格式化相对于扩展公钥的路径，如 M/0/5
+func publicPath(indexes []uint32) string {
+	return "M" + strings.TrimPrefix(FormatPath(indexes), "m")
+}
+
+// streamWatchOnly 从扩展公钥顺序派生观察钱包，BasePath 为相对路径，默认 DefaultWatchPath
+func (wg *WalletGenerator) streamWatchOnly(ctx context.Context, opts Options, yield func(MultiChainWallet, error) bool) {
+	key, err := ParseWatchKey(opts.ExtendedPublicKey)
+	if err != nil {
+		yield(MultiChainWallet{}, &GenerateError{Index: opts.StartIndex, Err: err})
+		return
+	}
+
+	basePath := strings.TrimSuffix(opts.BasePath, "/")
+	if basePath == "" {
+		basePath = DefaultWatchPath
+	}
+
+	// 基础路径只派生一次，逐个派生子地址
+	baseIndexes, err := ParseWatchPath(basePath)
+	if err != nil {
+		yield(MultiChainWallet{}, &GenerateError{Index: opts.StartIndex, Err: err})
+		return
+	}
+	base, err := key.Derive(basePath)
+	if err != nil {
+		yield(MultiChainWallet{}, &GenerateError{Index: opts.StartIndex, Err: err})
+		return
+	}
+	baseKey := *key
+	baseKey.Key = base
+
+	for i := opts.StartIndex; i < opts.StartIndex+opts.Count; i++ {
+		if err := ctx.Err(); err != nil {
+			yield(MultiChainWallet{}, err)
+			return
+		}
+
+		wallet, err := wg.WatchOnlyWallet(&baseKey, strconv.Itoa(i))
+		if err != nil {
+			err = &GenerateError{Index: i, Err: err}
+		} else {
+			wallet.Index = i
+			wallet.DerivePath = publicPath(append(baseIndexes[:len(baseIndexes):len(baseIndexes)], uint32(i)))
+		}
+
+		if !yield(wallet, err) {
+			return
+		}
+	}
+}
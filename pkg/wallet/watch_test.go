@@ -0,0 +1,93 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"wallet_create_address/pkg/chain"
+)
+
+// testZpub BIP84 测试向量中 m/84'/0'/0' 的 zpub
+const testZpub = "zpub6rFR7y4Q2AijBEqTUquhVz398htDFrtymD9xYYfG1m4wAcvPhXNfE3EfH1r1ADqtfSdVCToUG868RvUUkgDKf31mGDtKsAYz2oz2AGutZYs"
+
+func TestParseWatchKey(t *testing.T) {
+	key, err := ParseWatchKey(" " + testZpub + "\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key.Network != MainNet || key.Purpose != 84 || key.AddressType != chain.BTCP2WPKH {
+		t.Errorf("zpub = %s %d %s", key.Network, key.Purpose, key.AddressType)
+	}
+
+	key, err = ParseWatchKey("upub5EFU65HtV5TeiSHmZZm7FUffBGy8UKeqp7vw43jYbvZPpoVsgU93oac7Wk3u6moKegAEWtGNF8DehrnHtv21XXEMYRUocHqguyjknFHYfgY")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key.Network != TestNet || key.Purpose != 49 || key.AddressType != chain.BTCP2SHP2WPKH {
+		t.Errorf("upub = %s %d %s", key.Network, key.Purpose, key.AddressType)
+	}
+
+	masterKey := testMasterKey(t)
+	if _, err := ParseWatchKey(masterKey.B58Serialize()); !errors.Is(err, ErrPrivateExtendedKey) {
+		t.Errorf("xprv: %v", err)
+	}
+	if _, err := ParseWatchKey(SerializeExtendedKey(masterKey.PublicKey(), []byte{1, 2, 3, 4})); !errors.Is(err, ErrUnknownKeyVersion) {
+		t.Errorf("未知版本: %v", err)
+	}
+	if _, err := ParseWatchKey("xpub123"); err == nil {
+		t.Error("无效扩展公钥应返回错误")
+	}
+}
+
+func TestStreamWatchOnly(t *testing.T) {
+	wg := NewWalletGenerator()
+	ctx := context.Background()
+
+	// ETH 账户 xpub 派生的地址与助记词模式一致
+	account, _ := DeriveKey(testMasterKey(t), "m/44'/60'/0'")
+	watched, err := wg.GenerateWallets(ctx, Options{Count: 3, ExtendedPublicKey: account.PublicKey().B58Serialize()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	derived, err := wg.GenerateWallets(ctx, Options{Count: 3, UseMnemonic: true, Mnemonic: testMnemonic})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, w := range watched {
+		if w.EthAddress != derived[i].EthAddress || w.TronAddress != derived[i].TronAddress || w.PrivateKey != "" {
+			t.Errorf("钱包 %d = %+v", i, w)
+		}
+		if want := publicPath([]uint32{0, uint32(i)}); w.DerivePath != want || w.Index != i {
+			t.Errorf("钱包 %d 路径 = %s", i, w.DerivePath)
+		}
+	}
+
+	// zpub 派生 bc1q 地址（BIP84 测试向量 m/84'/0'/0'/0/0 与 m/84'/0'/0'/1/0）
+	for basePath, want := range map[string]string{
+		"":  "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu",
+		"1": "bc1q8c6fshw2dlwun7ekn9qwf37cu2rn755upcp6el",
+	} {
+		wallets, err := wg.GenerateWallets(ctx, Options{Count: 1, BasePath: basePath, ExtendedPublicKey: testZpub})
+		if err != nil || len(wallets) != 1 || wallets[0].BtcAddress != want {
+			t.Errorf("BasePath %q: %+v, %v", basePath, wallets, err)
+		}
+	}
+}
+
+func TestWatchOnlyHardenedPath(t *testing.T) {
+	wg := NewWalletGenerator()
+	_, err := wg.GenerateWallets(context.Background(), Options{Count: 1, BasePath: "0/1'", ExtendedPublicKey: testZpub})
+	var pathErr *PathError
+	if !errors.Is(err, ErrHardenedSegment) || !errors.As(err, &pathErr) || pathErr.Segment != "1'" {
+		t.Errorf("强化路径: %v", err)
+	}
+
+	key, _ := ParseWatchKey(testZpub)
+	if _, err := key.Derive("0h"); !errors.Is(err, ErrHardenedSegment) {
+		t.Errorf("Derive(0h): %v", err)
+	}
+	if _, err := wg.WatchOnlyWallet(key, "0/x"); err == nil {
+		t.Error("无效路径应返回错误")
+	}
+}
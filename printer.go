@@ -2,10 +2,15 @@ package main
 
 import (
 	"fmt"
+	"io"
+	"os"
+
+	"wallet_create_address/pkg/matcher"
+	"wallet_create_address/pkg/wallet"
 )
 
 // PrintWallet 打印完整钱包信息
-func PrintWallet(wallet MultiChainWallet) {
+func PrintWallet(wallet wallet.MultiChainWallet) {
 	fmt.Println("\n🔐 钱包信息")
 	fmt.Println("=============================================================")
 
@@ -15,7 +20,9 @@ func PrintWallet(wallet MultiChainWallet) {
 		fmt.Println("-------------------------------------------------------------")
 	}
 
-	fmt.Printf("私钥 (Private Key): %s\n", wallet.PrivateKey)
+	if wallet.PrivateKey != "" {
+		fmt.Printf("私钥 (Private Key): %s\n", wallet.PrivateKey)
+	}
 	fmt.Printf("公钥 (Public Key):  %s\n", wallet.PublicKey)
 	fmt.Println("-------------------------------------------------------------")
 	fmt.Printf("🔹 Ethereum:  %s\n", wallet.EthAddress)
@@ -23,22 +30,86 @@ func PrintWallet(wallet MultiChainWallet) {
 	fmt.Printf("🔹 BSC:       %s\n", wallet.BscAddress)
 	fmt.Printf("🔹 Polygon:   %s\n", wallet.PolygonAddress)
 	fmt.Printf("🔹 Tron:      %s\n", wallet.TronAddress)
+	if wallet.WIF != "" {
+		fmt.Println("-------------------------------------------------------------")
+		fmt.Printf("WIF:               %s\n", wallet.WIF)
+	}
+	if wallet.AccountXpub != "" {
+		fmt.Printf("账户路径:          %s\n", wallet.AccountPath)
+		fmt.Printf("账户 xprv:         %s\n", wallet.AccountXprv)
+		fmt.Printf("账户 xpub:         %s\n", wallet.AccountXpub)
+	}
+	if wallet.BIP49Ypub != "" {
+		fmt.Printf("BIP49 ypub:        %s\n", wallet.BIP49Ypub)
+		fmt.Printf("BIP84 zpub:        %s\n", wallet.BIP84Zpub)
+	}
 	fmt.Println("=============================================================")
 	fmt.Println("⚠️  请安全保存私钥和助记词!")
 }
 
+// PrintKeyInfo 打印私钥的全部地址与编码
+func PrintKeyInfo(info wallet.KeyInfo) {
+	fmt.Println("\n🔑 私钥信息")
+	fmt.Println("=============================================================")
+	if info.Mnemonic != "" {
+		fmt.Printf("助记词:            %s\n", info.Mnemonic)
+		fmt.Printf("派生路径:          %s\n", info.DerivePath)
+		fmt.Println("-------------------------------------------------------------")
+	}
+	fmt.Printf("私钥:              %s\n", info.PrivateKey)
+	fmt.Printf("WIF (压缩):        %s\n", info.WIF)
+	fmt.Printf("WIF (未压缩):      %s\n", info.WIFUncompressed)
+	fmt.Printf("公钥 (压缩):       %s\n", info.PublicKey)
+	fmt.Printf("公钥 (未压缩):     %s\n", info.PublicKeyUncompressed)
+	fmt.Println("-------------------------------------------------------------")
+	fmt.Printf("🔹 Ethereum/BSC/Polygon: %s\n", info.EthAddress)
+	fmt.Printf("🔹 Tron:                 %s\n", info.TronAddress)
+	fmt.Printf("🔹 Tron (hex):           %s\n", info.TronHexAddress)
+	fmt.Printf("🔹 BTC P2PKH:            %s\n", info.BtcP2PKH)
+	fmt.Printf("🔹 BTC P2PKH (未压缩):   %s\n", info.BtcP2PKHUncompressed)
+	fmt.Printf("🔹 BTC P2SH-P2WPKH:      %s\n", info.BtcP2SHP2WPKH)
+	fmt.Printf("🔹 BTC P2WPKH:           %s\n", info.BtcP2WPKH)
+	fmt.Printf("🔹 BTC P2TR:             %s\n", info.BtcP2TR)
+	if info.Network != wallet.MainNet {
+		fmt.Printf("（比特币地址与 WIF 为 %s）\n", info.Network)
+	}
+	fmt.Println("=============================================================")
+	fmt.Println("⚠️  请安全保存私钥!")
+}
+
 // PrintWalletSimple 打印简化钱包信息
-func PrintWalletSimple(wallet MultiChainWallet) {
+func PrintWalletSimple(wallet wallet.MultiChainWallet) {
 	fmt.Printf("\n💼 钱包 #%d\n", wallet.Index+1)
 	if wallet.DerivePath != "" {
 		fmt.Printf("路径: %s\n", wallet.DerivePath)
 	}
 	if wallet.Mnemonic != "" {
 		fmt.Printf("助记词: %s\n", wallet.Mnemonic)
-	} else {
+	} else if wallet.PrivateKey != "" {
 		fmt.Printf("私钥: %s\n", wallet.PrivateKey)
 	}
+	if wallet.WIF != "" {
+		fmt.Printf("WIF: %s\n", wallet.WIF)
+	}
 	fmt.Printf("ETH: %s\n", wallet.EthAddress)
 	fmt.Printf("BTC: %s\n", wallet.BtcAddress)
 	fmt.Printf("TRX: %s\n", wallet.TronAddress)
 }
+
+// PrintMatchStats 打印地址匹配统计信息
+func PrintMatchStats(stats matcher.Stats) {
+	FprintMatchStats(os.Stdout, stats)
+}
+
+// FprintMatchStats 将地址匹配统计信息输出到 w
+func FprintMatchStats(w io.Writer, stats matcher.Stats) {
+	fmt.Fprintf(w, "\n📊 地址匹配统计:\n")
+	fmt.Fprintf(w, "尝试次数: %d\n", stats.Attempts)
+	fmt.Fprintf(w, "匹配成功: %d\n", stats.Matched)
+	fmt.Fprintf(w, "匹配率: %.2f%%\n", stats.Rate)
+	fmt.Fprintf(w, "运行时间: %v\n", stats.Duration)
+
+	if stats.Attempts > 0 {
+		fmt.Fprintf(w, "平均速度: %.2f 次/秒\n", float64(stats.Attempts)/stats.Duration.Seconds())
+	}
+}